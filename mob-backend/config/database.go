@@ -8,14 +8,83 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
-	"finance-backend/models"
+	"finance-backend/migrations"
 	"finance-backend/utils"
 )
 
 var DB *gorm.DB
 
+// Modos de execução de migrations na inicialização (DB_MIGRATION_MODE)
+const (
+	MigrationModeAuto   = "auto"   // aplica migrations pendentes ao subir
+	MigrationModeStrict = "strict" // recusa subir se houver migrations pendentes
+	MigrationModeOff    = "off"    // não verifica migrations
+)
+
+// InitDB conecta no banco e trata migrations conforme DB_MIGRATION_MODE
 func InitDB() {
 	log := utils.GetLogger()
+
+	ConnectDB()
+
+	mode := getEnv("DB_MIGRATION_MODE", MigrationModeAuto)
+
+	switch mode {
+	case MigrationModeOff:
+		log.Warning("Verificação de migrations desativada", nil)
+		return
+	case MigrationModeAuto, MigrationModeStrict:
+	default:
+		log.Fatal("DB_MIGRATION_MODE inválido", map[string]interface{}{
+			"mode": mode,
+		})
+	}
+
+	migrator, err := migrations.NewMigrator(DB)
+	if err != nil {
+		log.Fatal("Erro ao carregar migrations", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if err := migrator.Verify(); err != nil {
+		log.Fatal("Migrations aplicadas divergem do binário", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if mode == MigrationModeStrict {
+		pending, err := migrator.Pending()
+		if err != nil {
+			log.Fatal("Erro ao verificar migrations pendentes", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		if len(pending) > 0 {
+			log.Fatal("Existem migrations pendentes. Execute './main migrate up' antes de iniciar", map[string]interface{}{
+				"pending":      len(pending),
+				"next_version": pending[0].Version,
+			})
+		}
+		log.Info("Schema do banco atualizado", nil)
+		return
+	}
+
+	applied, err := migrator.Up()
+	if err != nil {
+		log.Fatal("Erro ao executar migrations", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	log.Info("Migrations executadas com sucesso", map[string]interface{}{
+		"applied": len(applied),
+	})
+}
+
+// ConnectDB abre a conexão com o banco sem executar migrations
+func ConnectDB() {
+	log := utils.GetLogger()
 	
 	// Lê variáveis de ambiente
 	dbHost := getEnv("DB_HOST", "localhost")
@@ -53,34 +122,6 @@ func InitDB() {
 		"database": dbName,
 		"host":     dbHost,
 	})
-
-	// AutoMigrate dos models
-	err = DB.AutoMigrate(
-		&models.User{},
-		&models.MesData{},
-		&models.Gasto{},
-		// Novos models
-		&models.FamilyAccount{},
-		&models.FamilyMember{},
-		&models.Income{},
-		&models.ExpenseCategory{},
-		&models.Expense{},
-		&models.ExpenseSplit{},
-		&models.Investment{},
-		&models.EmergencyFund{},
-		// Tax configuration models
-		&models.INSSBracket{},
-		&models.IRPFBracket{},
-		&models.TaxConfiguration{},
-	)
-	
-	if err != nil {
-		log.Fatal("Erro ao executar migrations", map[string]interface{}{
-			"error": err.Error(),
-		})
-	}
-	
-	log.Info("Migrations executadas com sucesso", nil)
 }
 
 // getEnv retorna o valor da variável de ambiente ou o valor padrão
@@ -1,19 +1,27 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
-	"time"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
-	"github.com/golang-jwt/jwt/v5"
 
-	"finance-backend/config"
 	"finance-backend/models"
+	"finance-backend/services"
 	"finance-backend/utils"
 )
 
-func Register(c *gin.Context) {
+type AuthController struct {
+	authService *services.AuthService
+}
+
+func NewAuthController(authService *services.AuthService) *AuthController {
+	return &AuthController{authService: authService}
+}
+
+// Register cria um novo usuário e abre uma sessão
+func (ctrl *AuthController) Register(c *gin.Context) {
 	var body struct {
 		Name     string `json:"name"`
 		Email    string `json:"email"`
@@ -25,39 +33,25 @@ func Register(c *gin.Context) {
 		return
 	}
 
-	// Usar email como username se username não for fornecido
-	username := body.Username
-	if username == "" {
-		username = body.Email
-	}
-
-	hash, _ := bcrypt.GenerateFromPassword([]byte(body.Password), 12)
-	user := models.User{Username: username, Password: string(hash)}
-
-	if err := config.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Usuário já existe"})
+	user, tokens, err := ctrl.authService.Register(body.Name, body.Email, body.Username, body.Password, sessionClient(c))
+	if err != nil {
+		if validationErr, ok := err.(utils.ValidationErrors); ok {
+			utils.ValidationErrorResponse(c, validationErr)
+			return
+		}
+		if errors.Is(err, services.ErrUserAlreadyExists) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Usuário já existe"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar usuário"})
 		return
 	}
 
-	// Gerar token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
-	})
-	tokenString, _ := token.SignedString(utils.JwtSecret)
-
-	c.JSON(http.StatusOK, gin.H{
-		"token": tokenString,
-		"user": gin.H{
-			"id":         user.ID,
-			"name":       username,
-			"email":      body.Email,
-			"created_at": user.CreatedAt,
-		},
-	})
+	c.JSON(http.StatusOK, authResponse(user, tokens))
 }
 
-func Login(c *gin.Context) {
+// Login autentica o usuário e abre uma sessão para o dispositivo
+func (ctrl *AuthController) Login(c *gin.Context) {
 	var body struct {
 		Email    string `json:"email"`
 		Username string `json:"username"`
@@ -74,30 +68,140 @@ func Login(c *gin.Context) {
 		username = body.Email
 	}
 
-	var user models.User
-	if err := config.DB.Where("username = ?", username).First(&user).Error; err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário ou senha inválidos"})
+	user, tokens, err := ctrl.authService.Login(username, body.Password, sessionClient(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário ou senha inválidos"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao autenticar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponse(user, tokens))
+}
+
+// Refresh troca um refresh token válido por um novo par de tokens
+func (ctrl *AuthController) Refresh(c *gin.Context) {
+	var body struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token é obrigatório"})
+		return
+	}
+
+	tokens, err := ctrl.authService.Refresh(body.RefreshToken, sessionClient(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) || errors.Is(err, services.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao renovar sessão"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse(tokens))
+}
+
+// Logout revoga a sessão atual
+func (ctrl *AuthController) Logout(c *gin.Context) {
+	sessionID := c.GetUint("session_id")
+
+	if err := ctrl.authService.Logout(sessionID); err != nil {
+		utils.InternalErrorResponse(c, "Erro ao encerrar sessão")
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Sessão encerrada com sucesso", nil)
+}
+
+// GetSessions lista as sessões ativas do usuário (uma por dispositivo)
+func (ctrl *AuthController) GetSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	currentSessionID := c.GetUint("session_id")
+
+	sessions, err := ctrl.authService.GetActiveSessions(userID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar sessões")
 		return
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(body.Password)) != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário ou senha inválidos"})
+	type sessionView struct {
+		models.Session
+		Current bool `json:"current"`
+	}
+
+	result := make([]sessionView, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, sessionView{Session: session, Current: session.ID == currentSessionID})
+	}
+
+	utils.SuccessResponse(c, 200, result)
+}
+
+// RevokeSession encerra a sessão de um dispositivo
+func (ctrl *AuthController) RevokeSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	sessionID, err := strconv.ParseUint(c.Param("sessionId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID de sessão inválido")
 		return
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
-	})
-	tokenString, _ := token.SignedString(utils.JwtSecret)
-
-	c.JSON(http.StatusOK, gin.H{
-		"token": tokenString,
-		"user": gin.H{
-			"id":         user.ID,
-			"name":       user.Username,
-			"email":      user.Username,
-			"created_at": user.CreatedAt,
-		},
-	})
+	if err := ctrl.authService.RevokeSession(userID, uint(sessionID)); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			utils.NotFoundResponse(c, "Sessão")
+			return
+		}
+		utils.InternalErrorResponse(c, "Erro ao revogar sessão")
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Sessão revogada com sucesso", nil)
+}
+
+// RevokeOtherSessions encerra todas as sessões do usuário, exceto a atual
+func (ctrl *AuthController) RevokeOtherSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	currentSessionID := c.GetUint("session_id")
+
+	revoked, err := ctrl.authService.RevokeOtherSessions(userID, currentSessionID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao revogar sessões")
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Sessões revogadas com sucesso", gin.H{"revoked": revoked})
+}
+
+func sessionClient(c *gin.Context) services.SessionClient {
+	return services.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// tokenResponse mantém o campo "token" (access token) por compatibilidade com clientes antigos
+func tokenResponse(tokens *services.AuthTokens) gin.H {
+	return gin.H{
+		"token":                    tokens.AccessToken,
+		"access_token":             tokens.AccessToken,
+		"access_token_expires_at":  tokens.AccessTokenExpiresAt,
+		"refresh_token":            tokens.RefreshToken,
+		"refresh_token_expires_at": tokens.RefreshTokenExpiresAt,
+		"session_id":               tokens.SessionID,
+	}
+}
+
+func authResponse(user *models.User, tokens *services.AuthTokens) gin.H {
+	response := tokenResponse(tokens)
+	response["user"] = gin.H{
+		"id":         user.ID,
+		"name":       user.Name,
+		"email":      user.Email,
+		"created_at": user.CreatedAt,
+	}
+	return response
 }
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type BudgetController struct {
+	budgetService *services.BudgetService
+}
+
+func NewBudgetController(budgetService *services.BudgetService) *BudgetController {
+	return &BudgetController{budgetService: budgetService}
+}
+
+// GetBudgetReport retorna planejado x realizado x restante por categoria no mês (/budgets/:month, YYYY-MM)
+func (ctrl *BudgetController) GetBudgetReport(c *gin.Context) {
+	month, year, ok := parseBudgetMonth(c)
+	if !ok {
+		return
+	}
+
+	report, err := ctrl.budgetService.GetBudgetReport(c.GetUint("family_id"), month, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao calcular orçamento")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, report)
+}
+
+// SetBudgets define os orçamentos das categorias a partir do mês (valem até serem redefinidos)
+func (ctrl *BudgetController) SetBudgets(c *gin.Context) {
+	month, year, ok := parseBudgetMonth(c)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Budgets []services.BudgetInput `json:"budgets"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	report, err := ctrl.budgetService.SetBudgets(c.GetUint("family_id"), month, year, input.Budgets)
+	if err != nil {
+		handleBudgetError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Orçamento atualizado com sucesso", report)
+}
+
+// DeleteBudget remove o orçamento de uma categoria definido no mês
+func (ctrl *BudgetController) DeleteBudget(c *gin.Context) {
+	month, year, ok := parseBudgetMonth(c)
+	if !ok {
+		return
+	}
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.budgetService.DeleteBudget(c.GetUint("family_id"), categoryID, month, year); err != nil {
+		handleBudgetError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Orçamento removido com sucesso", nil)
+}
+
+func parseBudgetMonth(c *gin.Context) (int, int, bool) {
+	month, year := 0, 0
+	_, err := fmt.Sscanf(c.Param("month"), "%d-%d", &year, &month)
+	if err != nil || month < 1 || month > 12 || year < 2000 {
+		utils.ErrorResponse(c, 400, "Formato de mês inválido. Use YYYY-MM (ex: 2024-03)")
+		return 0, 0, false
+	}
+	return month, year, true
+}
+
+func handleBudgetError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, services.ErrBudgetNotFound) {
+		utils.NotFoundResponse(c, "Orçamento")
+		return
+	}
+	utils.ErrorResponse(c, 400, err.Error())
+}
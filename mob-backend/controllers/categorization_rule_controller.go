@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type CategorizationRuleController struct {
+	categorizationService *services.CategorizationService
+}
+
+func NewCategorizationRuleController(categorizationService *services.CategorizationService) *CategorizationRuleController {
+	return &CategorizationRuleController{categorizationService: categorizationService}
+}
+
+// CreateRule cria uma regra de categorização
+func (ctrl *CategorizationRuleController) CreateRule(c *gin.Context) {
+	var input services.RuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	rule, err := ctrl.categorizationService.CreateRule(c.GetUint("family_id"), input)
+	if err != nil {
+		handleRuleError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 201, "Regra criada com sucesso", rule)
+}
+
+// GetRules lista as regras da família em ordem de prioridade
+func (ctrl *CategorizationRuleController) GetRules(c *gin.Context) {
+	rules, err := ctrl.categorizationService.GetRules(c.GetUint("family_id"))
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar regras")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, rules)
+}
+
+// GetRule busca uma regra
+func (ctrl *CategorizationRuleController) GetRule(c *gin.Context) {
+	ruleID, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := ctrl.categorizationService.GetRule(c.GetUint("family_id"), ruleID)
+	if err != nil {
+		handleRuleError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, rule)
+}
+
+// UpdateRule atualiza uma regra
+func (ctrl *CategorizationRuleController) UpdateRule(c *gin.Context) {
+	ruleID, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	var input services.RuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	rule, err := ctrl.categorizationService.UpdateRule(c.GetUint("family_id"), ruleID, input)
+	if err != nil {
+		handleRuleError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Regra atualizada com sucesso", rule)
+}
+
+// DeleteRule exclui uma regra
+func (ctrl *CategorizationRuleController) DeleteRule(c *gin.Context) {
+	ruleID, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.categorizationService.DeleteRule(c.GetUint("family_id"), ruleID); err != nil {
+		handleRuleError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Regra excluída com sucesso", nil)
+}
+
+// PreviewRule mostra quais despesas existentes a regra enviada alteraria
+func (ctrl *CategorizationRuleController) PreviewRule(c *gin.Context) {
+	var input services.RuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	preview, err := ctrl.categorizationService.PreviewRule(c.GetUint("family_id"), input)
+	if err != nil {
+		handleRuleError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, preview)
+}
+
+func parseRuleID(c *gin.Context) (uint, bool) {
+	ruleID, err := strconv.ParseUint(c.Param("ruleId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID da regra inválido")
+		return 0, false
+	}
+	return uint(ruleID), true
+}
+
+func handleRuleError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, services.ErrRuleNotFound) {
+		utils.NotFoundResponse(c, "Regra")
+		return
+	}
+	utils.ErrorResponse(c, 400, err.Error())
+}
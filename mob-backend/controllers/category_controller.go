@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type CategoryController struct {
+	categoryService *services.CategoryService
+}
+
+func NewCategoryController(categoryService *services.CategoryService) *CategoryController {
+	return &CategoryController{categoryService: categoryService}
+}
+
+// GetCategories lista as categorias da família (padrão + personalizadas).
+// Com ?view=tree retorna as categorias de primeiro nível com suas subcategorias.
+func (ctrl *CategoryController) GetCategories(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+
+	getCategories := ctrl.categoryService.GetCategories
+	if c.Query("view") == "tree" {
+		getCategories = ctrl.categoryService.GetCategoryTree
+	}
+
+	categories, err := getCategories(familyID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar categorias")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, categories)
+}
+
+// GetCategory busca uma categoria
+func (ctrl *CategoryController) GetCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	category, err := ctrl.categoryService.GetCategory(c.GetUint("family_id"), categoryID)
+	if err != nil {
+		handleCategoryError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, category)
+}
+
+// CreateCategory cria uma categoria ou subcategoria da família
+func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
+	var input services.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	category, err := ctrl.categoryService.CreateCategory(c.GetUint("family_id"), input)
+	if err != nil {
+		handleCategoryError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 201, "Categoria criada com sucesso", category)
+}
+
+// UpdateCategory atualiza uma categoria da família
+func (ctrl *CategoryController) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var input services.CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	category, err := ctrl.categoryService.UpdateCategory(c.GetUint("family_id"), categoryID, input)
+	if err != nil {
+		handleCategoryError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Categoria atualizada com sucesso", category)
+}
+
+// DeleteCategory exclui uma categoria da família (?reassign_to=ID move os lançamentos antes)
+func (ctrl *CategoryController) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	reassignTo := uint64(0)
+	if value := c.Query("reassign_to"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, 400, "reassign_to inválido")
+			return
+		}
+		reassignTo = parsed
+	}
+
+	deletion, err := ctrl.categoryService.DeleteCategory(c.GetUint("family_id"), categoryID, uint(reassignTo))
+	if err != nil {
+		handleCategoryError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Categoria excluída com sucesso", deletion)
+}
+
+func parseCategoryID(c *gin.Context) (uint, bool) {
+	categoryID, err := strconv.ParseUint(c.Param("categoryId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID da categoria inválido")
+		return 0, false
+	}
+	return uint(categoryID), true
+}
+
+func handleCategoryError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrCategoryNotFound):
+		utils.NotFoundResponse(c, "Categoria")
+	case errors.Is(err, services.ErrDefaultCategoryReadOnly):
+		utils.ForbiddenResponse(c, err.Error())
+	case errors.Is(err, services.ErrCategoryNameTaken), errors.Is(err, services.ErrCategoryInUse):
+		utils.ErrorResponse(c, 409, err.Error())
+	default:
+		utils.ErrorResponse(c, 400, err.Error())
+	}
+}
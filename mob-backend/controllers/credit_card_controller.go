@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"finance-backend/models"
+	"finance-backend/services"
+	"finance-backend/utils"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CreditCardController struct {
+	creditCardService *services.CreditCardService
+}
+
+func NewCreditCardController(creditCardService *services.CreditCardService) *CreditCardController {
+	return &CreditCardController{creditCardService: creditCardService}
+}
+
+// CreateCard cria um cartão de crédito
+func (ctrl *CreditCardController) CreateCard(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	
+	var input struct {
+		Name           string `json:"name"`
+		LastFourDigits string `json:"last_four_digits"`
+		ClosingDay     int    `json:"closing_day"`
+		DueDay         int    `json:"due_day"`
+		LimitCents     int64  `json:"limit_cents"`
+	}
+	
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	
+	card := &models.CreditCard{
+		FamilyAccountID: familyID,
+		Name:            input.Name,
+		LastFourDigits:  input.LastFourDigits,
+		ClosingDay:      input.ClosingDay,
+		DueDay:          input.DueDay,
+		LimitCents:      input.LimitCents,
+		IsActive:        true,
+	}
+	
+	if err := ctrl.creditCardService.CreateCard(card); err != nil {
+		if validationErr, ok := err.(utils.ValidationErrors); ok {
+			utils.ValidationErrorResponse(c, validationErr)
+			return
+		}
+		utils.ErrorResponse(c, 400, err.Error())
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 201, "Cartão criado com sucesso", card)
+}
+
+// GetCards lista os cartões da família
+func (ctrl *CreditCardController) GetCards(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	
+	cards, err := ctrl.creditCardService.GetCardsByFamilyID(familyID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar cartões")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, cards)
+}
+
+// GetCard busca um cartão
+func (ctrl *CreditCardController) GetCard(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	card, err := ctrl.creditCardService.GetCard(c.GetUint("family_id"), uint(cardID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, card)
+}
+
+// UpdateCard atualiza um cartão
+func (ctrl *CreditCardController) UpdateCard(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	card, err := ctrl.creditCardService.GetCard(c.GetUint("family_id"), uint(cardID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	var input struct {
+		Name           string `json:"name"`
+		LastFourDigits string `json:"last_four_digits"`
+		ClosingDay     int    `json:"closing_day"`
+		DueDay         int    `json:"due_day"`
+		LimitCents     *int64 `json:"limit_cents"`
+	}
+	
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	
+	if input.Name != "" {
+		card.Name = input.Name
+	}
+	if input.LastFourDigits != "" {
+		card.LastFourDigits = input.LastFourDigits
+	}
+	if input.ClosingDay > 0 {
+		card.ClosingDay = input.ClosingDay
+	}
+	if input.DueDay > 0 {
+		card.DueDay = input.DueDay
+	}
+	if input.LimitCents != nil {
+		card.LimitCents = *input.LimitCents
+	}
+	
+	if err := ctrl.creditCardService.UpdateCard(card); err != nil {
+		if validationErr, ok := err.(utils.ValidationErrors); ok {
+			utils.ValidationErrorResponse(c, validationErr)
+			return
+		}
+		utils.ErrorResponse(c, 400, err.Error())
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Cartão atualizado com sucesso", card)
+}
+
+// DeleteCard exclui um cartão
+func (ctrl *CreditCardController) DeleteCard(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	if err := ctrl.creditCardService.DeleteCard(c.GetUint("family_id"), uint(cardID)); err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Cartão excluído com sucesso", nil)
+}
+
+// CreatePurchase registra uma compra (à vista ou parcelada) no cartão
+func (ctrl *CreditCardController) CreatePurchase(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	var input services.PurchaseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	
+	purchase, err := ctrl.creditCardService.CreatePurchase(c.GetUint("family_id"), uint(cardID), input)
+	if err != nil {
+		if validationErr, ok := err.(utils.ValidationErrors); ok {
+			utils.ValidationErrorResponse(c, validationErr)
+			return
+		}
+		utils.ErrorResponse(c, 400, err.Error())
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 201, "Compra registrada com sucesso", purchase)
+}
+
+// GetPurchases lista as compras do cartão
+func (ctrl *CreditCardController) GetPurchases(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	purchases, err := ctrl.creditCardService.GetPurchases(c.GetUint("family_id"), uint(cardID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, purchases)
+}
+
+// DeletePurchase cancela uma compra e suas parcelas
+func (ctrl *CreditCardController) DeletePurchase(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	purchaseID, err := strconv.ParseUint(c.Param("purchaseId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID da compra inválido")
+		return
+	}
+	
+	if err := ctrl.creditCardService.DeletePurchase(c.GetUint("family_id"), uint(cardID), uint(purchaseID)); err != nil {
+		utils.NotFoundResponse(c, "Compra")
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Compra excluída com sucesso", nil)
+}
+
+// GetStatements lista as faturas do cartão com o uso do limite
+func (ctrl *CreditCardController) GetStatements(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	statements, err := ctrl.creditCardService.GetStatements(c.GetUint("family_id"), uint(cardID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, statements)
+}
+
+// GetStatement retorna a fatura de um mês (YYYY-MM do vencimento)
+func (ctrl *CreditCardController) GetStatement(c *gin.Context) {
+	cardID, err := strconv.ParseUint(c.Param("cardId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do cartão inválido")
+		return
+	}
+	
+	month, year := 0, 0
+	_, parseErr := fmt.Sscanf(c.Param("month"), "%d-%d", &year, &month)
+	if parseErr != nil || month < 1 || month > 12 || year < 2000 {
+		utils.ErrorResponse(c, 400, "Formato de mês inválido. Use YYYY-MM (ex: 2024-03)")
+		return
+	}
+	
+	statement, err := ctrl.creditCardService.GetStatement(c.GetUint("family_id"), uint(cardID), month, year)
+	if err != nil {
+		utils.NotFoundResponse(c, "Cartão")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, statement)
+}
@@ -4,6 +4,7 @@ import (
 	"finance-backend/services"
 	"finance-backend/utils"
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +14,7 @@ type DashboardController struct {
 	expenseService    *services.ExpenseService
 	investmentService *services.InvestmentService
 	emergencyService  *services.EmergencyFundService
+	budgetService     *services.BudgetService
 }
 
 func NewDashboardController(
@@ -20,12 +22,14 @@ func NewDashboardController(
 	expenseService *services.ExpenseService,
 	investmentService *services.InvestmentService,
 	emergencyService *services.EmergencyFundService,
+	budgetService *services.BudgetService,
 ) *DashboardController {
 	return &DashboardController{
 		incomeService:     incomeService,
 		expenseService:    expenseService,
 		investmentService: investmentService,
 		emergencyService:  emergencyService,
+		budgetService:     budgetService,
 	}
 }
 
@@ -78,8 +82,28 @@ func (ctrl *DashboardController) GetDashboard(c *gin.Context) {
 	healthScore := ctrl.calculateFinancialHealth(incomeSummary, expensesSummary, investmentsSummary, emergencyProgress)
 	dashboard["financial_health_score"] = healthScore
 	
+	// Orçamento por categoria (sem mês informado, usa o mês corrente)
+	budgetMonth, budgetYear := month, year
+	if budgetMonth == 0 {
+		now := time.Now()
+		budgetMonth, budgetYear = int(now.Month()), now.Year()
+	}
+	budgetReport, err := ctrl.budgetService.GetBudgetReport(familyID, budgetMonth, budgetYear)
+	if err != nil {
+		utils.Error("Erro ao calcular orçamento do dashboard", map[string]interface{}{
+			"family_id": familyID,
+			"error":     err.Error(),
+		})
+	}
+	
+	// Renda do ano mês a mês (13º e férias das rendas CLT)
+	if annualIncome, err := ctrl.incomeService.GetAnnualIncomeProjection(familyID, budgetYear); err == nil {
+		dashboard["annual_income"] = annualIncome
+	}
+	
 	// Gerar alertas
 	alerts := ctrl.generateAlerts(incomeSummary, expensesSummary, investmentsSummary, emergencyProgress)
+	alerts = append(alerts, ctrl.generateBudgetAlerts(budgetReport)...)
 	dashboard["alerts"] = alerts
 	
 	utils.SuccessResponse(c, 200, dashboard)
@@ -271,3 +295,36 @@ func (ctrl *DashboardController) generateAlerts(
 	
 	return alerts
 }
+
+// generateBudgetAlerts gera alertas das categorias que passaram de 80% ou 100% do orçamento do mês
+func (ctrl *DashboardController) generateBudgetAlerts(report *services.BudgetReport) []Alert {
+	alerts := []Alert{}
+	if report == nil {
+		return alerts
+	}
+
+	for _, item := range report.Categories {
+		switch item.Status {
+		case services.BudgetStatusExceeded:
+			alerts = append(alerts, Alert{
+				Type:     "budget",
+				Category: "budget",
+				Severity: "critical",
+				Title:    fmt.Sprintf("Orçamento estourado: %s", item.CategoryName),
+				Message:  fmt.Sprintf("Os gastos em %s já somam %.0f%% do orçamento do mês (%s acima do previsto).", item.CategoryName, item.PercentUsed, utils.FormatMoney(-utils.FloatToCents(item.Remaining))),
+				Value:    item.PercentUsed,
+			})
+		case services.BudgetStatusWarning:
+			alerts = append(alerts, Alert{
+				Type:     "budget",
+				Category: "budget",
+				Severity: "warning",
+				Title:    fmt.Sprintf("Orçamento quase no limite: %s", item.CategoryName),
+				Message:  fmt.Sprintf("Os gastos em %s já somam %.0f%% do orçamento do mês. Restam %s.", item.CategoryName, item.PercentUsed, utils.FormatMoney(utils.FloatToCents(item.Remaining))),
+				Value:    item.PercentUsed,
+			})
+		}
+	}
+
+	return alerts
+}
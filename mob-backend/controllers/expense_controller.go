@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"finance-backend/models"
 	"finance-backend/services"
 	"finance-backend/utils"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,8 +32,19 @@ func (ctrl *ExpenseController) CreateExpense(c *gin.Context) {
 		Frequency   string                       `json:"frequency"`
 		ExpenseType string                       `json:"expense_type"`
 		DueDay      int                          `json:"due_day"`
-		IsFixed     bool                         `json:"is_fixed"`
+		IsFixed     *bool                        `json:"is_fixed"` // ausente = fixa
+		SplitMode   string                       `json:"split_mode"` // percentage (padrão), exact, shares, equal ou income
 		Splits      []services.ExpenseSplitInput `json:"splits"`
+		PaidBy      *uint                        `json:"paid_by_member_id"` // membro que pagou
+		
+		IRPFDeduction    string `json:"irpf_deduction"`             // vazio = a da categoria; nenhuma = não dedutível
+		IRPFBeneficiary  *uint  `json:"irpf_beneficiary_member_id"` // paciente, aluno ou alimentando
+		ProviderName     string `json:"provider_name"`
+		ProviderDocument string `json:"provider_document"` // CPF ou CNPJ do prestador
+		
+		RecurrenceRule     string `json:"recurrence_rule"`
+		RecurrenceInterval int    `json:"recurrence_interval"`
+		RecurrenceEndDate  string `json:"recurrence_end_date"` // YYYY-MM-DD
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -39,6 +52,12 @@ func (ctrl *ExpenseController) CreateExpense(c *gin.Context) {
 		return
 	}
 	
+	endDate, err := parseRecurrenceEndDate(input.RecurrenceEndDate)
+	if err != nil {
+		utils.ErrorResponse(c, 400, err.Error())
+		return
+	}
+	
 	expense := &models.Expense{
 		FamilyAccountID: familyID,
 		CategoryID:      input.CategoryID,
@@ -48,11 +67,22 @@ func (ctrl *ExpenseController) CreateExpense(c *gin.Context) {
 		Frequency:       models.ExpenseFrequency(input.Frequency),
 		ExpenseType:     models.ExpenseType(input.ExpenseType),
 		DueDay:          input.DueDay,
-		IsFixed:         input.IsFixed,
+		IsFixed:         input.IsFixed == nil || *input.IsFixed,
 		IsActive:        true,
+		SplitMode:       models.SplitMode(input.SplitMode),
+		PaidByMemberID:  paidByMemberID(input.PaidBy),
+		
+		IRPFDeduction:           models.IRPFDeduction(input.IRPFDeduction),
+		IRPFBeneficiaryMemberID: paidByMemberID(input.IRPFBeneficiary),
+		ProviderName:            input.ProviderName,
+		ProviderDocument:        input.ProviderDocument,
+		
+		RecurrenceRule:     models.RecurrenceRule(input.RecurrenceRule),
+		RecurrenceInterval: input.RecurrenceInterval,
+		RecurrenceEndDate:  endDate,
 	}
 	
-	err := ctrl.expenseService.CreateExpense(expense, input.Splits)
+	err = ctrl.expenseService.CreateExpense(expense, input.Splits)
 	if err != nil {
 		// Se for erro de validação, retornar detalhes
 		if validationErr, ok := err.(utils.ValidationErrors); ok {
@@ -112,6 +142,24 @@ func (ctrl *ExpenseController) GetFamilyExpenses(c *gin.Context) {
 	utils.SuccessResponse(c, 200, expenses)
 }
 
+// GetMyExpenses retorna as divisões de despesa do membro vinculado ao usuário logado
+func (ctrl *ExpenseController) GetMyExpenses(c *gin.Context) {
+	memberID := c.GetUint("family_member_id")
+	if memberID == 0 {
+		// Usuário sem registro de membro (ex: dono que não se cadastrou como membro)
+		utils.SuccessResponse(c, 200, services.MemberExpensesResponse{Expenses: []services.MemberExpenseDetail{}})
+		return
+	}
+	
+	response, err := ctrl.expenseService.GetMemberExpenses(memberID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar despesas do membro")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, response)
+}
+
 // GetExpensesByCategory retorna despesas agrupadas por categoria
 func (ctrl *ExpenseController) GetExpensesByCategory(c *gin.Context) {
 	familyID := c.GetUint("family_id")
@@ -181,7 +229,18 @@ func (ctrl *ExpenseController) UpdateExpense(c *gin.Context) {
 		ExpenseType string                       `json:"expense_type"`
 		DueDay      int                          `json:"due_day"`
 		IsFixed     *bool                        `json:"is_fixed"`
+		SplitMode   string                       `json:"split_mode"` // vazio = percentage
 		Splits      []services.ExpenseSplitInput `json:"splits"`
+		PaidBy      *uint                        `json:"paid_by_member_id"` // 0 remove o pagador
+		
+		IRPFDeduction    *string `json:"irpf_deduction"`             // "" volta a usar a da categoria
+		IRPFBeneficiary  *uint   `json:"irpf_beneficiary_member_id"` // 0 remove o beneficiário
+		ProviderName     *string `json:"provider_name"`
+		ProviderDocument *string `json:"provider_document"`
+		
+		RecurrenceRule     string  `json:"recurrence_rule"`
+		RecurrenceInterval int     `json:"recurrence_interval"`
+		RecurrenceEndDate  *string `json:"recurrence_end_date"` // YYYY-MM-DD; "" remove a data final
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -211,6 +270,36 @@ func (ctrl *ExpenseController) UpdateExpense(c *gin.Context) {
 	if input.IsFixed != nil {
 		expense.IsFixed = *input.IsFixed
 	}
+	if input.PaidBy != nil {
+		expense.PaidByMemberID = paidByMemberID(input.PaidBy)
+	}
+	if input.IRPFDeduction != nil {
+		expense.IRPFDeduction = models.IRPFDeduction(*input.IRPFDeduction)
+	}
+	if input.IRPFBeneficiary != nil {
+		expense.IRPFBeneficiaryMemberID = paidByMemberID(input.IRPFBeneficiary)
+	}
+	if input.ProviderName != nil {
+		expense.ProviderName = *input.ProviderName
+	}
+	if input.ProviderDocument != nil {
+		expense.ProviderDocument = *input.ProviderDocument
+	}
+	expense.SplitMode = models.SplitMode(input.SplitMode)
+	if input.RecurrenceRule != "" {
+		expense.RecurrenceRule = models.RecurrenceRule(input.RecurrenceRule)
+	}
+	if input.RecurrenceInterval > 0 {
+		expense.RecurrenceInterval = input.RecurrenceInterval
+	}
+	if input.RecurrenceEndDate != nil {
+		endDate, err := parseRecurrenceEndDate(*input.RecurrenceEndDate)
+		if err != nil {
+			utils.ErrorResponse(c, 400, err.Error())
+			return
+		}
+		expense.RecurrenceEndDate = endDate
+	}
 	
 	err = ctrl.expenseService.UpdateExpense(expense, input.Splits)
 	if err != nil {
@@ -239,13 +328,23 @@ func (ctrl *ExpenseController) DeleteExpense(c *gin.Context) {
 	utils.SuccessWithMessage(c, 200, "Despesa excluída com sucesso", nil)
 }
 
-// GetCategories busca todas as categorias
-func (ctrl *ExpenseController) GetCategories(c *gin.Context) {
-	categories, err := ctrl.expenseService.GetAllCategories()
-	if err != nil {
-		utils.InternalErrorResponse(c, "Erro ao buscar categorias")
-		return
+// parseRecurrenceEndDate converte a data final da recorrência (YYYY-MM-DD); vazio = sem data final
+func parseRecurrenceEndDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
 	}
 	
-	utils.SuccessResponse(c, 200, categories)
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, errors.New("Formato de data inválido em recurrence_end_date. Use YYYY-MM-DD")
+	}
+	return &date, nil
+}
+
+// paidByMemberID converte o membro informado (pagador ou beneficiário; 0 = nenhum)
+func paidByMemberID(value *uint) *uint {
+	if value == nil || *value == 0 {
+		return nil
+	}
+	return value
 }
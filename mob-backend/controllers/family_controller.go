@@ -57,11 +57,11 @@ func (ctrl *FamilyController) GetFamily(c *gin.Context) {
 	utils.SuccessResponse(c, 200, family)
 }
 
-// GetMyFamilies busca todas as famílias do usuário
+// GetMyFamilies busca todas as famílias que o usuário acessa, com seu papel em cada uma
 func (ctrl *FamilyController) GetMyFamilies(c *gin.Context) {
 	userID := c.GetUint("user_id")
 	
-	families, err := ctrl.familyService.GetFamiliesByOwner(userID)
+	families, err := ctrl.familyService.GetAccessibleFamilies(userID)
 	if err != nil {
 		utils.InternalErrorResponse(c, "Erro ao buscar famílias")
 		return
@@ -100,6 +100,29 @@ func (ctrl *FamilyController) UpdateFamily(c *gin.Context) {
 	utils.SuccessWithMessage(c, 200, "Família atualizada com sucesso", family)
 }
 
+// TransferOwnership transfere a família para outro membro vinculado a um usuário
+func (ctrl *FamilyController) TransferOwnership(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	userID := c.GetUint("user_id")
+	
+	var input struct {
+		MemberID uint `json:"member_id" binding:"required"`
+	}
+	
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	
+	family, err := ctrl.familyService.TransferOwnership(familyID, userID, input.MemberID)
+	if err != nil {
+		utils.ErrorResponse(c, 400, err.Error())
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Propriedade da família transferida com sucesso", family)
+}
+
 // DeleteFamily exclui uma família
 func (ctrl *FamilyController) DeleteFamily(c *gin.Context) {
 	familyID := c.GetUint("family_id")
@@ -131,9 +154,10 @@ func (ctrl *FamilyController) AddMember(c *gin.Context) {
 	familyID := c.GetUint("family_id")
 	
 	var input struct {
-		Name   string `json:"name" binding:"required"`
-		UserID *uint  `json:"user_id"`
-		Role   string `json:"role"`
+		Name                  string `json:"name" binding:"required"`
+		UserID                *uint  `json:"user_id"`
+		Role                  string `json:"role"`
+		IRPFDeclarantMemberID *uint  `json:"irpf_declarant_member_id"`
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -148,6 +172,9 @@ func (ctrl *FamilyController) AddMember(c *gin.Context) {
 		Role:            models.MemberRole(input.Role),
 		IsActive:        true,
 	}
+	if input.IRPFDeclarantMemberID != nil && *input.IRPFDeclarantMemberID != 0 {
+		member.IRPFDeclarantMemberID = input.IRPFDeclarantMemberID
+	}
 	
 	err := ctrl.familyService.AddMember(member)
 	if err != nil {
@@ -168,8 +195,9 @@ func (ctrl *FamilyController) UpdateMember(c *gin.Context) {
 	}
 	
 	var input struct {
-		Name string `json:"name" binding:"required"`
-		Role string `json:"role"`
+		Name                  string `json:"name" binding:"required"`
+		Role                  string `json:"role"`
+		IRPFDeclarantMemberID *uint  `json:"irpf_declarant_member_id"` // 0 volta ao declarante padrão
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -178,7 +206,7 @@ func (ctrl *FamilyController) UpdateMember(c *gin.Context) {
 	}
 	
 	member, err := ctrl.familyService.GetMemberByID(uint(memberID))
-	if err != nil {
+	if err != nil || member.FamilyAccountID != c.GetUint("family_id") {
 		utils.NotFoundResponse(c, "Membro")
 		return
 	}
@@ -187,6 +215,12 @@ func (ctrl *FamilyController) UpdateMember(c *gin.Context) {
 	if input.Role != "" {
 		member.Role = models.MemberRole(input.Role)
 	}
+	if input.IRPFDeclarantMemberID != nil {
+		member.IRPFDeclarantMemberID = input.IRPFDeclarantMemberID
+		if *input.IRPFDeclarantMemberID == 0 {
+			member.IRPFDeclarantMemberID = nil
+		}
+	}
 	
 	err = ctrl.familyService.UpdateMember(member)
 	if err != nil {
@@ -206,7 +240,7 @@ func (ctrl *FamilyController) RemoveMember(c *gin.Context) {
 		return
 	}
 	
-	err = ctrl.familyService.RemoveMember(uint(memberID))
+	err = ctrl.familyService.RemoveMember(c.GetUint("family_id"), uint(memberID))
 	if err != nil {
 		utils.ErrorResponse(c, 400, err.Error())
 		return
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/services/importer"
+	"finance-backend/utils"
+)
+
+// Tamanho máximo do arquivo de extrato (5 MB)
+const maxImportFileSize = 5 << 20
+
+type ImportController struct {
+	importService *services.ImportService
+}
+
+func NewImportController(importService *services.ImportService) *ImportController {
+	return &ImportController{importService: importService}
+}
+
+// UploadImport recebe um arquivo OFX/CSV (multipart, campo "file") e cria um lote para revisão
+func (ctrl *ImportController) UploadImport(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	userID := c.GetUint("user_id")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, 400, "Envie o extrato no campo 'file'")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		utils.ErrorResponse(c, 413, "Arquivo excede o limite de 5 MB")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, 400, "Não foi possível ler o arquivo")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		utils.ErrorResponse(c, 400, "Não foi possível ler o arquivo")
+		return
+	}
+
+	input := services.ImportFileInput{
+		FileName: fileHeader.Filename,
+		Content:  content,
+		Format:   c.PostForm("format"),
+		Layout:   c.PostForm("layout"),
+	}
+
+	// Layout personalizado: colunas informadas nos próprios campos do formulário
+	if input.Layout == "custom" {
+		input.CustomLayout = &importer.CSVLayout{
+			Delimiter:         c.DefaultPostForm("delimiter", ","),
+			HasHeader:         c.DefaultPostForm("has_header", "true") == "true",
+			DateColumn:        c.PostForm("date_column"),
+			DescriptionColumn: c.PostForm("description_column"),
+			AmountColumn:      c.PostForm("amount_column"),
+			DateFormat:        c.DefaultPostForm("date_format", "02/01/2006"),
+			DecimalSeparator:  c.DefaultPostForm("decimal_separator", ","),
+			InvertSign:        c.PostForm("invert_sign") == "true",
+		}
+	}
+
+	batch, err := ctrl.importService.StageImport(familyID, userID, input)
+	if err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 201, "Arquivo lido com sucesso; revise as linhas antes de confirmar", batch)
+}
+
+// GetLayouts lista os layouts CSV conhecidos
+func (ctrl *ImportController) GetLayouts(c *gin.Context) {
+	utils.SuccessResponse(c, 200, importer.Layouts)
+}
+
+// GetImports lista os lotes de importação da família
+func (ctrl *ImportController) GetImports(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+
+	batches, err := ctrl.importService.GetImports(familyID)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar importações")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, batches)
+}
+
+// GetImport busca um lote com suas linhas
+func (ctrl *ImportController) GetImport(c *gin.Context) {
+	importID, ok := parseImportID(c)
+	if !ok {
+		return
+	}
+
+	batch, err := ctrl.importService.GetImport(c.GetUint("family_id"), importID)
+	if err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, batch)
+}
+
+// UpdateRows altera seleção/categoria/membro das linhas em revisão
+func (ctrl *ImportController) UpdateRows(c *gin.Context) {
+	importID, ok := parseImportID(c)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Rows []services.ImportRowInput `json:"rows"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	batch, err := ctrl.importService.UpdateRows(c.GetUint("family_id"), importID, input.Rows)
+	if err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, batch)
+}
+
+// CommitImport cria as despesas e rendas das linhas selecionadas
+func (ctrl *ImportController) CommitImport(c *gin.Context) {
+	importID, ok := parseImportID(c)
+	if !ok {
+		return
+	}
+
+	var input services.CommitImportInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	batch, err := ctrl.importService.CommitImport(c.GetUint("family_id"), importID, input)
+	if err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Importação confirmada com sucesso", batch)
+}
+
+// UndoImport desfaz uma importação confirmada
+func (ctrl *ImportController) UndoImport(c *gin.Context) {
+	importID, ok := parseImportID(c)
+	if !ok {
+		return
+	}
+
+	batch, err := ctrl.importService.UndoImport(c.GetUint("family_id"), importID)
+	if err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Importação desfeita com sucesso", batch)
+}
+
+// DiscardImport descarta um lote ainda em revisão
+func (ctrl *ImportController) DiscardImport(c *gin.Context) {
+	importID, ok := parseImportID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.importService.DiscardImport(c.GetUint("family_id"), importID); err != nil {
+		handleImportError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Importação descartada com sucesso", nil)
+}
+
+func parseImportID(c *gin.Context) (uint, bool) {
+	importID, err := strconv.ParseUint(c.Param("importId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID da importação inválido")
+		return 0, false
+	}
+	return uint(importID), true
+}
+
+func handleImportError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrImportNotFound):
+		utils.NotFoundResponse(c, "Importação")
+	case errors.Is(err, services.ErrImportNotStaged), errors.Is(err, services.ErrImportNotCommitted):
+		utils.ErrorResponse(c, 409, err.Error())
+	default:
+		utils.ErrorResponse(c, 400, err.Error())
+	}
+}
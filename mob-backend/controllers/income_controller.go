@@ -6,6 +6,7 @@ import (
 	"finance-backend/utils"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,13 +26,19 @@ func (ctrl *IncomeController) CreateIncome(c *gin.Context) {
 	var input struct {
 		FamilyMemberID        uint    `json:"family_member_id" binding:"required"`
 		Type                  string  `json:"type" binding:"required"`
-		NetMonthlyCents       int64   `json:"net_monthly_cents" binding:"required"`
+		NetMonthlyCents       int64   `json:"net_monthly_cents"` // vazio: calculado a partir do bruto
 		GrossMonthlyCents     int64   `json:"gross_monthly_cents"`
 		FoodVoucherCents      int64   `json:"food_voucher_cents"`
 		TransportVoucherCents int64   `json:"transport_voucher_cents"`
 		BonusCents            int64   `json:"bonus_cents"`
 		SimplesNacionalRate   float64 `json:"simples_nacional_rate"`
 		ProLaboreCents        int64   `json:"pro_labore_cents"`
+		BusinessActivity      string  `json:"business_activity"`
+		EffectiveFrom         string  `json:"effective_from"`  // YYYY-MM a partir do qual vale (padrão: mês atual)
+		EffectiveUntil        string  `json:"effective_until"` // YYYY-MM do último mês da fonte (vazio = sem fim)
+		SourceID              *uint   `json:"source_id"`       // nova versão de uma fonte existente
+		SourceName            string  `json:"source_name"`
+		VacationMonth         *int    `json:"vacation_month"` // CLT: mês das férias (1-12)
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -39,6 +46,15 @@ func (ctrl *IncomeController) CreateIncome(c *gin.Context) {
 		return
 	}
 	
+	effectiveMonth, effectiveYear, ok := parseIncomeMonth(c, "effective_from", input.EffectiveFrom)
+	if !ok {
+		return
+	}
+	endMonth, endYear, ok := parseIncomeMonth(c, "effective_until", input.EffectiveUntil)
+	if !ok {
+		return
+	}
+	
 	// Validar que o membro pertence à família
 	if err := ctrl.incomeService.ValidateMemberBelongsToFamily(input.FamilyMemberID, familyID); err != nil {
 		utils.ErrorResponse(c, 403, "Membro não pertence a esta família")
@@ -55,7 +71,18 @@ func (ctrl *IncomeController) CreateIncome(c *gin.Context) {
 		BonusCents:            input.BonusCents,
 		SimplesNacionalRate:   input.SimplesNacionalRate,
 		ProLaboreCents:        input.ProLaboreCents,
+		BusinessActivity:      input.BusinessActivity,
+		SimplesAutoRate:       models.IncomeType(input.Type) == models.IncomePJ && input.SimplesNacionalRate == 0,
 		IsActive:              true,
+		ReferenceMonth:        effectiveMonth,
+		ReferenceYear:         effectiveYear,
+		SourceID:              input.SourceID,
+		SourceName:            input.SourceName,
+		VacationMonth:         input.VacationMonth,
+	}
+	if endMonth > 0 {
+		income.EndMonth = &endMonth
+		income.EndYear = &endYear
 	}
 	
 	err := ctrl.incomeService.CreateIncome(income)
@@ -69,18 +96,15 @@ func (ctrl *IncomeController) CreateIncome(c *gin.Context) {
 
 // GetIncome busca renda por ID
 func (ctrl *IncomeController) GetIncome(c *gin.Context) {
-	incomeID, _ := strconv.ParseUint(c.Param("incomeId"), 10, 32)
-	
-	income, err := ctrl.incomeService.GetIncomeByID(uint(incomeID))
-	if err != nil {
-		utils.NotFoundResponse(c, "Renda")
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
 		return
 	}
 	
 	utils.SuccessResponse(c, 200, income)
 }
 
-// GetFamilyIncomes busca rendas de uma família
+// GetFamilyIncomes busca as rendas vigentes da família (?month=YYYY-MM; padrão: mês atual)
 func (ctrl *IncomeController) GetFamilyIncomes(c *gin.Context) {
 	familyID := c.GetUint("family_id")
 	monthParam := c.Query("month") // Formato: YYYY-MM
@@ -113,6 +137,28 @@ func (ctrl *IncomeController) GetFamilyIncomes(c *gin.Context) {
 	utils.SuccessResponse(c, 200, incomes)
 }
 
+// GetMemberIncomeHistory lista o histórico de rendas de um membro (mais recente primeiro)
+func (ctrl *IncomeController) GetMemberIncomeHistory(c *gin.Context) {
+	memberID, err := strconv.ParseUint(c.Param("memberId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do membro inválido")
+		return
+	}
+	
+	if err := ctrl.incomeService.ValidateMemberBelongsToFamily(uint(memberID), c.GetUint("family_id")); err != nil {
+		utils.NotFoundResponse(c, "Membro")
+		return
+	}
+	
+	incomes, err := ctrl.incomeService.GetIncomesByMemberID(uint(memberID))
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar rendas")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, incomes)
+}
+
 // GetFamilyIncomeSummary retorna resumo das rendas da família
 func (ctrl *IncomeController) GetFamilyIncomeSummary(c *gin.Context) {
 	familyID := c.GetUint("family_id")
@@ -138,11 +184,38 @@ func (ctrl *IncomeController) GetFamilyIncomeSummary(c *gin.Context) {
 	utils.SuccessResponse(c, 200, summary)
 }
 
+// GetAnnualIncomeProjection retorna a renda da família mês a mês no ano (?year=YYYY; padrão: ano atual),
+// com 13º salário e férias das rendas CLT
+func (ctrl *IncomeController) GetAnnualIncomeProjection(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	
+	year := time.Now().Year()
+	if yearParam := c.Query("year"); yearParam != "" {
+		parsed, err := strconv.Atoi(yearParam)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			utils.ErrorResponse(c, 400, "Ano inválido")
+			return
+		}
+		year = parsed
+	}
+	
+	projection, err := ctrl.incomeService.GetAnnualIncomeProjection(familyID, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao calcular projeção anual")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, projection)
+}
+
 // GetIncomeBreakdown retorna detalhamento de uma renda
 func (ctrl *IncomeController) GetIncomeBreakdown(c *gin.Context) {
-	incomeID, _ := strconv.ParseUint(c.Param("incomeId"), 10, 32)
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
+		return
+	}
 	
-	breakdown, err := ctrl.incomeService.GetIncomeBreakdown(uint(incomeID))
+	breakdown, err := ctrl.incomeService.GetIncomeBreakdown(income.ID)
 	if err != nil {
 		utils.NotFoundResponse(c, "Renda")
 		return
@@ -153,11 +226,8 @@ func (ctrl *IncomeController) GetIncomeBreakdown(c *gin.Context) {
 
 // UpdateIncome atualiza uma renda
 func (ctrl *IncomeController) UpdateIncome(c *gin.Context) {
-	incomeID, _ := strconv.ParseUint(c.Param("incomeId"), 10, 32)
-	
-	income, err := ctrl.incomeService.GetIncomeByID(uint(incomeID))
-	if err != nil {
-		utils.NotFoundResponse(c, "Renda")
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
 		return
 	}
 	
@@ -170,6 +240,11 @@ func (ctrl *IncomeController) UpdateIncome(c *gin.Context) {
 		BonusCents            int64   `json:"bonus_cents"`
 		SimplesNacionalRate   float64 `json:"simples_nacional_rate"`
 		ProLaboreCents        int64   `json:"pro_labore_cents"`
+		BusinessActivity      string  `json:"business_activity"`
+		EffectiveFrom         string  `json:"effective_from"`  // YYYY-MM; vazio mantém a vigência
+		EffectiveUntil        *string `json:"effective_until"` // YYYY-MM; "" remove o fim, ausente mantém
+		SourceName            string  `json:"source_name"`
+		VacationMonth         *int    `json:"vacation_month"` // 0 remove o mês de férias, ausente mantém
 	}
 	
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -177,23 +252,65 @@ func (ctrl *IncomeController) UpdateIncome(c *gin.Context) {
 		return
 	}
 	
+	effectiveMonth, effectiveYear, ok := parseIncomeMonth(c, "effective_from", input.EffectiveFrom)
+	if !ok {
+		return
+	}
+	if effectiveMonth > 0 {
+		income.ReferenceMonth = effectiveMonth
+		income.ReferenceYear = effectiveYear
+	}
+	if input.EffectiveUntil != nil {
+		endMonth, endYear, ok := parseIncomeMonth(c, "effective_until", *input.EffectiveUntil)
+		if !ok {
+			return
+		}
+		income.EndMonth, income.EndYear = nil, nil
+		if endMonth > 0 {
+			income.EndMonth = &endMonth
+			income.EndYear = &endYear
+		}
+	}
+	if input.SourceName != "" {
+		income.SourceName = input.SourceName
+	}
+	if input.VacationMonth != nil {
+		income.VacationMonth = input.VacationMonth
+		if *input.VacationMonth == 0 {
+			income.VacationMonth = nil
+		}
+	}
+	
 	if input.Type != "" {
 		income.Type = models.IncomeType(input.Type)
 	}
 	if input.NetMonthlyCents > 0 {
+		if input.GrossMonthlyCents == 0 && income.NetOnly() {
+			// Renda informada só pelo líquido continua sem bruto próprio
+			income.GrossMonthlyCents = 0
+		}
 		income.NetMonthlyCents = input.NetMonthlyCents
+		income.NetCalculated = false
 	}
 	if input.GrossMonthlyCents > 0 {
 		income.GrossMonthlyCents = input.GrossMonthlyCents
+		if input.NetMonthlyCents == 0 {
+			// Só o bruto informado: o líquido passa a ser calculado (e recalculado nas próximas edições)
+			income.NetCalculated = true
+		}
 	}
 	income.FoodVoucherCents = input.FoodVoucherCents
 	income.TransportVoucherCents = input.TransportVoucherCents
 	income.BonusCents = input.BonusCents
 	income.SimplesNacionalRate = input.SimplesNacionalRate
+	income.SimplesAutoRate = income.Type == models.IncomePJ && input.SimplesNacionalRate == 0
 	income.ProLaboreCents = input.ProLaboreCents
+	if input.BusinessActivity != "" {
+		// Vazio mantém a atividade (MEI e Lucro Presumido)
+		income.BusinessActivity = input.BusinessActivity
+	}
 	
-	err = ctrl.incomeService.UpdateIncome(income)
-	if err != nil {
+	if err := ctrl.incomeService.UpdateIncome(income); err != nil {
 		utils.ErrorResponse(c, 400, err.Error())
 		return
 	}
@@ -203,13 +320,119 @@ func (ctrl *IncomeController) UpdateIncome(c *gin.Context) {
 
 // DeleteIncome exclui uma renda
 func (ctrl *IncomeController) DeleteIncome(c *gin.Context) {
-	incomeID, _ := strconv.ParseUint(c.Param("incomeId"), 10, 32)
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
+		return
+	}
 	
-	err := ctrl.incomeService.DeleteIncome(uint(incomeID))
-	if err != nil {
+	if err := ctrl.incomeService.DeleteIncome(income.ID); err != nil {
 		utils.ErrorResponse(c, 400, err.Error())
 		return
 	}
 	
 	utils.SuccessWithMessage(c, 200, "Renda excluída com sucesso", nil)
 }
+
+// GetRevenues lista o faturamento mensal da fonte de uma renda PJ ou MEI
+func (ctrl *IncomeController) GetRevenues(c *gin.Context) {
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
+		return
+	}
+	
+	revenues, err := ctrl.incomeService.GetRevenues(income)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar faturamento")
+		return
+	}
+	
+	utils.SuccessResponse(c, 200, revenues)
+}
+
+// SetRevenue grava o faturamento e a folha da fonte PJ ou MEI no mês (YYYY-MM)
+func (ctrl *IncomeController) SetRevenue(c *gin.Context) {
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
+		return
+	}
+	month, year, ok := parseIncomeMonth(c, "mês", c.Param("month"))
+	if !ok {
+		return
+	}
+	
+	var input struct {
+		RevenueCents int64 `json:"revenue_cents"`
+		PayrollCents int64 `json:"payroll_cents"` // pró-labore, salários e encargos do mês
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	
+	revenue, err := ctrl.incomeService.SetRevenue(income, month, year, input.RevenueCents, input.PayrollCents)
+	if err != nil {
+		if validationErr, ok := err.(utils.ValidationErrors); ok {
+			utils.ValidationErrorResponse(c, validationErr)
+			return
+		}
+		utils.InternalErrorResponse(c, "Erro ao salvar faturamento")
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Faturamento salvo com sucesso", revenue)
+}
+
+// DeleteRevenue remove o faturamento da fonte PJ ou MEI no mês (YYYY-MM)
+func (ctrl *IncomeController) DeleteRevenue(c *gin.Context) {
+	income, ok := ctrl.familyIncome(c)
+	if !ok {
+		return
+	}
+	month, year, ok := parseIncomeMonth(c, "mês", c.Param("month"))
+	if !ok {
+		return
+	}
+	
+	deleted, err := ctrl.incomeService.DeleteRevenue(income, month, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao excluir faturamento")
+		return
+	}
+	if !deleted {
+		utils.NotFoundResponse(c, "Faturamento")
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, "Faturamento excluído com sucesso", nil)
+}
+
+// familyIncome carrega a renda da URL garantindo que pertence a um membro da família
+func (ctrl *IncomeController) familyIncome(c *gin.Context) (*models.Income, bool) {
+	incomeID, err := strconv.ParseUint(c.Param("incomeId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID da renda inválido")
+		return nil, false
+	}
+	
+	income, err := ctrl.incomeService.GetIncomeByID(uint(incomeID))
+	if err != nil || income.FamilyMember.FamilyAccountID != c.GetUint("family_id") {
+		utils.NotFoundResponse(c, "Renda")
+		return nil, false
+	}
+	return income, true
+}
+
+// parseIncomeMonth converte um mês de vigência (YYYY-MM); vazio retorna 0, 0
+func parseIncomeMonth(c *gin.Context, field, value string) (int, int, bool) {
+	if value == "" {
+		return 0, 0, true
+	}
+	
+	month, year := 0, 0
+	_, err := fmt.Sscanf(value, "%d-%d", &year, &month)
+	if err != nil || month < 1 || month > 12 || year < 2000 {
+		utils.ErrorResponse(c, 400, fmt.Sprintf("Formato de %s inválido. Use YYYY-MM (ex: 2024-03)", field))
+		return 0, 0, false
+	}
+	return month, year, true
+}
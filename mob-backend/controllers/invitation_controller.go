@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/models"
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type InvitationController struct {
+	invitationService *services.InvitationService
+}
+
+func NewInvitationController(invitationService *services.InvitationService) *InvitationController {
+	return &InvitationController{invitationService: invitationService}
+}
+
+// CreateInvitation cria um convite para a família
+func (ctrl *InvitationController) CreateInvitation(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	userID := c.GetUint("user_id")
+
+	var input struct {
+		Role           string `json:"role"`
+		Email          string `json:"email"`
+		MemberID       *uint  `json:"member_id"`
+		ExpiresInHours int    `json:"expires_in_hours"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	invitation, token, err := ctrl.invitationService.CreateInvitation(familyID, userID, services.CreateInvitationInput{
+		Role:           models.MemberRole(input.Role),
+		Email:          input.Email,
+		FamilyMemberID: input.MemberID,
+		ExpiresInHours: input.ExpiresInHours,
+	})
+	if err != nil {
+		handleInvitationError(c, err)
+		return
+	}
+
+	// O token só é retornado aqui; o banco guarda apenas o hash
+	utils.SuccessWithMessage(c, 201, "Convite criado com sucesso", gin.H{
+		"invitation": invitation,
+		"token":      token,
+	})
+}
+
+// GetPendingInvitations lista os convites pendentes da família
+func (ctrl *InvitationController) GetPendingInvitations(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+
+	invitations, err := ctrl.invitationService.GetPendingInvitations(familyID)
+	if err != nil {
+		handleInvitationError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, invitations)
+}
+
+// RevokeInvitation revoga um convite pendente
+func (ctrl *InvitationController) RevokeInvitation(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do convite inválido")
+		return
+	}
+
+	if err := ctrl.invitationService.RevokeInvitation(familyID, uint(invitationID)); err != nil {
+		handleInvitationError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Convite revogado com sucesso", nil)
+}
+
+// AcceptInvitation vincula o usuário logado à família do convite
+func (ctrl *InvitationController) AcceptInvitation(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	member, err := ctrl.invitationService.AcceptInvitation(c.Param("token"), userID)
+	if err != nil {
+		handleInvitationError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Convite aceito com sucesso", member)
+}
+
+func handleInvitationError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrInvitationEmailMismatch):
+		utils.ForbiddenResponse(c, err.Error())
+	case errors.Is(err, services.ErrInvitationNotFound):
+		utils.NotFoundResponse(c, "Convite")
+	case errors.Is(err, services.ErrInvitationNotPending):
+		utils.ErrorResponse(c, 410, err.Error())
+	case errors.Is(err, services.ErrAlreadyFamilyMember):
+		utils.ErrorResponse(c, 409, err.Error())
+	default:
+		utils.ErrorResponse(c, 400, err.Error())
+	}
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type IRPFController struct {
+	declarationService *services.IRPFDeclarationService
+}
+
+func NewIRPFController(declarationService *services.IRPFDeclarationService) *IRPFController {
+	return &IRPFController{declarationService: declarationService}
+}
+
+// parseMemberYear lê o membro e o ano-calendário da URL (responde 400 e retorna false se inválidos)
+func parseMemberYear(c *gin.Context) (uint, int, bool) {
+	memberID, err := strconv.ParseUint(c.Param("memberId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do membro inválido")
+		return 0, 0, false
+	}
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil || year < 2000 || year > 2100 {
+		utils.ErrorResponse(c, 400, "Ano inválido")
+		return 0, 0, false
+	}
+	return uint(memberID), year, true
+}
+
+// SimulateDeclaration simula a declaração de ajuste anual do membro no ano-calendário (?dependents=N para
+// sobrescrever os dependentes informados nas rendas)
+func (ctrl *IRPFController) SimulateDeclaration(c *gin.Context) {
+	memberID, year, ok := parseMemberYear(c)
+	if !ok {
+		return
+	}
+
+	dependents := -1
+	if dependentsParam := c.Query("dependents"); dependentsParam != "" {
+		var err error
+		dependents, err = strconv.Atoi(dependentsParam)
+		if err != nil || dependents < 0 {
+			utils.ErrorResponse(c, 400, "Número de dependentes inválido")
+			return
+		}
+	}
+
+	declaration, err := ctrl.declarationService.SimulateDeclaration(c.GetUint("family_id"), memberID, year, dependents)
+	if err != nil {
+		if err == services.ErrMemberNotInFamily {
+			utils.NotFoundResponse(c, "Membro")
+			return
+		}
+		utils.InternalErrorResponse(c, "Erro ao simular declaração")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, declaration)
+}
+
+// GetPaymentsReport lista as despesas dedutíveis do membro no ano no formato da ficha "Pagamentos Efetuados"
+// (?format=csv baixa a planilha)
+func (ctrl *IRPFController) GetPaymentsReport(c *gin.Context) {
+	memberID, year, ok := parseMemberYear(c)
+	if !ok {
+		return
+	}
+
+	report, err := ctrl.declarationService.GetPaymentsReport(c.GetUint("family_id"), memberID, year)
+	if err != nil {
+		if err == services.ErrMemberNotInFamily {
+			utils.NotFoundResponse(c, "Membro")
+			return
+		}
+		utils.InternalErrorResponse(c, "Erro ao gerar relatório de pagamentos")
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		data, err := report.CSV()
+		if err != nil {
+			utils.InternalErrorResponse(c, "Erro ao gerar CSV")
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=pagamentos-efetuados-%d-membro-%d.csv", year, memberID))
+		c.Data(200, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, report)
+}
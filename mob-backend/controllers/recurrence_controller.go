@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"finance-backend/services"
+	"finance-backend/utils"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurrenceController struct {
+	recurrenceService *services.RecurrenceService
+}
+
+func NewRecurrenceController(recurrenceService *services.RecurrenceService) *RecurrenceController {
+	return &RecurrenceController{recurrenceService: recurrenceService}
+}
+
+// Rollover gera as ocorrências das despesas recorrentes no mês informado (idempotente)
+func (ctrl *RecurrenceController) Rollover(c *gin.Context) {
+	familyID := c.GetUint("family_id")
+	monthParam := c.Param("month") // Formato: YYYY-MM
+	
+	month, year := 0, 0
+	_, parseErr := fmt.Sscanf(monthParam, "%d-%d", &year, &month)
+	if parseErr != nil || month < 1 || month > 12 || year < 2000 {
+		utils.ErrorResponse(c, 400, "Formato de mês inválido. Use YYYY-MM (ex: 2024-03)")
+		return
+	}
+	
+	result, err := ctrl.recurrenceService.GenerateMonth(familyID, month, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao gerar despesas recorrentes")
+		return
+	}
+	
+	utils.SuccessWithMessage(c, 200, fmt.Sprintf("%d despesa(s) recorrente(s) gerada(s)", result.Created), result)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type SettlementController struct {
+	settlementService *services.SettlementService
+}
+
+func NewSettlementController(settlementService *services.SettlementService) *SettlementController {
+	return &SettlementController{settlementService: settlementService}
+}
+
+// GetBalances retorna quem deve a quem e as transferências sugeridas (?month=YYYY-MM; sem mês = todo o histórico)
+func (ctrl *SettlementController) GetBalances(c *gin.Context) {
+	month, year, ok := parseOptionalMonthQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := ctrl.settlementService.GetBalances(c.GetUint("family_id"), month, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao calcular saldos")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, report)
+}
+
+// GetSettlements lista os acertos registrados (?month=YYYY-MM)
+func (ctrl *SettlementController) GetSettlements(c *gin.Context) {
+	month, year, ok := parseOptionalMonthQuery(c)
+	if !ok {
+		return
+	}
+
+	settlements, err := ctrl.settlementService.GetSettlements(c.GetUint("family_id"), month, year)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar acertos")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, settlements)
+}
+
+// CreateSettlement registra um pagamento de acerto entre membros
+func (ctrl *SettlementController) CreateSettlement(c *gin.Context) {
+	var input services.SettlementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	settlement, err := ctrl.settlementService.CreateSettlement(c.GetUint("family_id"), c.GetUint("user_id"), input)
+	if err != nil {
+		handleSettlementError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 201, "Acerto registrado com sucesso", settlement)
+}
+
+// DeleteSettlement exclui um acerto
+func (ctrl *SettlementController) DeleteSettlement(c *gin.Context) {
+	settlementID, err := strconv.ParseUint(c.Param("settlementId"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, 400, "ID do acerto inválido")
+		return
+	}
+
+	if err := ctrl.settlementService.DeleteSettlement(c.GetUint("family_id"), uint(settlementID)); err != nil {
+		handleSettlementError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Acerto excluído com sucesso", nil)
+}
+
+// parseOptionalMonthQuery lê ?month=YYYY-MM; sem o parâmetro retorna 0, 0
+func parseOptionalMonthQuery(c *gin.Context) (int, int, bool) {
+	month, year := 0, 0
+	if monthParam := c.Query("month"); monthParam != "" {
+		_, err := fmt.Sscanf(monthParam, "%d-%d", &year, &month)
+		if err != nil || month < 1 || month > 12 || year < 2000 {
+			utils.ErrorResponse(c, 400, "Formato de mês inválido. Use YYYY-MM (ex: 2024-03)")
+			return 0, 0, false
+		}
+	}
+	return month, year, true
+}
+
+func handleSettlementError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, services.ErrSettlementNotFound) {
+		utils.NotFoundResponse(c, "Acerto")
+		return
+	}
+	utils.ErrorResponse(c, 400, err.Error())
+}
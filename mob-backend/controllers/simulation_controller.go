@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type SimulationController struct {
+	simulationService *services.SimulationService
+}
+
+func NewSimulationController(simulationService *services.SimulationService) *SimulationController {
+	return &SimulationController{simulationService: simulationService}
+}
+
+// CompareCLTvsPJ compara no ano uma proposta CLT com uma proposta PJ e calcula o faturamento de equilíbrio
+func (ctrl *SimulationController) CompareCLTvsPJ(c *gin.Context) {
+	var input services.CLTvsPJInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+
+	simulation, err := ctrl.simulationService.CompareCLTvsPJ(input)
+	if err != nil {
+		handleSimulationError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, simulation)
+}
+
+func handleSimulationError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+	utils.InternalErrorResponse(c, "Erro ao calcular simulação")
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+type TaxTableController struct {
+	taxTableService *services.TaxTableService
+}
+
+func NewTaxTableController(taxTableService *services.TaxTableService) *TaxTableController {
+	return &TaxTableController{taxTableService: taxTableService}
+}
+
+// ListYears lista os anos com tabela de impostos cadastrada
+func (ctrl *TaxTableController) ListYears(c *gin.Context) {
+	years, err := ctrl.taxTableService.ListYears()
+	if err != nil {
+		utils.InternalErrorResponse(c, "Erro ao buscar tabelas de impostos")
+		return
+	}
+
+	utils.SuccessResponse(c, 200, years)
+}
+
+// GetTable retorna a tabela de impostos de um ano (ativa ou não)
+func (ctrl *TaxTableController) GetTable(c *gin.Context) {
+	year, ok := parseTaxYear(c)
+	if !ok {
+		return
+	}
+
+	table, err := ctrl.taxTableService.GetTable(year)
+	if err != nil {
+		handleTaxTableError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, table)
+}
+
+// GetDiff compara a tabela de um ano com a do ano anterior
+func (ctrl *TaxTableController) GetDiff(c *gin.Context) {
+	year, ok := parseTaxYear(c)
+	if !ok {
+		return
+	}
+
+	diff, err := ctrl.taxTableService.DiffYear(year)
+	if err != nil {
+		handleTaxTableError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, 200, diff)
+}
+
+// ImportTable cria ou substitui a tabela completa de um ano (?activate=true ativa; ?dry_run=true só valida e compara)
+func (ctrl *TaxTableController) ImportTable(c *gin.Context) {
+	year, ok := parseTaxYear(c)
+	if !ok {
+		return
+	}
+
+	var table services.TaxTable
+	if err := c.ShouldBindJSON(&table); err != nil {
+		utils.ErrorResponse(c, 400, "Dados inválidos")
+		return
+	}
+	if table.Year != 0 && table.Year != year {
+		utils.ErrorResponse(c, 400, "O ano do corpo difere do ano da URL")
+		return
+	}
+	table.Year = year
+
+	result, err := ctrl.taxTableService.ImportTable(&table, c.Query("activate") == "true", c.Query("dry_run") == "true")
+	if err != nil {
+		handleTaxTableError(c, err)
+		return
+	}
+
+	if !result.Saved {
+		utils.SuccessWithMessage(c, 200, "Tabela válida (nada foi gravado)", result)
+		return
+	}
+	utils.SuccessWithMessage(c, 200, "Tabela de impostos importada com sucesso", result)
+}
+
+// ActivateYear ativa a tabela de um ano para os cálculos
+func (ctrl *TaxTableController) ActivateYear(c *gin.Context) {
+	year, ok := parseTaxYear(c)
+	if !ok {
+		return
+	}
+
+	table, err := ctrl.taxTableService.ActivateYear(year)
+	if err != nil {
+		handleTaxTableError(c, err)
+		return
+	}
+
+	utils.SuccessWithMessage(c, 200, "Tabela de impostos ativada com sucesso", table)
+}
+
+func parseTaxYear(c *gin.Context) (int, bool) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil || year < 2000 {
+		utils.ErrorResponse(c, 400, "Ano inválido")
+		return 0, false
+	}
+	return year, true
+}
+
+func handleTaxTableError(c *gin.Context, err error) {
+	if validationErr, ok := err.(utils.ValidationErrors); ok {
+		utils.ValidationErrorResponse(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, services.ErrTaxYearNotFound) {
+		utils.NotFoundResponse(c, "Tabela de impostos")
+		return
+	}
+	utils.InternalErrorResponse(c, "Erro ao processar tabela de impostos")
+}
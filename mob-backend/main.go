@@ -7,7 +7,9 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"finance-backend/config"
+	"finance-backend/repositories"
 	"finance-backend/routes"
+	"finance-backend/services"
 	"finance-backend/utils"
 )
 
@@ -15,6 +17,21 @@ func main() {
 	// Inicializa logger estruturado
 	utils.InitLogger("mob-finance-backend")
 	log := utils.GetLogger()
+
+	// Subcomando de migrations: ./main migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
+
+	// Subcomando de tabelas de impostos: ./main tax list|show|diff|import|activate
+	if len(os.Args) > 1 && os.Args[1] == "tax" {
+		os.Exit(runTaxCommand(os.Args[2:]))
+	}
+	
+	// JWT_EXPIRATION_HOURS (token único de longa duração) foi substituída pelo par access/refresh token
+	if os.Getenv("JWT_EXPIRATION_HOURS") != "" {
+		log.Warning("JWT_EXPIRATION_HOURS não é mais usada; configure JWT_ACCESS_TTL_MINUTES e JWT_REFRESH_TTL_HOURS")
+	}
 	
 	// Inicializa banco de dados
 	config.InitDB()
@@ -47,6 +64,18 @@ func main() {
 	// Setup das rotas da aplicação
 	routes.SetupRoutes(r)
 
+	// Agendador em background das despesas recorrentes do mês corrente
+	expenseRepo := repositories.NewExpenseRepository(config.DB)
+	expenseService := services.NewExpenseService(
+		expenseRepo,
+		repositories.NewFamilyRepository(config.DB),
+		repositories.NewExpenseCategoryRepository(config.DB),
+		repositories.NewCategorizationRuleRepository(config.DB),
+		repositories.NewIncomeRepository(config.DB),
+	)
+	recurrenceService := services.NewRecurrenceService(expenseRepo, expenseService)
+	services.NewRecurrenceScheduler(recurrenceService).Start()
+
 	// Porta do servidor
 	port := os.Getenv("PORT")
 	if port == "" {
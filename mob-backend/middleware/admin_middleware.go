@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"finance-backend/repositories"
+	"finance-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddleware restringe a rota a usuários administradores (users.is_admin)
+func AdminMiddleware(userRepo *repositories.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := userRepo.GetByID(c.GetUint("user_id"))
+		if err != nil {
+			utils.UnauthorizedResponse(c, "Usuário não encontrado")
+			c.Abort()
+			return
+		}
+
+		if !user.IsAdmin {
+			utils.ForbiddenResponse(c, "Acesso restrito a administradores")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -3,12 +3,13 @@ package middleware
 import (
 	"net/http"
 
-	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"finance-backend/repositories"
 	"finance-backend/utils"
+	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware valida o access token e verifica se a sessão não foi revogada
+func AuthMiddleware(sessionRepo *repositories.SessionRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if len(auth) < 8 || auth[:7] != "Bearer " {
@@ -16,16 +17,38 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(auth[7:], func(token *jwt.Token) (interface{}, error) {
-			return utils.JwtSecret, nil
-		})
-		if err != nil || !token.Valid {
+		claims, err := utils.ValidateToken(auth[7:])
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token inválido"})
 			return
 		}
 
-		claims := token.Claims.(jwt.MapClaims)
-		c.Set("user_id", uint(claims["user_id"].(float64)))
+		userIDClaim, okUser := claims["user_id"].(float64)
+		if !okUser {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token inválido"})
+			return
+		}
+		userID := uint(userIDClaim)
+
+		// Tokens sem sessão foram emitidos antes dos refresh tokens (validade de até 72h): continuam aceitos
+		// até expirar para o deploy não derrubar quem está logado, mas não podem ser revogados
+		var sessionID uint
+		if sessionIDClaim, ok := claims["sid"].(float64); ok {
+			sessionID = uint(sessionIDClaim)
+
+			active, err := sessionRepo.IsActive(sessionID, userID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar sessão"})
+				return
+			}
+			if !active {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Sessão expirada ou revogada"})
+				return
+			}
+		}
+
+		c.Set("user_id", userID)
+		c.Set("session_id", sessionID)
 		c.Next()
 	}
 }
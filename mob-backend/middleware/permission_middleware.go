@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"finance-backend/models"
+	"finance-backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission exige que o papel do usuário na família (definido pelo TenantMiddleware)
+// possua a permissão informada
+func RequirePermission(permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleInterface, exists := c.Get("family_role")
+		role, ok := roleInterface.(models.MemberRole)
+		if !exists || !ok || !role.Can(permission) {
+			utils.PermissionDeniedResponse(c, string(permission))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
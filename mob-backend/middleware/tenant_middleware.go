@@ -42,22 +42,26 @@ func TenantMiddleware(familyRepo *repositories.FamilyRepository) gin.HandlerFunc
 			return
 		}
 		
-		// Verificar se usuário tem acesso à família
-		hasAccess, err := familyRepo.UserHasAccess(userID, uint(familyID))
+		// Verificar se usuário tem acesso à família (e com qual papel)
+		role, member, err := familyRepo.GetUserRole(userID, uint(familyID))
 		if err != nil {
 			utils.InternalErrorResponse(c, "Erro ao verificar acesso")
 			c.Abort()
 			return
 		}
 		
-		if !hasAccess {
+		if role == "" {
 			utils.ForbiddenResponse(c, "Você não tem acesso a esta família")
 			c.Abort()
 			return
 		}
 		
-		// Adicionar family_id ao contexto para uso nos controllers
+		// Adicionar family_id e papel ao contexto para uso nos controllers e em RequirePermission
 		c.Set("family_id", uint(familyID))
+		c.Set("family_role", role)
+		if member != nil {
+			c.Set("family_member_id", member.ID)
+		}
 		
 		c.Next()
 	}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"finance-backend/config"
+	"finance-backend/migrations"
+)
+
+const migrateUsage = `Uso: main migrate <comando>
+
+Comandos:
+  up              aplica todas as migrations pendentes
+  down [-steps N] reverte as últimas N migrations aplicadas (padrão: 1)
+  status          lista migrations aplicadas e pendentes
+`
+
+// runMigrateCommand executa o subcomando "migrate" e retorna o código de saída
+func runMigrateCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	config.ConnectDB()
+
+	migrator, err := migrations.NewMigrator(config.DB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "erro ao carregar migrations: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("aplicada  %03d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("nenhuma migration pendente")
+		}
+
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ContinueOnError)
+		steps := flags.Int("steps", 1, "quantidade de migrations a reverter")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		reverted, err := migrator.Down(*steps)
+		for _, migration := range reverted {
+			fmt.Printf("revertida %03d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nenhuma migration aplicada")
+		}
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSÃO\tNOME\tESTADO\tAPLICADA EM")
+		for _, status := range statuses {
+			state := "pendente"
+			appliedAt := "-"
+			if status.Applied {
+				state = "aplicada"
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.ChecksumMismatch {
+				state = "checksum divergente"
+			}
+			if status.MissingFile {
+				state = "arquivo ausente"
+			}
+			fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		w.Flush()
+
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,16 @@
+-- Rollback: Initial schema for Family Finance Organizer
+
+DROP TABLE IF EXISTS projections CASCADE;
+DROP TABLE IF EXISTS emergency_funds CASCADE;
+DROP TABLE IF EXISTS investments CASCADE;
+DROP TABLE IF EXISTS expense_splits CASCADE;
+DROP TABLE IF EXISTS expenses CASCADE;
+DROP TABLE IF EXISTS expense_categories CASCADE;
+DROP TABLE IF EXISTS incomes CASCADE;
+DROP TABLE IF EXISTS family_members CASCADE;
+DROP TABLE IF EXISTS family_accounts CASCADE;
+DROP TABLE IF EXISTS gastos CASCADE;
+DROP TABLE IF EXISTS mes_data CASCADE;
+DROP TABLE IF EXISTS users CASCADE;
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,295 @@
+-- Migration: Initial schema for Family Finance Organizer
+-- Date: 2025-12-26
+-- Description: Schema base. Todas as instruções são idempotentes para que bancos
+-- criados anteriormente via AutoMigrate possam adotar o controle de migrations.
+
+-- =====================================================
+-- USERS
+-- =====================================================
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    username TEXT,
+    password TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users(username);
+
+-- =====================================================
+-- LEGADO: MES DATA / GASTOS (controle mensal antigo)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS mes_data (
+    id BIGSERIAL PRIMARY KEY,
+    mes_ano TEXT,
+    renda DECIMAL,
+    user_id BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_mesano ON mes_data(mes_ano, user_id);
+
+CREATE TABLE IF NOT EXISTS gastos (
+    id BIGSERIAL PRIMARY KEY,
+    categoria TEXT,
+    descricao TEXT,
+    valor DECIMAL,
+    mes_data_id BIGINT,
+
+    CONSTRAINT fk_mes_data_gastos FOREIGN KEY (mes_data_id) REFERENCES mes_data(id)
+);
+
+-- =====================================================
+-- FAMILY ACCOUNTS (conta familiar - multi-tenant)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS family_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    owner_user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_family_owner FOREIGN KEY (owner_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_family_accounts_owner ON family_accounts(owner_user_id);
+
+-- =====================================================
+-- FAMILY MEMBERS (membros da família)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS family_members (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    user_id BIGINT, -- nullable para dependentes
+    name TEXT NOT NULL,
+    role TEXT DEFAULT 'member', -- owner, member, dependent
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_member_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_member_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_family_members_family_account ON family_members(family_account_id);
+CREATE INDEX IF NOT EXISTS idx_family_members_user ON family_members(user_id);
+
+-- =====================================================
+-- INCOMES (rendas individuais)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS incomes (
+    id BIGSERIAL PRIMARY KEY,
+    family_member_id BIGINT NOT NULL,
+    type TEXT NOT NULL, -- CLT, PJ
+
+    -- Valores em centavos
+    gross_monthly_cents BIGINT DEFAULT 0,
+    food_voucher_cents BIGINT DEFAULT 0,
+    transport_voucher_cents BIGINT DEFAULT 0,
+    bonus_cents BIGINT DEFAULT 0,
+
+    -- Para PJ
+    simples_nacional_rate DECIMAL DEFAULT 0,
+    pro_labore_cents BIGINT DEFAULT 0,
+
+    -- Para CLT (calculado)
+    inss_cents BIGINT DEFAULT 0,
+    fgts_cents BIGINT DEFAULT 0,
+    irpf_cents BIGINT DEFAULT 0,
+
+    -- Líquido
+    net_monthly_cents BIGINT NOT NULL,
+
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    -- Referência mensal para histórico
+    reference_month BIGINT NOT NULL DEFAULT EXTRACT(MONTH FROM CURRENT_DATE),
+    reference_year BIGINT NOT NULL DEFAULT EXTRACT(YEAR FROM CURRENT_DATE),
+
+    CONSTRAINT fk_income_member FOREIGN KEY (family_member_id) REFERENCES family_members(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_incomes_family_member ON incomes(family_member_id);
+CREATE INDEX IF NOT EXISTS idx_incomes_active ON incomes(is_active);
+
+-- =====================================================
+-- EXPENSE CATEGORIES (categorias de despesas)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS expense_categories (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    icon TEXT,
+    color TEXT,
+    is_default BOOLEAN DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_categories_name ON expense_categories(name);
+
+-- Inserir categorias padrão
+INSERT INTO expense_categories (name, icon, color, is_default) VALUES
+    ('Moradia', '🏠', '#3B82F6', TRUE),
+    ('Alimentação', '🍽️', '#10B981', TRUE),
+    ('Transporte', '🚗', '#F59E0B', TRUE),
+    ('Saúde', '🏥', '#EF4444', TRUE),
+    ('Educação', '📚', '#8B5CF6', TRUE),
+    ('Lazer', '🎮', '#EC4899', TRUE),
+    ('Vestuário', '👔', '#6366F1', TRUE),
+    ('Utilidades', '💡', '#14B8A6', TRUE),
+    ('Outros', '📦', '#6B7280', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- =====================================================
+-- EXPENSES (despesas)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS expenses (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    amount_cents BIGINT NOT NULL,
+    due_day BIGINT DEFAULT 1,
+    is_fixed BOOLEAN DEFAULT TRUE,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    -- Referência mensal para histórico
+    reference_month BIGINT NOT NULL DEFAULT EXTRACT(MONTH FROM CURRENT_DATE),
+    reference_year BIGINT NOT NULL DEFAULT EXTRACT(YEAR FROM CURRENT_DATE),
+
+    CONSTRAINT fk_expense_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_expense_category FOREIGN KEY (category_id) REFERENCES expense_categories(id),
+    CONSTRAINT chk_due_day CHECK (due_day BETWEEN 1 AND 31)
+);
+
+-- Colunas ausentes em bancos criados via AutoMigrate
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS frequency TEXT NOT NULL DEFAULT 'monthly'
+    CONSTRAINT chk_expense_frequency CHECK (frequency IN ('monthly', 'yearly', 'one_time'));
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS expense_type TEXT NOT NULL DEFAULT 'expense'
+    CONSTRAINT chk_expense_type CHECK (expense_type IN ('expense', 'investment', 'emergency_fund'));
+
+CREATE INDEX IF NOT EXISTS idx_expenses_family_account ON expenses(family_account_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_category ON expenses(category_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_active ON expenses(is_active);
+CREATE INDEX IF NOT EXISTS idx_expenses_family_month ON expenses(family_account_id, reference_year, reference_month);
+
+-- =====================================================
+-- EXPENSE SPLITS (divisão de despesas entre membros)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS expense_splits (
+    id BIGSERIAL PRIMARY KEY,
+    expense_id BIGINT NOT NULL,
+    family_member_id BIGINT NOT NULL,
+    percentage DECIMAL NOT NULL, -- ex: 50.00
+    amount_cents BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_split_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_split_member FOREIGN KEY (family_member_id) REFERENCES family_members(id) ON DELETE CASCADE,
+    CONSTRAINT chk_percentage CHECK (percentage >= 0 AND percentage <= 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_splits_expense ON expense_splits(expense_id);
+CREATE INDEX IF NOT EXISTS idx_expense_splits_member ON expense_splits(family_member_id);
+
+-- =====================================================
+-- INVESTMENTS (investimentos)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS investments (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL, -- renda_fixa, renda_variavel, fundos, crypto, imoveis
+    monthly_contribution_cents BIGINT NOT NULL,
+    current_balance_cents BIGINT DEFAULT 0,
+    annual_return_rate DECIMAL NOT NULL, -- ex: 10.50
+    start_date TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    -- Referência mensal para histórico
+    reference_month BIGINT NOT NULL DEFAULT EXTRACT(MONTH FROM CURRENT_DATE),
+    reference_year BIGINT NOT NULL DEFAULT EXTRACT(YEAR FROM CURRENT_DATE),
+
+    CONSTRAINT fk_investment_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT chk_investment_type CHECK (type IN ('renda_fixa', 'renda_variavel', 'fundos', 'crypto', 'imoveis'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_investments_family_account ON investments(family_account_id);
+CREATE INDEX IF NOT EXISTS idx_investments_active ON investments(is_active);
+
+-- =====================================================
+-- EMERGENCY FUNDS (reserva de emergência - valores em reais)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS emergency_funds (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT UNIQUE NOT NULL,
+    target_months BIGINT NOT NULL,
+    monthly_expenses DECIMAL NOT NULL,
+    target_amount DECIMAL NOT NULL,
+    current_amount DECIMAL DEFAULT 0,
+    monthly_goal DECIMAL NOT NULL,
+    estimated_months BIGINT DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_emergency_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT chk_target_months CHECK (target_months >= 3)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_funds_family_account ON emergency_funds(family_account_id);
+
+-- =====================================================
+-- PROJECTIONS (projeções financeiras)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS projections (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    projection_date TIMESTAMPTZ NOT NULL,
+
+    -- Valores em centavos
+    total_income_cents BIGINT,
+    total_expenses_cents BIGINT,
+    investments_total_cents BIGINT,
+    emergency_fund_cents BIGINT,
+    net_worth_cents BIGINT,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_projection_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_projections_family_account ON projections(family_account_id);
+CREATE INDEX IF NOT EXISTS idx_projections_date ON projections(projection_date);
+CREATE INDEX IF NOT EXISTS idx_projections_family_date ON projections(family_account_id, projection_date);
+
+-- =====================================================
+-- TRIGGERS para updated_at
+-- =====================================================
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS update_family_accounts_updated_at ON family_accounts;
+DROP TRIGGER IF EXISTS update_family_members_updated_at ON family_members;
+DROP TRIGGER IF EXISTS update_incomes_updated_at ON incomes;
+DROP TRIGGER IF EXISTS update_expenses_updated_at ON expenses;
+DROP TRIGGER IF EXISTS update_investments_updated_at ON investments;
+DROP TRIGGER IF EXISTS update_emergency_funds_updated_at ON emergency_funds;
+
+CREATE TRIGGER update_family_accounts_updated_at BEFORE UPDATE ON family_accounts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_family_members_updated_at BEFORE UPDATE ON family_members FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_incomes_updated_at BEFORE UPDATE ON incomes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_expenses_updated_at BEFORE UPDATE ON expenses FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_investments_updated_at BEFORE UPDATE ON investments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_emergency_funds_updated_at BEFORE UPDATE ON emergency_funds FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
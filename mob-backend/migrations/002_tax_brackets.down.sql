@@ -0,0 +1,5 @@
+-- Rollback: Tax Brackets Configuration
+
+DROP TABLE IF EXISTS irpf_brackets;
+DROP TABLE IF EXISTS inss_brackets;
+DROP TABLE IF EXISTS tax_configurations;
@@ -0,0 +1,12 @@
+-- Rollback: Performance Optimization Indexes
+-- idx_expense_splits_expense e idx_expense_splits_member pertencem à 001 e são mantidos.
+
+DROP INDEX IF EXISTS idx_family_members_family_active;
+DROP INDEX IF EXISTS idx_incomes_member_active;
+DROP INDEX IF EXISTS idx_incomes_member_net;
+DROP INDEX IF EXISTS idx_expenses_family_active;
+DROP INDEX IF EXISTS idx_expenses_family_category;
+DROP INDEX IF EXISTS idx_expenses_family_frequency;
+DROP INDEX IF EXISTS idx_investments_family_active;
+DROP INDEX IF EXISTS idx_emergency_funds_family;
+DROP INDEX IF EXISTS idx_expenses_sum_monthly;
@@ -0,0 +1,4 @@
+-- Rollback: Auth sessions and rotating refresh tokens
+
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
@@ -0,0 +1,3 @@
+-- Rollback: Family invitations
+
+DROP TABLE IF EXISTS family_invitations;
@@ -0,0 +1,32 @@
+-- Migration: Family invitations
+-- Date: 2026-01-12
+-- Description: Convites para que um usuário real entre em uma família existente.
+-- O token é exibido apenas na criação; o banco guarda somente o hash SHA-256.
+
+CREATE TABLE IF NOT EXISTS family_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    family_member_id BIGINT, -- opcional: vincula o usuário a um membro já cadastrado
+    invited_by_user_id BIGINT NOT NULL,
+    token_hash TEXT NOT NULL,
+    email TEXT, -- opcional: restringe o aceite a este email
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'dependent')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by_user_id BIGINT,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_invitation_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_invitation_member FOREIGN KEY (family_member_id) REFERENCES family_members(id) ON DELETE CASCADE,
+    CONSTRAINT fk_invitation_invited_by FOREIGN KEY (invited_by_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_invitation_accepted_by FOREIGN KEY (accepted_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_family_invitations_token_hash ON family_invitations(token_hash);
+CREATE INDEX IF NOT EXISTS idx_family_invitations_family_pending ON family_invitations(family_account_id)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+DROP TRIGGER IF EXISTS update_family_invitations_updated_at ON family_invitations;
+CREATE TRIGGER update_family_invitations_updated_at BEFORE UPDATE ON family_invitations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
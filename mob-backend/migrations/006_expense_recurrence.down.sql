@@ -0,0 +1,9 @@
+-- Rollback: Expense recurrence rules
+
+DROP INDEX IF EXISTS idx_expenses_recurring_roots;
+DROP INDEX IF EXISTS idx_expenses_recurrence_occurrence;
+
+ALTER TABLE expenses DROP COLUMN IF EXISTS recurrence_parent_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS recurrence_end_date;
+ALTER TABLE expenses DROP COLUMN IF EXISTS recurrence_interval;
+ALTER TABLE expenses DROP COLUMN IF EXISTS recurrence_rule;
@@ -0,0 +1,19 @@
+-- Migration: Expense recurrence rules
+-- Date: 2026-01-19
+-- Description: Regras de recorrência em despesas. A despesa original (recurrence_parent_id NULL)
+-- guarda a regra; cada mês gerado é uma nova despesa apontando para ela, com seus próprios splits.
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS recurrence_rule TEXT NOT NULL DEFAULT 'none'
+    CHECK (recurrence_rule IN ('none', 'monthly', 'every_n_months', 'yearly'));
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS recurrence_interval INTEGER NOT NULL DEFAULT 1
+    CHECK (recurrence_interval >= 1);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS recurrence_end_date DATE;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS recurrence_parent_id BIGINT
+    REFERENCES expenses(id) ON DELETE SET NULL;
+
+-- Idempotência do gerador: no máximo uma ocorrência por regra e mês
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expenses_recurrence_occurrence
+    ON expenses(recurrence_parent_id, reference_year, reference_month);
+
+CREATE INDEX IF NOT EXISTS idx_expenses_recurring_roots ON expenses(family_account_id)
+    WHERE recurrence_rule <> 'none' AND recurrence_parent_id IS NULL AND is_active = true;
@@ -0,0 +1,12 @@
+-- Rollback: Credit cards and installment purchases
+
+DROP INDEX IF EXISTS idx_expenses_installment_purchase_id;
+DROP INDEX IF EXISTS idx_expenses_credit_card_statement;
+
+ALTER TABLE expenses DROP COLUMN IF EXISTS installment_count;
+ALTER TABLE expenses DROP COLUMN IF EXISTS installment_number;
+ALTER TABLE expenses DROP COLUMN IF EXISTS installment_purchase_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS credit_card_id;
+
+DROP TABLE IF EXISTS installment_purchases;
+DROP TABLE IF EXISTS credit_cards;
@@ -0,0 +1,68 @@
+-- Migration: Credit cards and installment purchases
+-- Date: 2026-01-26
+-- Description: Cartões de crédito com fechamento/vencimento/limite e compras parceladas.
+-- Cada parcela é uma linha em expenses no mês da fatura (mês de vencimento).
+
+-- =====================================================
+-- CREDIT CARDS
+-- =====================================================
+CREATE TABLE IF NOT EXISTS credit_cards (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    last_four_digits TEXT,
+    closing_day INTEGER NOT NULL CHECK (closing_day >= 1 AND closing_day <= 31),
+    due_day INTEGER NOT NULL CHECK (due_day >= 1 AND due_day <= 31),
+    limit_cents BIGINT NOT NULL CHECK (limit_cents >= 0),
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_credit_card_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_cards_family_account_id ON credit_cards(family_account_id);
+
+-- =====================================================
+-- INSTALLMENT PURCHASES
+-- =====================================================
+CREATE TABLE IF NOT EXISTS installment_purchases (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    credit_card_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    total_amount_cents BIGINT NOT NULL CHECK (total_amount_cents > 0),
+    installment_count INTEGER NOT NULL DEFAULT 1 CHECK (installment_count >= 1),
+    purchase_date DATE NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_installment_purchase_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_installment_purchase_card FOREIGN KEY (credit_card_id) REFERENCES credit_cards(id) ON DELETE CASCADE,
+    CONSTRAINT fk_installment_purchase_category FOREIGN KEY (category_id) REFERENCES expense_categories(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_installment_purchases_card ON installment_purchases(credit_card_id);
+
+-- =====================================================
+-- EXPENSES: vínculo das parcelas
+-- =====================================================
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS credit_card_id BIGINT
+    REFERENCES credit_cards(id) ON DELETE SET NULL;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS installment_purchase_id BIGINT
+    REFERENCES installment_purchases(id) ON DELETE CASCADE;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS installment_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS installment_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_expenses_credit_card_statement
+    ON expenses(credit_card_id, reference_year, reference_month) WHERE credit_card_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_expenses_installment_purchase_id ON expenses(installment_purchase_id);
+
+DROP TRIGGER IF EXISTS update_credit_cards_updated_at ON credit_cards;
+CREATE TRIGGER update_credit_cards_updated_at BEFORE UPDATE ON credit_cards FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_installment_purchases_updated_at ON installment_purchases;
+CREATE TRIGGER update_installment_purchases_updated_at BEFORE UPDATE ON installment_purchases FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback: Transaction imports
+
+DROP TABLE IF EXISTS import_rows;
+DROP TABLE IF EXISTS import_batches;
@@ -0,0 +1,63 @@
+-- Migration: Transaction imports
+-- Date: 2026-01-28
+-- Description: Importação de extratos (OFX/CSV). Cada arquivo gera um lote com as linhas
+-- em revisão; ao confirmar, as linhas selecionadas viram despesas e o lote guarda o vínculo
+-- para permitir desfazer a importação.
+
+-- =====================================================
+-- IMPORT BATCHES
+-- =====================================================
+CREATE TABLE IF NOT EXISTS import_batches (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    created_by_user_id BIGINT,
+    file_name TEXT,
+    format TEXT NOT NULL CHECK (format IN ('ofx', 'csv')),
+    layout TEXT,
+    status TEXT NOT NULL DEFAULT 'staged' CHECK (status IN ('staged', 'committed', 'undone')),
+    row_count INTEGER NOT NULL DEFAULT 0,
+    duplicate_count INTEGER NOT NULL DEFAULT 0,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    committed_at TIMESTAMPTZ,
+    undone_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_import_batch_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_import_batch_user FOREIGN KEY (created_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batches_family_account_id ON import_batches(family_account_id);
+
+-- =====================================================
+-- IMPORT ROWS
+-- =====================================================
+CREATE TABLE IF NOT EXISTS import_rows (
+    id BIGSERIAL PRIMARY KEY,
+    import_batch_id BIGINT NOT NULL,
+    line_number INTEGER NOT NULL,
+    transaction_date DATE NOT NULL,
+    description TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL, -- negativo = saída, positivo = entrada
+    external_id TEXT,             -- FITID do OFX
+    duplicate_expense_id BIGINT,
+    selected BOOLEAN NOT NULL DEFAULT false,
+    category_id BIGINT,
+    expense_id BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_import_row_batch FOREIGN KEY (import_batch_id) REFERENCES import_batches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_import_row_duplicate FOREIGN KEY (duplicate_expense_id) REFERENCES expenses(id) ON DELETE SET NULL,
+    CONSTRAINT fk_import_row_category FOREIGN KEY (category_id) REFERENCES expense_categories(id) ON DELETE SET NULL,
+    CONSTRAINT fk_import_row_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_rows_batch ON import_rows(import_batch_id, line_number);
+CREATE INDEX IF NOT EXISTS idx_import_rows_expense_id ON import_rows(expense_id) WHERE expense_id IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_import_batches_updated_at ON import_batches;
+CREATE TRIGGER update_import_batches_updated_at BEFORE UPDATE ON import_batches FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_import_rows_updated_at ON import_rows;
+CREATE TRIGGER update_import_rows_updated_at BEFORE UPDATE ON import_rows FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Rollback: Categorization rules
+
+ALTER TABLE import_rows DROP COLUMN IF EXISTS categorization_rule_id;
+
+DROP TABLE IF EXISTS categorization_rule_splits;
+DROP TABLE IF EXISTS categorization_rules;
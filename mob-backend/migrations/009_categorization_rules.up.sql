@@ -0,0 +1,56 @@
+-- Migration: Categorization rules
+-- Date: 2026-01-30
+-- Description: Regras por família para categorizar despesas automaticamente
+-- (texto/regex no nome ou descrição, faixa de valor opcional, prioridade).
+
+-- =====================================================
+-- CATEGORIZATION RULES
+-- =====================================================
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    match_field TEXT NOT NULL DEFAULT 'any' CHECK (match_field IN ('name', 'description', 'any')),
+    match_type TEXT NOT NULL DEFAULT 'contains' CHECK (match_type IN ('contains', 'regex')),
+    pattern TEXT NOT NULL,
+    min_amount_cents BIGINT CHECK (min_amount_cents >= 0),
+    max_amount_cents BIGINT CHECK (max_amount_cents >= 0),
+    category_id BIGINT NOT NULL,
+    is_fixed BOOLEAN, -- NULL = não altera
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_categorization_rule_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_categorization_rule_category FOREIGN KEY (category_id) REFERENCES expense_categories(id),
+    CONSTRAINT chk_categorization_rule_amount_range CHECK (
+        min_amount_cents IS NULL OR max_amount_cents IS NULL OR min_amount_cents <= max_amount_cents
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_family_priority
+    ON categorization_rules(family_account_id, priority DESC, id) WHERE is_active = true;
+
+-- =====================================================
+-- CATEGORIZATION RULE SPLITS (modelo de divisão)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS categorization_rule_splits (
+    id BIGSERIAL PRIMARY KEY,
+    categorization_rule_id BIGINT NOT NULL,
+    family_member_id BIGINT NOT NULL,
+    percentage DECIMAL NOT NULL CHECK (percentage >= 0 AND percentage <= 100),
+
+    CONSTRAINT fk_rule_split_rule FOREIGN KEY (categorization_rule_id) REFERENCES categorization_rules(id) ON DELETE CASCADE,
+    CONSTRAINT fk_rule_split_member FOREIGN KEY (family_member_id) REFERENCES family_members(id) ON DELETE CASCADE,
+    CONSTRAINT uq_rule_split_member UNIQUE (categorization_rule_id, family_member_id)
+);
+
+-- =====================================================
+-- IMPORT ROWS: regra aplicada na revisão
+-- =====================================================
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS categorization_rule_id BIGINT
+    REFERENCES categorization_rules(id) ON DELETE SET NULL;
+
+DROP TRIGGER IF EXISTS update_categorization_rules_updated_at ON categorization_rules;
+CREATE TRIGGER update_categorization_rules_updated_at BEFORE UPDATE ON categorization_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,47 @@
+-- Rollback: Family categories
+-- Atenção: despesas, compras, regras, linhas de importação e orçamentos em categorias de famílias são movidos
+-- para "Outros" antes de remover essas categorias.
+
+DROP TRIGGER IF EXISTS update_expense_categories_updated_at ON expense_categories;
+
+UPDATE expenses SET category_id = (SELECT id FROM expense_categories WHERE name = 'Outros' AND family_account_id IS NULL LIMIT 1)
+    WHERE category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL);
+UPDATE installment_purchases SET category_id = (SELECT id FROM expense_categories WHERE name = 'Outros' AND family_account_id IS NULL LIMIT 1)
+    WHERE category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL);
+UPDATE categorization_rules SET category_id = (SELECT id FROM expense_categories WHERE name = 'Outros' AND family_account_id IS NULL LIMIT 1)
+    WHERE category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL);
+UPDATE import_rows SET category_id = (SELECT id FROM expense_categories WHERE name = 'Outros' AND family_account_id IS NULL LIMIT 1)
+    WHERE category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL);
+
+-- Orçamentos (011) só existem se a tabela ainda não foi removida; os valores do mesmo mês são somados em "Outros"
+DO $$
+BEGIN
+    IF to_regclass('category_budgets') IS NOT NULL THEN
+        INSERT INTO category_budgets (family_account_id, category_id, year, month, amount_cents, rollover)
+        SELECT b.family_account_id, (SELECT id FROM expense_categories WHERE name = 'Outros' AND family_account_id IS NULL LIMIT 1),
+               b.year, b.month, SUM(b.amount_cents), BOOL_OR(b.rollover)
+        FROM category_budgets b
+        WHERE b.category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL)
+        GROUP BY b.family_account_id, b.year, b.month
+        ON CONFLICT (family_account_id, category_id, year, month)
+        DO UPDATE SET amount_cents = category_budgets.amount_cents + EXCLUDED.amount_cents;
+
+        DELETE FROM category_budgets
+        WHERE category_id IN (SELECT id FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL);
+    END IF;
+END $$;
+
+DELETE FROM expense_categories WHERE family_account_id IS NOT NULL OR parent_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_expense_categories_parent_id;
+DROP INDEX IF EXISTS idx_expense_categories_family_account_id;
+DROP INDEX IF EXISTS idx_expense_categories_family_name;
+DROP INDEX IF EXISTS idx_expense_categories_default_name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_categories_name ON expense_categories(name);
+
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS created_at;
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS is_active;
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS family_account_id;
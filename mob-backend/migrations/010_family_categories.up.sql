@@ -0,0 +1,27 @@
+-- Migration: Family categories
+-- Date: 2026-02-02
+-- Description: Categorias personalizadas por família sobre as categorias padrão,
+-- com subcategorias (um nível), ícone e cor. Exclusão é lógica (is_active).
+
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS family_account_id BIGINT
+    REFERENCES family_accounts(id) ON DELETE CASCADE;
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS parent_id BIGINT
+    REFERENCES expense_categories(id) ON DELETE CASCADE;
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS is_active BOOLEAN DEFAULT true;
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+-- O nome deixa de ser único globalmente: é único por nível dentro das categorias visíveis
+DROP INDEX IF EXISTS idx_expense_categories_name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_categories_default_name
+    ON expense_categories(LOWER(name), COALESCE(parent_id, 0))
+    WHERE family_account_id IS NULL AND is_active = true;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expense_categories_family_name
+    ON expense_categories(family_account_id, LOWER(name), COALESCE(parent_id, 0))
+    WHERE family_account_id IS NOT NULL AND is_active = true;
+CREATE INDEX IF NOT EXISTS idx_expense_categories_family_account_id ON expense_categories(family_account_id);
+CREATE INDEX IF NOT EXISTS idx_expense_categories_parent_id ON expense_categories(parent_id);
+
+DROP TRIGGER IF EXISTS update_expense_categories_updated_at ON expense_categories;
+CREATE TRIGGER update_expense_categories_updated_at BEFORE UPDATE ON expense_categories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- Rollback: Category budgets
+
+DROP TABLE IF EXISTS category_budgets;
@@ -0,0 +1,28 @@
+-- Migration: Category budgets
+-- Date: 2026-02-04
+-- Description: Orçamento mensal por categoria. Cada linha vale a partir do mês informado
+-- até existir outra linha mais recente para a mesma categoria; rollover leva a sobra para o mês seguinte.
+
+-- =====================================================
+-- CATEGORY BUDGETS
+-- =====================================================
+CREATE TABLE IF NOT EXISTS category_budgets (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    year INTEGER NOT NULL CHECK (year >= 2000),
+    month INTEGER NOT NULL CHECK (month >= 1 AND month <= 12),
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    rollover BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_category_budget_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_category_budget_category FOREIGN KEY (category_id) REFERENCES expense_categories(id) ON DELETE CASCADE,
+    CONSTRAINT uq_category_budget_month UNIQUE (family_account_id, category_id, year, month)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_budgets_family_period ON category_budgets(family_account_id, year, month);
+
+DROP TRIGGER IF EXISTS update_category_budgets_updated_at ON category_budgets;
+CREATE TRIGGER update_category_budgets_updated_at BEFORE UPDATE ON category_budgets FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Rollback: Settlements
+
+DROP TABLE IF EXISTS settlements;
+
+DROP INDEX IF EXISTS idx_expenses_paid_by_member;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS fk_expense_paid_by_member;
+ALTER TABLE expenses DROP COLUMN IF EXISTS paid_by_member_id;
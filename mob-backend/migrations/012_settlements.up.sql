@@ -0,0 +1,43 @@
+-- Migration: Settlements
+-- Date: 2026-02-05
+-- Description: Registra quem pagou cada despesa e os acertos de contas (transferências) entre membros
+
+-- =====================================================
+-- EXPENSES: membro pagador
+-- =====================================================
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS paid_by_member_id BIGINT;
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS fk_expense_paid_by_member;
+ALTER TABLE expenses ADD CONSTRAINT fk_expense_paid_by_member FOREIGN KEY (paid_by_member_id) REFERENCES family_members(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_expenses_paid_by_member ON expenses(paid_by_member_id);
+
+-- =====================================================
+-- SETTLEMENTS (pagamentos de acerto entre membros)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS settlements (
+    id BIGSERIAL PRIMARY KEY,
+    family_account_id BIGINT NOT NULL,
+    from_member_id BIGINT NOT NULL,
+    to_member_id BIGINT NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    reference_month INTEGER NOT NULL CHECK (reference_month >= 1 AND reference_month <= 12),
+    reference_year INTEGER NOT NULL CHECK (reference_year >= 2000),
+    paid_at DATE NOT NULL,
+    notes TEXT,
+    is_active BOOLEAN DEFAULT true,
+    created_by_user_id BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_settlement_family FOREIGN KEY (family_account_id) REFERENCES family_accounts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_settlement_from_member FOREIGN KEY (from_member_id) REFERENCES family_members(id) ON DELETE CASCADE,
+    CONSTRAINT fk_settlement_to_member FOREIGN KEY (to_member_id) REFERENCES family_members(id) ON DELETE CASCADE,
+    CONSTRAINT fk_settlement_created_by FOREIGN KEY (created_by_user_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_settlement_members CHECK (from_member_id <> to_member_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_settlements_family_period ON settlements(family_account_id, reference_year, reference_month);
+
+DROP TRIGGER IF EXISTS update_settlements_updated_at ON settlements;
+CREATE TRIGGER update_settlements_updated_at BEFORE UPDATE ON settlements FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
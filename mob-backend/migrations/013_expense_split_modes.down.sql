@@ -0,0 +1,4 @@
+-- Rollback: Expense split modes
+
+ALTER TABLE expense_splits DROP COLUMN IF EXISTS shares;
+ALTER TABLE expenses DROP COLUMN IF EXISTS split_mode;
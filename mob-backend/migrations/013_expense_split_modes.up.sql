@@ -0,0 +1,10 @@
+-- Migration: Expense split modes
+-- Date: 2026-02-06
+-- Description: Modo de divisão da despesa (porcentagem, valores exatos, pesos, partes iguais ou
+-- proporcional à renda). Os splits continuam guardando o valor em centavos, que soma exatamente o total.
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS split_mode TEXT NOT NULL DEFAULT 'percentage'
+    CHECK (split_mode IN ('percentage', 'exact', 'shares', 'equal', 'income'));
+
+ALTER TABLE expense_splits ADD COLUMN IF NOT EXISTS shares DECIMAL NOT NULL DEFAULT 0
+    CHECK (shares >= 0);
@@ -0,0 +1,15 @@
+-- Rollback: Income history
+
+-- Volta a deixar apenas a renda vigente mais recente de cada membro ativa
+UPDATE incomes
+SET is_active = false
+WHERE is_active = true
+  AND EXISTS (
+      SELECT 1 FROM incomes newer
+      WHERE newer.family_member_id = incomes.family_member_id
+        AND newer.is_active = true
+        AND (newer.reference_year * 12 + newer.reference_month, newer.id)
+            > (incomes.reference_year * 12 + incomes.reference_month, incomes.id)
+  );
+
+DROP INDEX IF EXISTS idx_incomes_member_effective;
@@ -0,0 +1,29 @@
+-- Migration: Income history
+-- Date: 2026-02-09
+-- Description: Rendas passam a ter vigência. reference_month/reference_year indicam o mês a partir
+-- do qual a renda vale; ela continua valendo até outra renda ativa do membro começar depois.
+-- is_active = false passa a significar apenas "excluída".
+
+-- Antes, criar uma renda desativava, na mesma transação, todas as outras rendas do membro, e excluir
+-- também só desativava. Reativa apenas as rendas claramente substituídas: o trigger grava em updated_at
+-- o início da transação que as desativou e a renda que as substituiu foi criada nessa mesma transação,
+-- então updated_at fica a poucos segundos do created_at de uma renda mais nova, que precisa estar ativa.
+-- Rendas desativadas por uma exclusão, ou cuja substituta também foi excluída, continuam inativas.
+-- Limites: editar a renda ativa também regravava updated_at das outras, apagando o rastro (essas ficam
+-- inativas); e uma renda excluída antes da última criação de renda do membro é reativada, pois a
+-- criação regravou seu updated_at.
+UPDATE incomes
+SET is_active = true
+WHERE is_active = false
+  AND EXISTS (
+      SELECT 1 FROM incomes newer
+      WHERE newer.family_member_id = incomes.family_member_id
+        AND newer.id <> incomes.id
+        AND newer.is_active = true
+        AND newer.created_at > incomes.created_at
+        AND newer.created_at BETWEEN incomes.updated_at - INTERVAL '5 seconds'
+                                 AND incomes.updated_at + INTERVAL '5 seconds'
+  );
+
+CREATE INDEX IF NOT EXISTS idx_incomes_member_effective
+    ON incomes(family_member_id, reference_year, reference_month) WHERE is_active = true;
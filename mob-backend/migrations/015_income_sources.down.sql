@@ -0,0 +1,14 @@
+-- Rollback: Income sources
+
+-- Membros com mais de uma fonte passam a ver apenas a versão mais recente entre todas elas
+DROP INDEX IF EXISTS idx_incomes_source_effective;
+CREATE INDEX IF NOT EXISTS idx_incomes_member_effective
+    ON incomes(family_member_id, reference_year, reference_month) WHERE is_active = true;
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_type;
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_end_month;
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS end_year;
+ALTER TABLE incomes DROP COLUMN IF EXISTS end_month;
+ALTER TABLE incomes DROP COLUMN IF EXISTS source_name;
+ALTER TABLE incomes DROP COLUMN IF EXISTS source_id;
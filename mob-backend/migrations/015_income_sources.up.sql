@@ -0,0 +1,33 @@
+-- Migration: Income sources
+-- Date: 2026-02-10
+-- Description: Um membro pode ter várias fontes de renda ao mesmo tempo (emprego, freelance, aluguel,
+-- aposentadoria, pensão). source_id aponta para a renda original da fonte: uma nova versão da mesma
+-- fonte a substitui a partir do seu mês de início, enquanto as outras fontes continuam valendo.
+-- end_month/end_year indicam o último mês em que a fonte vale (NULL = sem fim).
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS source_id BIGINT
+    REFERENCES incomes(id) ON DELETE CASCADE;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS source_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS end_month INT;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS end_year INT;
+
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_end_month
+    CHECK ((end_month IS NULL AND end_year IS NULL) OR (end_month BETWEEN 1 AND 12 AND end_year IS NOT NULL));
+
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_type
+    CHECK (type IN ('CLT', 'PJ', 'aluguel', 'aposentadoria', 'pensao', 'freelance'));
+
+-- Até aqui cada membro tinha uma única fonte: as rendas passam a ser versões da primeira renda do membro
+UPDATE incomes
+SET source_id = first_income.id
+FROM (
+    SELECT family_member_id, MIN(id) AS id
+    FROM incomes
+    GROUP BY family_member_id
+) first_income
+WHERE incomes.family_member_id = first_income.family_member_id
+  AND incomes.id <> first_income.id;
+
+DROP INDEX IF EXISTS idx_incomes_member_effective;
+CREATE INDEX IF NOT EXISTS idx_incomes_source_effective
+    ON incomes((COALESCE(source_id, id)), reference_year, reference_month) WHERE is_active = true;
@@ -0,0 +1,4 @@
+-- Rollback: Income tax calculation
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS irpf_dependents;
+ALTER TABLE incomes DROP COLUMN IF EXISTS tax_table_year;
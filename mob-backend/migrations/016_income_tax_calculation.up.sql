@@ -0,0 +1,9 @@
+-- Migration: Income tax calculation
+-- Date: 2026-02-11
+-- Description: Impostos da renda passam a ser calculados com as tabelas do banco (tax_configurations,
+-- inss_brackets, irpf_brackets) do ano de referência. Guarda o ano da tabela usada e quantos
+-- dependentes foram deduzidos no IRPF.
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS tax_table_year INT NOT NULL DEFAULT 0;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS irpf_dependents INT NOT NULL DEFAULT 0
+    CHECK (irpf_dependents >= 0);
@@ -0,0 +1,3 @@
+-- Rollback: Admin users
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
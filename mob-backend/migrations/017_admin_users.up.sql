@@ -0,0 +1,6 @@
+-- Migration: Admin users
+-- Date: 2026-02-12
+-- Description: Usuários administradores podem gerenciar as tabelas de impostos (/api/admin).
+-- Para promover um usuário: UPDATE users SET is_admin = true WHERE email = '...';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
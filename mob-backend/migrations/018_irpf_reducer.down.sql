@@ -0,0 +1,8 @@
+-- Rollback: IRPF reducer and simplified discount
+
+DROP TABLE IF EXISTS irpf_reducers;
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS irpf_simplified;
+ALTER TABLE incomes DROP COLUMN IF EXISTS irpf_reduction_cents;
+
+ALTER TABLE tax_configurations DROP COLUMN IF EXISTS irpf_simplified_discount;
@@ -0,0 +1,60 @@
+-- Migration: IRPF reducer and simplified discount
+-- Date: 2026-02-13
+-- Description: Redução mensal do IRPF por ano (Lei 15.270/2025: imposto zerado até R$ 5.000 de
+-- rendimentos e redução decrescente até R$ 7.350) e desconto simplificado mensal, usado no lugar
+-- das deduções legais (INSS e dependentes) quando for mais vantajoso. Cada renda guarda a redução aplicada
+-- e se usou o desconto simplificado.
+
+ALTER TABLE tax_configurations ADD COLUMN IF NOT EXISTS irpf_simplified_discount DECIMAL(10,2) NOT NULL DEFAULT 0
+    CHECK (irpf_simplified_discount >= 0);
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS irpf_reduction_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS irpf_simplified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS irpf_reducers (
+    id SERIAL PRIMARY KEY,
+    year INTEGER NOT NULL,
+    min_value DECIMAL(10,2) NOT NULL,
+    max_value DECIMAL(12,2) NOT NULL, -- 0 significa sem limite
+    fixed_amount DECIMAL(10,2) NOT NULL,
+    rate DECIMAL(8,6) NOT NULL DEFAULT 0,
+    "order" INTEGER NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_irpf_reducers_year ON irpf_reducers(year);
+
+-- Desconto simplificado da tabela semeada de 2025 (faixa de isenção de R$ 2.259,20)
+UPDATE tax_configurations SET irpf_simplified_discount = 564.80 WHERE year = 2025 AND irpf_simplified_discount = 0;
+
+-- 2026: se a tabela de exemplo semeada na 002 ainda não foi substituída, troca o IRPF pela tabela oficial
+-- (isenção até R$ 2.428,80). As faixas de INSS de 2026 continuam de exemplo: importe as oficiais.
+UPDATE irpf_brackets b
+SET min_value = v.min_value, max_value = v.max_value, rate = v.rate, deduction = v.deduction
+FROM (VALUES
+    (1, 0.00, 2428.80, 0.000, 0.00),
+    (2, 2428.81, 2826.65, 0.075, 182.16),
+    (3, 2826.66, 3751.05, 0.15, 394.16),
+    (4, 3751.06, 4664.68, 0.225, 675.49),
+    (5, 4664.69, 999999999.99, 0.275, 908.73)
+) AS v("order", min_value, max_value, rate, deduction)
+WHERE b.year = 2026 AND b."order" = v."order"
+  AND EXISTS (SELECT 1 FROM irpf_brackets WHERE year = 2026 AND "order" = 1 AND max_value = 2400.00 AND is_active = FALSE);
+
+UPDATE tax_configurations SET inss_deduction_per_dependent = 189.59
+WHERE year = 2026 AND inss_deduction_per_dependent = 195.00 AND is_active = FALSE;
+
+UPDATE tax_configurations SET irpf_simplified_discount = 607.20 WHERE year = 2026 AND irpf_simplified_discount = 0;
+
+-- Redução de 2026: até R$ 5.000 zera o imposto (até R$ 312,89); de R$ 5.000,01 a R$ 7.350,
+-- redução = R$ 978,62 - 0,133145 × rendimentos
+INSERT INTO irpf_reducers (year, min_value, max_value, fixed_amount, rate, "order", is_active)
+SELECT v.*, COALESCE((SELECT is_active FROM tax_configurations WHERE year = 2026), FALSE) FROM (VALUES
+    (2026, 0.00, 5000.00, 312.89, 0.000000, 1),
+    (2026, 5000.01, 7350.00, 978.62, 0.133145, 2)
+) AS v(year, min_value, max_value, fixed_amount, rate, "order")
+WHERE NOT EXISTS (SELECT 1 FROM irpf_reducers WHERE year = 2026);
+
+COMMENT ON TABLE irpf_reducers IS 'Redução mensal do IRPF por faixa de rendimentos e ano';
@@ -0,0 +1,4 @@
+-- Rollback: Income vacation month
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_vacation_month;
+ALTER TABLE incomes DROP COLUMN IF EXISTS vacation_month;
@@ -0,0 +1,9 @@
+-- Migration: Income vacation month
+-- Date: 2026-02-14
+-- Description: Mês de férias da renda CLT, usado na projeção anual (1/3 de férias e 13º salário).
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS vacation_month INT;
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_vacation_month;
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_vacation_month
+    CHECK (vacation_month IS NULL OR (vacation_month BETWEEN 1 AND 12 AND type = 'CLT'));
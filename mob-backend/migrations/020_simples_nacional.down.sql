@@ -0,0 +1,8 @@
+-- Rollback: Simples Nacional
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS fator_r;
+ALTER TABLE incomes DROP COLUMN IF EXISTS rbt12_cents;
+ALTER TABLE incomes DROP COLUMN IF EXISTS simples_annex;
+ALTER TABLE incomes DROP COLUMN IF EXISTS simples_auto_rate;
+
+DROP TABLE IF EXISTS pj_monthly_revenues;
@@ -0,0 +1,24 @@
+-- Migration: Simples Nacional
+-- Date: 2026-02-15
+-- Description: Histórico de faturamento mensal das fontes PJ (RBT12 e Fator R) e alíquota do Simples
+-- Nacional calculada pelos Anexos III/V quando a renda não informa uma alíquota fixa.
+
+CREATE TABLE IF NOT EXISTS pj_monthly_revenues (
+    id SERIAL PRIMARY KEY,
+    income_source_id INTEGER NOT NULL REFERENCES incomes(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    month INTEGER NOT NULL CHECK (month BETWEEN 1 AND 12),
+    revenue_cents BIGINT NOT NULL CHECK (revenue_cents >= 0),
+    payroll_cents BIGINT NOT NULL DEFAULT 0 CHECK (payroll_cents >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (income_source_id, year, month)
+);
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS simples_auto_rate BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS simples_annex VARCHAR(3) NOT NULL DEFAULT '';
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS rbt12_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS fator_r DECIMAL(6,4) NOT NULL DEFAULT 0;
+
+COMMENT ON TABLE pj_monthly_revenues IS 'Faturamento e folha mensais das fontes de renda PJ';
+COMMENT ON COLUMN incomes.simples_auto_rate IS 'TRUE = alíquota do Simples calculada pelo RBT12 e Fator R';
@@ -0,0 +1,7 @@
+-- Rollback: PJ pro-labore taxes
+
+UPDATE incomes
+SET irpf_cents = simples_tax_cents, inss_cents = 0
+WHERE type = 'PJ';
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS simples_tax_cents;
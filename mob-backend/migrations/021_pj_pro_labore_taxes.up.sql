@@ -0,0 +1,11 @@
+-- Migration: PJ pro-labore taxes
+-- Date: 2026-02-22
+-- Description: DAS do Simples Nacional em coluna própria. Nas rendas PJ, inss_cents e irpf_cents passam a
+-- guardar o INSS e o IRPF retidos do sócio sobre o pró-labore (antes irpf_cents guardava o DAS).
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS simples_tax_cents BIGINT NOT NULL DEFAULT 0;
+
+-- Rendas PJ já calculadas: o DAS sai de irpf_cents; INSS/IRPF do pró-labore são calculados na próxima edição
+UPDATE incomes
+SET simples_tax_cents = irpf_cents, irpf_cents = 0
+WHERE type = 'PJ' AND simples_tax_cents = 0;
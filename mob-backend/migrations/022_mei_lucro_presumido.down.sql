@@ -0,0 +1,13 @@
+-- Rollback: MEI and Lucro Presumido
+
+-- Rendas MEI e Lucro Presumido voltam a ser PJ (recalculadas na próxima edição)
+UPDATE incomes SET type = 'PJ' WHERE type IN ('MEI', 'lucro_presumido');
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_type;
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_type
+    CHECK (type IN ('CLT', 'PJ', 'aluguel', 'aposentadoria', 'pensao', 'freelance'));
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_business_activity;
+ALTER TABLE incomes DROP COLUMN IF EXISTS business_activity;
+
+ALTER TABLE incomes RENAME COLUMN company_tax_cents TO simples_tax_cents;
@@ -0,0 +1,15 @@
+-- Migration: MEI and Lucro Presumido
+-- Date: 2026-03-01
+-- Description: Rendas de MEI (DAS fixo) e de empresa no Lucro Presumido (PIS/COFINS/IRPJ/CSLL).
+-- simples_tax_cents passa a company_tax_cents: tributos pagos pela empresa em qualquer regime.
+-- business_activity define o DAS do MEI e as presunções do Lucro Presumido.
+
+ALTER TABLE incomes RENAME COLUMN simples_tax_cents TO company_tax_cents;
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS business_activity TEXT NOT NULL DEFAULT '';
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_business_activity
+    CHECK (business_activity IN ('', 'servicos', 'comercio', 'comercio_servicos'));
+
+ALTER TABLE incomes DROP CONSTRAINT IF EXISTS chk_incomes_type;
+ALTER TABLE incomes ADD CONSTRAINT chk_incomes_type
+    CHECK (type IN ('CLT', 'PJ', 'MEI', 'lucro_presumido', 'aluguel', 'aposentadoria', 'pensao', 'freelance'));
@@ -0,0 +1,10 @@
+-- Rollback: IRPF annual declaration
+
+ALTER TABLE expense_categories DROP CONSTRAINT IF EXISTS chk_expense_categories_irpf_deduction;
+ALTER TABLE expense_categories DROP COLUMN IF EXISTS irpf_deduction;
+
+DELETE FROM irpf_reducers WHERE annual = TRUE;
+ALTER TABLE irpf_reducers DROP COLUMN IF EXISTS annual;
+
+ALTER TABLE tax_configurations DROP COLUMN IF EXISTS irpf_education_cap;
+ALTER TABLE tax_configurations DROP COLUMN IF EXISTS irpf_annual_simplified_cap;
@@ -0,0 +1,37 @@
+-- Migration: IRPF annual declaration
+-- Date: 2026-03-08
+-- Description: Parâmetros anuais do IRPF por ano (teto do desconto simplificado de 20%, limite de
+-- instrução por pessoa e redução anual) para a simulação da declaração de ajuste, e a dedução do
+-- IRPF de cada categoria de despesa (saúde, educação, previdência privada PGBL e pensão alimentícia).
+
+ALTER TABLE tax_configurations ADD COLUMN IF NOT EXISTS irpf_annual_simplified_cap DECIMAL(10,2) NOT NULL DEFAULT 0
+    CHECK (irpf_annual_simplified_cap >= 0);
+ALTER TABLE tax_configurations ADD COLUMN IF NOT EXISTS irpf_education_cap DECIMAL(10,2) NOT NULL DEFAULT 0
+    CHECK (irpf_education_cap >= 0);
+
+-- Redutores anuais (declaração) ficam na mesma tabela dos mensais
+ALTER TABLE irpf_reducers ADD COLUMN IF NOT EXISTS annual BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE expense_categories ADD COLUMN IF NOT EXISTS irpf_deduction TEXT NOT NULL DEFAULT '';
+ALTER TABLE expense_categories ADD CONSTRAINT chk_expense_categories_irpf_deduction
+    CHECK (irpf_deduction IN ('', 'saude', 'educacao', 'previdencia_privada', 'pensao_alimenticia'));
+
+UPDATE expense_categories SET irpf_deduction = 'saude'
+WHERE family_account_id IS NULL AND name = 'Saúde' AND irpf_deduction = '';
+UPDATE expense_categories SET irpf_deduction = 'educacao'
+WHERE family_account_id IS NULL AND name = 'Educação' AND irpf_deduction = '';
+
+-- Desconto simplificado anual limitado a R$ 16.754,34 e instrução limitada a R$ 3.561,50 por pessoa
+UPDATE tax_configurations SET irpf_annual_simplified_cap = 16754.34
+WHERE year IN (2025, 2026) AND irpf_annual_simplified_cap = 0;
+UPDATE tax_configurations SET irpf_education_cap = 3561.50
+WHERE year IN (2025, 2026) AND irpf_education_cap = 0;
+
+-- Redução anual de 2026: até R$ 60.000 zera o imposto (até R$ 2.694,15); de R$ 60.000,01 a R$ 88.200,
+-- redução = R$ 8.429,73 - 0,095575 × rendimentos
+INSERT INTO irpf_reducers (year, min_value, max_value, fixed_amount, rate, "order", annual, is_active)
+SELECT v.*, TRUE, COALESCE((SELECT is_active FROM tax_configurations WHERE year = 2026), FALSE) FROM (VALUES
+    (2026, 0.00, 60000.00, 2694.15, 0.000000, 1),
+    (2026, 60000.01, 88200.00, 8429.73, 0.095575, 2)
+) AS v(year, min_value, max_value, fixed_amount, rate, "order")
+WHERE NOT EXISTS (SELECT 1 FROM irpf_reducers WHERE year = 2026 AND annual = TRUE);
@@ -0,0 +1,12 @@
+-- Rollback: Expense IRPF metadata
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS chk_expenses_provider_document;
+ALTER TABLE expenses DROP COLUMN IF EXISTS provider_document;
+ALTER TABLE expenses DROP COLUMN IF EXISTS provider_name;
+
+DROP INDEX IF EXISTS idx_expenses_irpf_beneficiary_member;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS fk_expense_irpf_beneficiary_member;
+ALTER TABLE expenses DROP COLUMN IF EXISTS irpf_beneficiary_member_id;
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS chk_expenses_irpf_deduction;
+ALTER TABLE expenses DROP COLUMN IF EXISTS irpf_deduction;
@@ -0,0 +1,21 @@
+-- Migration: Expense IRPF metadata
+-- Date: 2026-03-15
+-- Description: Dados de dedutibilidade no IRPF de cada despesa para a ficha "Pagamentos Efetuados":
+-- dedução própria (vazio = a da categoria; nenhuma = não dedutível), membro beneficiário
+-- (paciente, aluno ou alimentando) e nome e CPF/CNPJ (só dígitos) do prestador.
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS irpf_deduction TEXT NOT NULL DEFAULT '';
+ALTER TABLE expenses ADD CONSTRAINT chk_expenses_irpf_deduction
+    CHECK (irpf_deduction IN ('', 'nenhuma', 'saude', 'educacao', 'previdencia_privada', 'pensao_alimenticia'));
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS irpf_beneficiary_member_id BIGINT;
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS fk_expense_irpf_beneficiary_member;
+ALTER TABLE expenses ADD CONSTRAINT fk_expense_irpf_beneficiary_member FOREIGN KEY (irpf_beneficiary_member_id) REFERENCES family_members(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_expenses_irpf_beneficiary_member ON expenses(irpf_beneficiary_member_id);
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS provider_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS provider_document VARCHAR(14) NOT NULL DEFAULT '';
+ALTER TABLE expenses ADD CONSTRAINT chk_expenses_provider_document
+    CHECK (provider_document = '' OR provider_document ~ '^([0-9]{11}|[0-9]{14})$');
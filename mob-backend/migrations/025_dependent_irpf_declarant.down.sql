@@ -0,0 +1,5 @@
+-- Rollback: Dependent IRPF declarant
+
+DROP INDEX IF EXISTS idx_family_members_irpf_declarant;
+ALTER TABLE family_members DROP CONSTRAINT IF EXISTS fk_family_member_irpf_declarant;
+ALTER TABLE family_members DROP COLUMN IF EXISTS irpf_declarant_member_id;
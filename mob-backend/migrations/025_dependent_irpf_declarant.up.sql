@@ -0,0 +1,11 @@
+-- Migration: Dependent IRPF declarant
+-- Date: 2026-03-22
+-- Description: Membro que deduz cada dependente no IRPF. Sem declarante (ou com um declarante
+-- inativo), o dependente é deduzido pelo membro do dono da família, para não ser deduzido duas vezes.
+
+ALTER TABLE family_members ADD COLUMN IF NOT EXISTS irpf_declarant_member_id BIGINT;
+
+ALTER TABLE family_members DROP CONSTRAINT IF EXISTS fk_family_member_irpf_declarant;
+ALTER TABLE family_members ADD CONSTRAINT fk_family_member_irpf_declarant FOREIGN KEY (irpf_declarant_member_id) REFERENCES family_members(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_family_members_irpf_declarant ON family_members(irpf_declarant_member_id);
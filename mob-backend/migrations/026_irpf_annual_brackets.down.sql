@@ -0,0 +1,4 @@
+-- Rollback: IRPF annual brackets
+
+DELETE FROM irpf_brackets WHERE annual = TRUE;
+ALTER TABLE irpf_brackets DROP COLUMN IF EXISTS annual;
@@ -0,0 +1,18 @@
+-- Migration: IRPF annual brackets
+-- Date: 2026-03-29
+-- Description: Tabela progressiva anual da declaração de ajuste, na mesma tabela das faixas mensais.
+-- Quando a tabela mensal muda durante o ano (maio de 2025) a anual não é a mensal × 12; sem faixas
+-- anuais cadastradas a declaração continua usando a mensal × 12 e avisa.
+
+ALTER TABLE irpf_brackets ADD COLUMN IF NOT EXISTS annual BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Ano-calendário 2025: 4 meses com isenção até R$ 2.259,20 e 8 meses até R$ 2.428,80
+INSERT INTO irpf_brackets (year, min_value, max_value, rate, deduction, "order", annual, is_active)
+SELECT v.*, TRUE, COALESCE((SELECT is_active FROM tax_configurations WHERE year = 2025), FALSE) FROM (VALUES
+    (2025, 0.00, 28467.20, 0.000, 0.00, 1),
+    (2025, 28467.21, 33919.80, 0.075, 2135.04, 2),
+    (2025, 33919.81, 45012.60, 0.15, 4679.03, 3),
+    (2025, 45012.61, 55976.16, 0.225, 8054.97, 4),
+    (2025, 55976.17, 999999999.99, 0.275, 10853.78, 5)
+) AS v(year, min_value, max_value, rate, deduction, "order")
+WHERE NOT EXISTS (SELECT 1 FROM irpf_brackets WHERE year = 2025 AND annual = TRUE);
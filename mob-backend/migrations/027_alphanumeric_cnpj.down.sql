@@ -0,0 +1,8 @@
+-- Rollback: Alphanumeric CNPJ
+-- CNPJs alfanuméricos não cabem na regra anterior (só dígitos) e são apagados.
+
+UPDATE expenses SET provider_document = '' WHERE provider_document ~ '[A-Z]';
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS chk_expenses_provider_document;
+ALTER TABLE expenses ADD CONSTRAINT chk_expenses_provider_document
+    CHECK (provider_document = '' OR provider_document ~ '^([0-9]{11}|[0-9]{14})$');
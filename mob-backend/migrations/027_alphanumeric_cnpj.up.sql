@@ -0,0 +1,8 @@
+-- Migration: Alphanumeric CNPJ
+-- Date: 2026-04-05
+-- Description: CPF/CNPJ do prestador aceita o CNPJ alfanumérico (IN RFB 2.229/2024): 12 caracteres
+-- de 0-9 ou A-Z (maiúsculas, sem pontuação) e 2 dígitos verificadores.
+
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS chk_expenses_provider_document;
+ALTER TABLE expenses ADD CONSTRAINT chk_expenses_provider_document
+    CHECK (provider_document = '' OR provider_document ~ '^([0-9]{11}|[0-9A-Z]{12}[0-9]{2})$');
@@ -0,0 +1,5 @@
+-- Rollback: Fixed expense recurrence
+-- As regras preenchidas não se distinguem das cadastradas pelo usuário depois da migration e são
+-- mantidas; as ocorrências já geradas também continuam.
+
+SELECT 1;
@@ -0,0 +1,24 @@
+-- Migration: Fixed expense recurrence
+-- Date: 2026-04-12
+-- Description: Despesas fixas criadas antes das regras de recorrência (006) ficaram com 'none' e não
+-- são geradas nos meses seguintes. Passam a recorrer conforme a frequência: mensal vira 'monthly' e
+-- anual vira 'yearly'. Despesas de pagamento único e as que o usuário já relança manualmente não
+-- mudam: quando há uma despesa fixa ativa mais recente com o mesmo nome na família, só a mais recente
+-- vira a despesa original, evitando uma ocorrência duplicada por mês.
+
+UPDATE expenses
+SET recurrence_rule = CASE frequency WHEN 'yearly' THEN 'yearly' ELSE 'monthly' END
+WHERE recurrence_rule = 'none'
+  AND recurrence_parent_id IS NULL
+  AND is_fixed = true
+  AND is_active = true
+  AND frequency IN ('monthly', 'yearly')
+  AND NOT EXISTS (
+      SELECT 1 FROM expenses newer
+      WHERE newer.family_account_id = expenses.family_account_id
+        AND lower(newer.name) = lower(expenses.name)
+        AND newer.is_fixed = true
+        AND newer.is_active = true
+        AND (newer.reference_year * 12 + newer.reference_month, newer.id)
+            > (expenses.reference_year * 12 + expenses.reference_month, expenses.id)
+  );
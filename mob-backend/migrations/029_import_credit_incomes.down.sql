@@ -0,0 +1,7 @@
+-- Rollback: Import credit incomes
+
+DROP INDEX IF EXISTS idx_import_rows_income_id;
+ALTER TABLE import_rows DROP CONSTRAINT IF EXISTS fk_import_row_income;
+ALTER TABLE import_rows DROP CONSTRAINT IF EXISTS fk_import_row_family_member;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS income_id;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS family_member_id;
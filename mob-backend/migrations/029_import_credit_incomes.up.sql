@@ -0,0 +1,17 @@
+-- Migration: Import credit incomes
+-- Date: 2026-04-19
+-- Description: Entradas do extrato podem ser confirmadas como rendas. Cada linha guarda o membro que
+-- recebeu a entrada (escolhido na revisão) e a renda criada ao confirmar, para permitir desfazer.
+
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS family_member_id BIGINT;
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS income_id BIGINT;
+
+ALTER TABLE import_rows DROP CONSTRAINT IF EXISTS fk_import_row_family_member;
+ALTER TABLE import_rows ADD CONSTRAINT fk_import_row_family_member
+    FOREIGN KEY (family_member_id) REFERENCES family_members(id) ON DELETE SET NULL;
+
+ALTER TABLE import_rows DROP CONSTRAINT IF EXISTS fk_import_row_income;
+ALTER TABLE import_rows ADD CONSTRAINT fk_import_row_income
+    FOREIGN KEY (income_id) REFERENCES incomes(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_import_rows_income_id ON import_rows(income_id) WHERE income_id IS NOT NULL;
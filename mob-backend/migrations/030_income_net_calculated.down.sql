@@ -0,0 +1,3 @@
+-- Rollback: Income net calculated
+
+ALTER TABLE incomes DROP COLUMN IF EXISTS net_calculated;
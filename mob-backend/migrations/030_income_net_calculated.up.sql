@@ -0,0 +1,22 @@
+-- Migration: Income net calculated
+-- Date: 2026-04-26
+-- Description: Marca as rendas cujo líquido é calculado a partir do bruto, para recalculá-lo a cada edição
+-- mesmo quando o bruto não é reenviado. Nas rendas existentes, é calculado o líquido que bate com o bruto
+-- menos os impostos gravados mais os benefícios (fontes de pessoa física e MEI) e o das rendas PJ com a
+-- alíquota do Simples automática, que sempre foi recalculado. As demais rendas PJ e de Lucro Presumido
+-- ficam com o líquido informado até serem editadas só com o bruto.
+
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS net_calculated BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE incomes
+SET net_calculated = true
+WHERE tax_table_year > 0
+  AND (
+      (type IN ('CLT', 'aluguel', 'aposentadoria', 'pensao', 'freelance')
+       AND net_monthly_cents = gross_monthly_cents - inss_cents - irpf_cents
+                               + food_voucher_cents + transport_voucher_cents + bonus_cents)
+      OR (type = 'MEI'
+          AND net_monthly_cents = gross_monthly_cents - company_tax_cents
+                                  + food_voucher_cents + transport_voucher_cents + bonus_cents)
+      OR (type = 'PJ' AND simples_auto_rate = true)
+  );
@@ -0,0 +1,243 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"finance-backend/models"
+)
+
+// advisoryLockKey serializa execuções concorrentes (ex: várias réplicas subindo juntas)
+const advisoryLockKey = 726_590_001
+
+// Migrator aplica e reverte migrations registrando-as em schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// MigrationStatus descreve o estado de uma migration no banco
+type MigrationStatus struct {
+	Version          int        `json:"version"`
+	Name             string     `json:"name"`
+	Applied          bool       `json:"applied"`
+	AppliedAt        *time.Time `json:"applied_at,omitempty"`
+	ChecksumMismatch bool       `json:"checksum_mismatch"`
+	MissingFile      bool       `json:"missing_file"` // aplicada no banco, mas ausente no binário
+}
+
+// NewMigrator cria um migrator com as migrations embutidas no binário
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// ensureTable cria a tabela schema_migrations se ainda não existir
+func (m *Migrator) ensureTable() error {
+	return m.db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`).Error
+}
+
+// applied retorna as migrations registradas no banco indexadas por versão
+func (m *Migrator) applied() (map[int]models.SchemaMigration, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var rows []models.SchemaMigration
+	if err := m.db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]models.SchemaMigration, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+	return result, nil
+}
+
+// Status retorna o estado de todas as migrations conhecidas e aplicadas
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	known := make(map[int]bool)
+
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = row.Checksum != migration.Checksum
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for version, row := range applied {
+		if known[version] {
+			continue
+		}
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:     row.Version,
+			Name:        row.Name,
+			Applied:     true,
+			AppliedAt:   &appliedAt,
+			MissingFile: true,
+		})
+	}
+
+	return statuses, nil
+}
+
+// Pending retorna as migrations ainda não aplicadas, em ordem
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Verify falha se alguma migration aplicada foi alterada ou não existe mais no binário
+func (m *Migrator) Verify() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if status.ChecksumMismatch {
+			return fmt.Errorf("migration %03d_%s foi alterada após ser aplicada (checksum divergente)", status.Version, status.Name)
+		}
+		if status.MissingFile {
+			return fmt.Errorf("migration %03d_%s está aplicada no banco mas não existe neste binário", status.Version, status.Name)
+		}
+	}
+	return nil
+}
+
+// Up aplica todas as migrations pendentes, cada uma em sua própria transação
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := m.Verify(); err != nil {
+		return nil, err
+	}
+
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []Migration{}
+	for _, migration := range pending {
+		ran, err := m.apply(migration)
+		if err != nil {
+			return applied, fmt.Errorf("erro ao aplicar migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+
+	return applied, nil
+}
+
+// apply executa uma migration; retorna false se outra instância já a aplicou
+func (m *Migrator) apply(migration Migration) (bool, error) {
+	ran := false
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(migration.UpSQL).Error; err != nil {
+			return err
+		}
+
+		ran = true
+		return tx.Create(&models.SchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+
+	return ran, err
+}
+
+// Down reverte as últimas `steps` migrations aplicadas, da mais recente para a mais antiga
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("quantidade de passos deve ser positiva")
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := []Migration{}
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if migration.DownSQL == "" {
+			return reverted, fmt.Errorf("migration %03d_%s não possui arquivo .down.sql", migration.Version, migration.Name)
+		}
+
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(migration.DownSQL).Error; err != nil {
+				return err
+			}
+			return tx.Where("version = ?", migration.Version).Delete(&models.SchemaMigration{}).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("erro ao reverter migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+type RuleMatchField string
+
+const (
+	RuleMatchName        RuleMatchField = "name"
+	RuleMatchDescription RuleMatchField = "description"
+	RuleMatchAny         RuleMatchField = "any" // nome ou descrição
+)
+
+type RuleMatchType string
+
+const (
+	RuleMatchContains RuleMatchType = "contains" // trecho do texto, sem diferenciar maiúsculas
+	RuleMatchRegex    RuleMatchType = "regex"    // expressão regular, sem diferenciar maiúsculas
+)
+
+// CategorizationRule regra da família para categorizar despesas automaticamente.
+// Regras com maior prioridade são avaliadas primeiro; a primeira que casar é aplicada.
+type CategorizationRule struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	FamilyAccountID uint           `gorm:"not null;index" json:"family_account_id"`
+	Name            string         `gorm:"not null" json:"name"`
+	MatchField      RuleMatchField `gorm:"not null;default:'any'" json:"match_field"`
+	MatchType       RuleMatchType  `gorm:"not null;default:'contains'" json:"match_type"`
+	Pattern         string         `gorm:"not null" json:"pattern"`
+	MinAmountCents  *int64         `json:"min_amount_cents,omitempty"`
+	MaxAmountCents  *int64         `json:"max_amount_cents,omitempty"`
+	CategoryID      uint           `gorm:"not null" json:"category_id"`
+	IsFixed         *bool          `json:"is_fixed,omitempty"` // nil = não altera
+	Priority        int            `gorm:"not null;default:0" json:"priority"`
+	IsActive        bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+
+	// Relacionamentos
+	Category ExpenseCategory           `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Splits   []CategorizationRuleSplit `gorm:"foreignKey:CategorizationRuleID" json:"splits,omitempty"` // modelo de divisão (opcional)
+}
+
+// CategorizationRuleSplit porcentagem de um membro no modelo de divisão da regra
+type CategorizationRuleSplit struct {
+	ID                   uint    `gorm:"primaryKey" json:"id"`
+	CategorizationRuleID uint    `gorm:"not null;index" json:"categorization_rule_id"`
+	FamilyMemberID       uint    `gorm:"not null" json:"family_member_id"`
+	Percentage           float64 `gorm:"not null" json:"percentage"`
+}
+
+// CompilePattern compila o padrão da regra (regex sem diferenciar maiúsculas)
+func (r *CategorizationRule) CompilePattern() (*regexp.Regexp, error) {
+	if r.MatchType == RuleMatchRegex {
+		return regexp.Compile("(?i)" + r.Pattern)
+	}
+	return regexp.Compile("(?i)" + regexp.QuoteMeta(strings.TrimSpace(r.Pattern)))
+}
+
+// Matches indica se a regra casa com o nome/descrição e o valor (em centavos, positivo) informados
+func (r *CategorizationRule) Matches(name, description string, amountCents int64) bool {
+	if r.MinAmountCents != nil && amountCents < *r.MinAmountCents {
+		return false
+	}
+	if r.MaxAmountCents != nil && amountCents > *r.MaxAmountCents {
+		return false
+	}
+
+	pattern, err := r.CompilePattern()
+	if err != nil {
+		return false
+	}
+
+	switch r.MatchField {
+	case RuleMatchName:
+		return pattern.MatchString(name)
+	case RuleMatchDescription:
+		return pattern.MatchString(description)
+	default:
+		return pattern.MatchString(name) || pattern.MatchString(description)
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// CategoryBudget orçamento mensal de uma categoria.
+// Vale a partir de Month/Year até existir outro orçamento mais recente para a mesma categoria.
+type CategoryBudget struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	FamilyAccountID uint      `gorm:"not null;index" json:"family_account_id"`
+	CategoryID      uint      `gorm:"not null" json:"category_id"`
+	Year            int       `gorm:"not null" json:"year"`
+	Month           int       `gorm:"not null" json:"month"`
+	AmountCents     int64     `gorm:"not null" json:"amount_cents"` // 0 = sem orçamento a partir deste mês
+	Rollover        bool      `gorm:"not null" json:"rollover"`     // sobra do mês é somada ao mês seguinte
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relacionamentos
+	Category ExpenseCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+}
+
+// MonthIndex retorna o mês como número sequencial (ano*12 + mês - 1), útil para comparar períodos
+func (b *CategoryBudget) MonthIndex() int {
+	return b.Year*12 + b.Month - 1
+}
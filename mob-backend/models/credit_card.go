@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// CreditCard cartão de crédito da família.
+// A fatura de um mês é identificada pelo mês de vencimento.
+type CreditCard struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	FamilyAccountID uint      `gorm:"not null;index" json:"family_account_id"`
+	Name            string    `gorm:"not null" json:"name"` // ex: "Nubank Roxinho"
+	LastFourDigits  string    `json:"last_four_digits"`
+	ClosingDay      int       `gorm:"not null" json:"closing_day"` // dia de fechamento (1-31)
+	DueDay          int       `gorm:"not null" json:"due_day"`     // dia de vencimento (1-31)
+	LimitCents      int64     `gorm:"not null" json:"limit_cents"`
+	IsActive        bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relacionamentos
+	FamilyAccount FamilyAccount `gorm:"foreignKey:FamilyAccountID" json:"family_account,omitempty"`
+}
+
+// InstallmentPurchase compra no cartão (à vista ou parcelada).
+// Cada parcela é materializada como uma Expense no mês da fatura correspondente.
+type InstallmentPurchase struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	FamilyAccountID  uint      `gorm:"not null;index" json:"family_account_id"`
+	CreditCardID     uint      `gorm:"not null;index" json:"credit_card_id"`
+	CategoryID       uint      `gorm:"not null" json:"category_id"`
+	Name             string    `gorm:"not null" json:"name"`
+	Description      string    `json:"description"`
+	TotalAmountCents int64     `gorm:"not null" json:"total_amount_cents"`
+	InstallmentCount int       `gorm:"not null;default:1" json:"installment_count"`
+	PurchaseDate     time.Time `gorm:"type:date;not null" json:"purchase_date"`
+	IsActive         bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relacionamentos
+	CreditCard   CreditCard      `gorm:"foreignKey:CreditCardID" json:"credit_card,omitempty"`
+	Category     ExpenseCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Installments []Expense       `gorm:"foreignKey:InstallmentPurchaseID" json:"installments,omitempty"`
+}
+
+// StatementForPurchase retorna o mês/ano da fatura (mês de vencimento) em que cai uma compra.
+// Compras feitas a partir do dia de fechamento entram na fatura seguinte.
+func (c *CreditCard) StatementForPurchase(purchaseDate time.Time) (month, year int) {
+	closingMonth := time.Date(purchaseDate.Year(), purchaseDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if purchaseDate.Day() >= clampDay(purchaseDate.Year(), purchaseDate.Month(), c.ClosingDay) {
+		closingMonth = closingMonth.AddDate(0, 1, 0)
+	}
+
+	// Vencimento antes (ou no dia) do fechamento cai no mês seguinte ao fechamento
+	dueMonth := closingMonth
+	if c.DueDay <= c.ClosingDay {
+		dueMonth = dueMonth.AddDate(0, 1, 0)
+	}
+
+	return int(dueMonth.Month()), dueMonth.Year()
+}
+
+// DueDate retorna a data de vencimento da fatura do mês informado
+func (c *CreditCard) DueDate(month, year int) time.Time {
+	return time.Date(year, time.Month(month), clampDay(year, time.Month(month), c.DueDay), 0, 0, 0, 0, time.UTC)
+}
+
+// ClosingDate retorna a data de fechamento da fatura do mês informado
+func (c *CreditCard) ClosingDate(month, year int) time.Time {
+	closingMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if c.DueDay <= c.ClosingDay {
+		closingMonth = closingMonth.AddDate(0, -1, 0)
+	}
+
+	return time.Date(closingMonth.Year(), closingMonth.Month(),
+		clampDay(closingMonth.Year(), closingMonth.Month(), c.ClosingDay), 0, 0, 0, 0, time.UTC)
+}
+
+// clampDay limita o dia ao último dia do mês (ex: dia 31 em fevereiro)
+func clampDay(year int, month time.Month, day int) int {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		return lastDay
+	}
+	return day
+}
@@ -2,6 +2,31 @@ package models
 
 import "time"
 
+type ExpenseFrequency string
+
+const (
+	FrequencyMonthly ExpenseFrequency = "monthly"
+	FrequencyYearly  ExpenseFrequency = "yearly"
+	FrequencyOneTime ExpenseFrequency = "one_time"
+)
+
+type ExpenseType string
+
+const (
+	ExpenseTypeExpense       ExpenseType = "expense"
+	ExpenseTypeInvestment    ExpenseType = "investment"
+	ExpenseTypeEmergencyFund ExpenseType = "emergency_fund"
+)
+
+type RecurrenceRule string
+
+const (
+	RecurrenceNone         RecurrenceRule = "none"
+	RecurrenceMonthly      RecurrenceRule = "monthly"
+	RecurrenceEveryNMonths RecurrenceRule = "every_n_months"
+	RecurrenceYearly       RecurrenceRule = "yearly"
+)
+
 type Expense struct {
 	ID              uint             `gorm:"primaryKey" json:"id"`
 	FamilyAccountID uint             `gorm:"not null;index" json:"family_account_id"`
@@ -9,18 +34,94 @@ type Expense struct {
 	Name            string           `gorm:"not null" json:"name"` // ex: "Aluguel"
 	Description     string           `json:"description"`
 	AmountCents     int64            `gorm:"not null" json:"amount_cents"`
+	Frequency       ExpenseFrequency `gorm:"default:'monthly'" json:"frequency"`
+	ExpenseType     ExpenseType      `gorm:"default:'expense'" json:"expense_type"`
 	DueDay          int              `gorm:"default:1" json:"due_day"` // dia do vencimento (1-31)
-	IsFixed         bool             `gorm:"default:true" json:"is_fixed"`
+	IsFixed         bool             `json:"is_fixed"`
 	IsActive        bool             `gorm:"default:true" json:"is_active"`
+	SplitMode       SplitMode        `gorm:"default:'percentage'" json:"split_mode"`
 	CreatedAt       time.Time        `json:"created_at"`
 	UpdatedAt       time.Time        `json:"updated_at"`
 	
 	// Campos de referência mensal para histórico
 	ReferenceMonth int `gorm:"not null;default:EXTRACT(MONTH FROM CURRENT_DATE)" json:"reference_month"`
 	ReferenceYear  int `gorm:"not null;default:EXTRACT(YEAR FROM CURRENT_DATE)" json:"reference_year"`
+	
+	// Recorrência: a despesa original guarda a regra e as ocorrências geradas apontam para ela
+	RecurrenceRule     RecurrenceRule `gorm:"default:'none'" json:"recurrence_rule"`
+	RecurrenceInterval int            `gorm:"default:1" json:"recurrence_interval"` // every_n_months: a cada N meses
+	RecurrenceEndDate  *time.Time     `gorm:"type:date" json:"recurrence_end_date,omitempty"`
+	RecurrenceParentID *uint          `gorm:"index" json:"recurrence_parent_id,omitempty"`
+	
+	// Cartão de crédito: parcelas de uma compra caem no mês da fatura correspondente
+	CreditCardID          *uint `gorm:"index" json:"credit_card_id,omitempty"`
+	InstallmentPurchaseID *uint `gorm:"index" json:"installment_purchase_id,omitempty"`
+	InstallmentNumber     int   `gorm:"default:0" json:"installment_number,omitempty"` // 1..InstallmentCount
+	InstallmentCount      int   `gorm:"default:0" json:"installment_count,omitempty"`
+	
+	// Quem pagou a despesa (base do acerto de contas entre membros); nil = não informado
+	PaidByMemberID *uint `gorm:"index" json:"paid_by_member_id,omitempty"`
+	
+	// IRPF: dedução da despesa (vazio = a da categoria), beneficiário (nil = quem pagou) e prestador do serviço
+	IRPFDeduction           IRPFDeduction `gorm:"default:''" json:"irpf_deduction,omitempty"`
+	IRPFBeneficiaryMemberID *uint         `gorm:"index" json:"irpf_beneficiary_member_id,omitempty"`
+	ProviderName            string        `gorm:"default:''" json:"provider_name,omitempty"`
+	ProviderDocument        string        `gorm:"default:''" json:"provider_document,omitempty"` // CPF ou CNPJ (alfanumérico) sem pontuação
 
 	// Relacionamentos
 	FamilyAccount FamilyAccount  `gorm:"foreignKey:FamilyAccountID" json:"family_account,omitempty"`
 	Category      ExpenseCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	Splits        []ExpenseSplit  `gorm:"foreignKey:ExpenseID" json:"splits,omitempty"`
 }
+
+// IsRecurringRoot indica se a despesa é a origem de uma regra de recorrência
+func (e *Expense) IsRecurringRoot() bool {
+	return e.RecurrenceParentID == nil && e.RecurrenceRule != "" && e.RecurrenceRule != RecurrenceNone
+}
+
+// ResolveIRPFDeduction dedução do IRPF da despesa a partir da dedução da categoria (vazio = não dedutível)
+func (e *Expense) ResolveIRPFDeduction(categoryDeduction IRPFDeduction) IRPFDeduction {
+	switch e.IRPFDeduction {
+	case "":
+		return categoryDeduction
+	case IRPFDeductionNone:
+		return ""
+	}
+	return e.IRPFDeduction
+}
+
+// OccursIn indica se a regra de recorrência da despesa gera ocorrência no mês informado.
+// O mês de referência da própria despesa original conta como a primeira ocorrência.
+func (e *Expense) OccursIn(year, month int) bool {
+	if !e.IsRecurringRoot() {
+		return false
+	}
+	
+	start := e.ReferenceYear*12 + e.ReferenceMonth - 1
+	target := year*12 + month - 1
+	if target < start {
+		return false
+	}
+	
+	if e.RecurrenceEndDate != nil {
+		end := e.RecurrenceEndDate.Year()*12 + int(e.RecurrenceEndDate.Month()) - 1
+		if target > end {
+			return false
+		}
+	}
+	
+	diff := target - start
+	switch e.RecurrenceRule {
+	case RecurrenceMonthly:
+		return true
+	case RecurrenceEveryNMonths:
+		interval := e.RecurrenceInterval
+		if interval < 1 {
+			interval = 1
+		}
+		return diff%interval == 0
+	case RecurrenceYearly:
+		return diff%12 == 0
+	}
+	return false
+}
@@ -1,12 +1,40 @@
 package models
 
+import "time"
+
+// IRPFDeduction dedução do IRPF das despesas de uma categoria na declaração de ajuste anual
+type IRPFDeduction string
+
+const (
+	IRPFDeductionHealth    IRPFDeduction = "saude"
+	IRPFDeductionEducation IRPFDeduction = "educacao"
+	IRPFDeductionPGBL      IRPFDeduction = "previdencia_privada" // PGBL
+	IRPFDeductionAlimony   IRPFDeduction = "pensao_alimenticia"  // pensão alimentícia judicial
+	IRPFDeductionNone      IRPFDeduction = "nenhuma"             // só em despesas: não dedutível mesmo em categoria dedutível
+)
+
+// ExpenseCategory categoria de despesa.
+// Categorias padrão (FamilyAccountID nulo) são visíveis a todas as famílias;
+// as demais pertencem a uma família. ParentID indica uma subcategoria (um nível).
 type ExpenseCategory struct {
-	ID        uint   `gorm:"primaryKey" json:"id"`
-	Name      string `gorm:"unique;not null" json:"name"` // ex: "Moradia", "Alimentação"
-	Icon      string `json:"icon"`
-	Color     string `json:"color"`
-	IsDefault bool   `gorm:"default:false" json:"is_default"`
+	ID              uint          `gorm:"primaryKey" json:"id"`
+	FamilyAccountID *uint         `gorm:"index" json:"family_account_id,omitempty"`
+	ParentID        *uint         `gorm:"index" json:"parent_id,omitempty"`
+	Name            string        `gorm:"not null" json:"name"` // ex: "Moradia", "Alimentação"
+	Icon            string        `json:"icon"`
+	Color           string        `json:"color"`
+	IsDefault       bool          `gorm:"default:false" json:"is_default"`
+	IRPFDeduction   IRPFDeduction `gorm:"default:''" json:"irpf_deduction,omitempty"` // vazio: herda da categoria pai
+	IsActive        bool          `gorm:"default:true" json:"is_active"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
 
 	// Relacionamentos
-	Expenses []Expense `gorm:"foreignKey:CategoryID" json:"expenses,omitempty"`
+	Expenses      []Expense         `gorm:"foreignKey:CategoryID" json:"expenses,omitempty"`
+	Subcategories []ExpenseCategory `gorm:"foreignKey:ParentID" json:"subcategories,omitempty"`
+}
+
+// IsCustom indica se a categoria pertence a uma família (não é padrão)
+func (c *ExpenseCategory) IsCustom() bool {
+	return c.FamilyAccountID != nil
 }
@@ -8,9 +8,21 @@ type ExpenseSplit struct {
 	FamilyMemberID uint      `gorm:"not null;index" json:"family_member_id"`
 	Percentage     float64   `gorm:"not null" json:"percentage"` // ex: 50.00 (%)
 	AmountCents    int64     `gorm:"not null" json:"amount_cents"` // calculado
+	Shares         float64   `gorm:"default:0" json:"shares,omitempty"` // peso informado no modo shares
 	CreatedAt      time.Time `json:"created_at"`
 
 	// Relacionamentos
 	Expense      Expense      `gorm:"foreignKey:ExpenseID" json:"expense,omitempty"`
 	FamilyMember FamilyMember `gorm:"foreignKey:FamilyMemberID" json:"family_member,omitempty"`
 }
+
+// SplitMode forma de dividir o valor de uma despesa entre os membros
+type SplitMode string
+
+const (
+	SplitModePercentage SplitMode = "percentage" // porcentagens que somam 100%
+	SplitModeExact      SplitMode = "exact"      // valores em centavos que somam o total
+	SplitModeShares     SplitMode = "shares"     // pesos (ex: 2 partes x 1 parte)
+	SplitModeEqual      SplitMode = "equal"      // partes iguais
+	SplitModeIncome     SplitMode = "income"     // proporcional à renda líquida ativa de cada membro
+)
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// FamilyInvitation convite para um usuário entrar em uma família
+type FamilyInvitation struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	FamilyAccountID  uint       `gorm:"not null;index" json:"family_account_id"`
+	FamilyMemberID   *uint      `json:"family_member_id"` // nullable: cria um novo membro ao aceitar
+	InvitedByUserID  uint       `gorm:"not null" json:"invited_by_user_id"`
+	TokenHash        string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 do token
+	Email            *string    `json:"email"`                         // nullable: qualquer usuário pode aceitar
+	Role             MemberRole `gorm:"default:'member'" json:"role"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	AcceptedByUserID *uint      `json:"accepted_by_user_id,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Relacionamentos
+	FamilyAccount FamilyAccount `gorm:"foreignKey:FamilyAccountID" json:"family_account,omitempty"`
+	FamilyMember  *FamilyMember `gorm:"foreignKey:FamilyMemberID" json:"family_member,omitempty"`
+}
+
+// Status retorna o estado atual do convite
+func (i *FamilyInvitation) Status() InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case time.Now().After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
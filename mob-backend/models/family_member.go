@@ -11,17 +11,50 @@ const (
 )
 
 type FamilyMember struct {
-	ID              uint       `gorm:"primaryKey" json:"id"`
-	FamilyAccountID uint       `gorm:"not null;index" json:"family_account_id"`
-	UserID          *uint      `gorm:"index" json:"user_id"` // nullable: pode ser dependente (filho)
-	Name            string     `gorm:"not null" json:"name"`
-	Role            MemberRole `gorm:"default:'member'" json:"role"`
-	IsActive        bool       `gorm:"default:true" json:"is_active"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID                    uint       `gorm:"primaryKey" json:"id"`
+	FamilyAccountID       uint       `gorm:"not null;index" json:"family_account_id"`
+	UserID                *uint      `gorm:"index" json:"user_id"` // nullable: pode ser dependente (filho)
+	Name                  string     `gorm:"not null" json:"name"`
+	Role                  MemberRole `gorm:"default:'member'" json:"role"`
+	IsActive              bool       `gorm:"default:true" json:"is_active"`
+	IRPFDeclarantMemberID *uint      `gorm:"index" json:"irpf_declarant_member_id"` // dependente: membro que o deduz no IRPF (nil = declarante padrão)
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
 
 	// Relacionamentos
 	FamilyAccount FamilyAccount `gorm:"foreignKey:FamilyAccountID" json:"family_account,omitempty"`
 	User          *User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Incomes       []Income      `gorm:"foreignKey:FamilyMemberID" json:"incomes,omitempty"`
 }
+
+// DefaultIRPFDeclarantID declarante padrão dos dependentes: o membro do dono da família ou, sem ele,
+// o primeiro membro ativo que não é dependente (0 se não houver)
+func DefaultIRPFDeclarantID(members []FamilyMember, ownerUserID uint) uint {
+	defaultID := uint(0)
+	for _, member := range members {
+		if !member.IsActive || member.Role == RoleDependent {
+			continue
+		}
+		if member.UserID != nil && *member.UserID == ownerUserID {
+			return member.ID
+		}
+		if defaultID == 0 || member.ID < defaultID {
+			defaultID = member.ID
+		}
+	}
+	return defaultID
+}
+
+// IRPFDeclarantID membro que deduz o dependente no IRPF: o declarante escolhido, se ainda for um membro ativo
+// que não é dependente, ou o declarante padrão. Assim cada dependente é deduzido por um único membro
+func (m *FamilyMember) IRPFDeclarantID(members []FamilyMember, defaultID uint) uint {
+	if m.IRPFDeclarantMemberID == nil {
+		return defaultID
+	}
+	for _, member := range members {
+		if member.ID == *m.IRPFDeclarantMemberID && member.IsActive && member.Role != RoleDependent {
+			return member.ID
+		}
+	}
+	return defaultID
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+type ImportFormat string
+
+const (
+	ImportFormatOFX ImportFormat = "ofx"
+	ImportFormatCSV ImportFormat = "csv"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusStaged    ImportStatus = "staged"    // aguardando revisão
+	ImportStatusCommitted ImportStatus = "committed" // linhas selecionadas viraram despesas e rendas
+	ImportStatusUndone    ImportStatus = "undone"    // despesas e rendas da importação foram desativadas
+)
+
+// ImportBatch lote gerado a partir de um arquivo de extrato (OFX ou CSV)
+type ImportBatch struct {
+	ID              uint         `gorm:"primaryKey" json:"id"`
+	FamilyAccountID uint         `gorm:"not null;index" json:"family_account_id"`
+	CreatedByUserID *uint        `json:"created_by_user_id,omitempty"`
+	FileName        string       `json:"file_name"`
+	Format          ImportFormat `gorm:"not null" json:"format"`
+	Layout          string       `json:"layout,omitempty"` // layout CSV usado (ex: "nubank", "itau", "custom")
+	Status          ImportStatus `gorm:"not null;default:'staged'" json:"status"`
+	RowCount        int          `gorm:"not null;default:0" json:"row_count"`
+	DuplicateCount  int          `gorm:"not null;default:0" json:"duplicate_count"`
+	ImportedCount   int          `gorm:"not null;default:0" json:"imported_count"`
+	CommittedAt     *time.Time   `json:"committed_at,omitempty"`
+	UndoneAt        *time.Time   `json:"undone_at,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+
+	// Relacionamentos
+	Rows []ImportRow `gorm:"foreignKey:ImportBatchID" json:"rows,omitempty"`
+}
+
+// ImportRow transação lida do arquivo, em revisão até o lote ser confirmado
+type ImportRow struct {
+	ID                   uint      `gorm:"primaryKey" json:"id"`
+	ImportBatchID        uint      `gorm:"not null;index" json:"import_batch_id"`
+	LineNumber           int       `gorm:"not null" json:"line_number"`
+	TransactionDate      time.Time `gorm:"type:date;not null" json:"transaction_date"`
+	Description          string    `gorm:"not null" json:"description"`
+	AmountCents          int64     `gorm:"not null" json:"amount_cents"` // negativo = saída, positivo = entrada
+	ExternalID           string    `json:"external_id,omitempty"`        // FITID do OFX
+	DuplicateExpenseID   *uint     `json:"duplicate_expense_id,omitempty"`
+	Selected             bool      `gorm:"not null" json:"selected"`
+	CategoryID           *uint     `json:"category_id,omitempty"`
+	CategorizationRuleID *uint     `json:"categorization_rule_id,omitempty"` // regra que sugeriu a categoria
+	ExpenseID            *uint     `json:"expense_id,omitempty"`             // despesa criada ao confirmar
+	FamilyMemberID       *uint     `json:"family_member_id,omitempty"`       // membro que recebeu a entrada
+	IncomeID             *uint     `json:"income_id,omitempty"`              // renda criada ao confirmar uma entrada
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// IsDebit indica se a linha é uma saída (candidata a despesa); as entradas são candidatas a renda
+func (r *ImportRow) IsDebit() bool {
+	return r.AmountCents < 0
+}
+
+// IsDuplicate indica se já existe uma despesa equivalente na família
+func (r *ImportRow) IsDuplicate() bool {
+	return r.DuplicateExpenseID != nil
+}
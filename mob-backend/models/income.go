@@ -5,10 +5,28 @@ import "time"
 type IncomeType string
 
 const (
-	IncomeCLT IncomeType = "CLT"
-	IncomePJ  IncomeType = "PJ"
+	IncomeCLT            IncomeType = "CLT"
+	IncomePJ             IncomeType = "PJ"
+	IncomeMEI            IncomeType = "MEI"             // microempreendedor individual (DAS fixo)
+	IncomeLucroPresumido IncomeType = "lucro_presumido" // empresa do membro no Lucro Presumido
+	IncomeAluguel        IncomeType = "aluguel"         // aluguel recebido de pessoa física (carnê-leão)
+	IncomeAposentadoria  IncomeType = "aposentadoria"   // benefício previdenciário
+	IncomePensao         IncomeType = "pensao"          // pensão previdenciária (tributável)
+	IncomeFreelance      IncomeType = "freelance"       // serviço autônomo prestado a empresas
 )
 
+// Atividade da empresa do MEI e do Lucro Presumido
+const (
+	ActivityServices         = "servicos"
+	ActivityCommerce         = "comercio"
+	ActivityCommerceServices = "comercio_servicos" // somente MEI
+)
+
+// IsBusiness indica renda de empresa do membro: a empresa paga os tributos e o membro recebe pró-labore e lucros
+func (t IncomeType) IsBusiness() bool {
+	return t == IncomePJ || t == IncomeMEI || t == IncomeLucroPresumido
+}
+
 type Income struct {
 	ID             uint       `gorm:"primaryKey" json:"id"`
 	FamilyMemberID uint       `gorm:"not null;index" json:"family_member_id"`
@@ -22,17 +40,35 @@ type Income struct {
 	TransportVoucherCents int64 `gorm:"default:0" json:"transport_voucher_cents"`
 	BonusCents            int64 `gorm:"default:0" json:"bonus_cents"`
 
-	// Para PJ
+	// Para PJ, MEI e Lucro Presumido
 	SimplesNacionalRate float64 `gorm:"default:0" json:"simples_nacional_rate"` // ex: 6.5 (%)
 	ProLaboreCents      int64   `gorm:"default:0" json:"pro_labore_cents"`
+	CompanyTaxCents     int64   `gorm:"default:0" json:"company_tax_cents"`            // tributos do mês pagos pela empresa (DAS ou PIS/COFINS/IRPJ/CSLL)
+	BusinessActivity    string  `gorm:"default:''" json:"business_activity,omitempty"` // MEI e Lucro Presumido
+	
+	// Simples Nacional pelos Anexos III/V (SimplesAutoRate): alíquota efetiva calculada com o RBT12 e o
+	// Fator R do histórico de faturamento da fonte e guardada em SimplesNacionalRate
+	SimplesAutoRate bool    `gorm:"default:false" json:"simples_auto_rate"`
+	SimplesAnnex    string  `json:"simples_annex,omitempty"`
+	RBT12Cents      int64   `gorm:"column:rbt12_cents;default:0" json:"rbt12_cents"`
+	FatorR          float64 `gorm:"default:0" json:"fator_r"`
 
-	// Para CLT (calculado automaticamente)
+	// Para CLT (calculado automaticamente); para empresas, INSS e IRPF retidos sobre o pró-labore
 	INSSCents int64 `gorm:"default:0" json:"inss_cents"`
 	FGTSCents int64 `gorm:"default:0" json:"fgts_cents"`
 	IRPFCents int64 `gorm:"default:0" json:"irpf_cents"`
+	
+	// Tabela de impostos usada no cálculo (0 = impostos não calculados) e dependentes deduzidos no IRPF
+	TaxTableYear   int `gorm:"default:0" json:"tax_table_year"`
+	IRPFDependents int `gorm:"default:0" json:"irpf_dependents"`
+	
+	// Redução mensal do IRPF já descontada de IRPFCents e se o IRPF usou o desconto simplificado
+	IRPFReductionCents int64 `gorm:"default:0" json:"irpf_reduction_cents"`
+	IRPFSimplified     bool  `gorm:"default:false" json:"irpf_simplified"`
 
-	// Líquido (obrigatório)
+	// Líquido (obrigatório). NetCalculated: o líquido é calculado a partir do bruto e recalculado a cada edição
 	NetMonthlyCents int64 `gorm:"not null" json:"net_monthly_cents"`
+	NetCalculated   bool  `gorm:"default:false" json:"net_calculated"`
 
 	IsActive  bool      `gorm:"default:true" json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
@@ -41,7 +77,36 @@ type Income struct {
 	// Campos de referência mensal para histórico
 	ReferenceMonth int `gorm:"not null;default:EXTRACT(MONTH FROM CURRENT_DATE)" json:"reference_month"`
 	ReferenceYear  int `gorm:"not null;default:EXTRACT(YEAR FROM CURRENT_DATE)" json:"reference_year"`
+	
+	// Fonte de renda: um membro pode ter várias. Uma nova versão da fonte aponta para a renda original
+	// e a substitui a partir do seu mês de início; EndMonth/EndYear é o último mês em que a fonte vale
+	SourceID   *uint  `gorm:"index" json:"source_id,omitempty"`
+	SourceName string `json:"source_name"` // ex: "Empresa X", "Apartamento Centro"
+	EndMonth   *int   `json:"end_month,omitempty"`
+	EndYear    *int   `json:"end_year,omitempty"`
+	
+	// Mês de gozo das férias (CLT), usado na projeção anual
+	VacationMonth *int `json:"vacation_month,omitempty"`
 
 	// Relacionamentos
 	FamilyMember FamilyMember `gorm:"foreignKey:FamilyMemberID" json:"family_member,omitempty"`
 }
+
+// TaxCents soma os impostos do mês: INSS, IRPF e os tributos da empresa
+func (i *Income) TaxCents() int64 {
+	return i.INSSCents + i.IRPFCents + i.CompanyTaxCents
+}
+
+// NetOnly indica renda informada só pelo líquido: sem bruto próprio (gravada com o bruto igual ao líquido)
+// e sem impostos calculados
+func (i *Income) NetOnly() bool {
+	return !i.NetCalculated && (i.GrossMonthlyCents == 0 || i.GrossMonthlyCents == i.NetMonthlyCents)
+}
+
+// SourceKey identifica a fonte de renda: o ID da renda original da fonte
+func (i *Income) SourceKey() uint {
+	if i.SourceID != nil {
+		return *i.SourceID
+	}
+	return i.ID
+}
@@ -0,0 +1,65 @@
+package models
+
+// Permission código de permissão verificado nas rotas da família
+type Permission string
+
+const (
+	PermFamilyRead        Permission = "family:read"
+	PermFamilyUpdate      Permission = "family:update"
+	PermFamilyDelete      Permission = "family:delete"
+	PermFamilyTransfer    Permission = "family:transfer_ownership"
+	PermMembersRead       Permission = "members:read"
+	PermMembersManage     Permission = "members:manage"
+	PermInvitationsManage Permission = "invitations:manage"
+	PermFinancesRead      Permission = "finances:read"
+	PermFinancesWrite     Permission = "finances:write"
+	PermOwnSplitsRead     Permission = "splits:read_own"
+)
+
+// rolePermissions matriz de permissões por papel na família
+var rolePermissions = map[MemberRole][]Permission{
+	RoleOwner: {
+		PermFamilyRead, PermFamilyUpdate, PermFamilyDelete, PermFamilyTransfer,
+		PermMembersRead, PermMembersManage, PermInvitationsManage,
+		PermFinancesRead, PermFinancesWrite, PermOwnSplitsRead,
+	},
+	RoleMember: {
+		PermFamilyRead, PermMembersRead,
+		PermFinancesRead, PermFinancesWrite, PermOwnSplitsRead,
+	},
+	RoleDependent: {
+		PermFamilyRead, PermOwnSplitsRead,
+	},
+}
+
+// ResolveMemberRole determina o papel efetivo do usuário em uma família.
+// owner vem de FamilyAccount.OwnerUserID; member é o registro ativo do usuário em family_members (ou nil).
+// Retorna papel vazio quando o usuário não tem acesso.
+func ResolveMemberRole(ownerUserID, userID uint, member *FamilyMember) MemberRole {
+	if ownerUserID == userID {
+		return RoleOwner
+	}
+	if member == nil || !member.IsActive {
+		return ""
+	}
+	if member.Role == RoleDependent {
+		return RoleDependent
+	}
+	// Papel owner em family_members sem ser OwnerUserID é tratado como member
+	return RoleMember
+}
+
+// Can verifica se o papel possui a permissão
+func (r MemberRole) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions retorna as permissões do papel
+func (r MemberRole) Permissions() []Permission {
+	return rolePermissions[r]
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// PJMonthlyRevenue faturamento mensal de uma fonte de renda PJ, usado no RBT12 e no Fator R do Simples Nacional
+type PJMonthlyRevenue struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	IncomeSourceID uint      `gorm:"not null;index" json:"income_source_id"` // renda original da fonte (Income.SourceKey)
+	Year           int       `gorm:"not null" json:"year"`
+	Month          int       `gorm:"not null" json:"month"`
+	RevenueCents   int64     `gorm:"not null" json:"revenue_cents"`
+	PayrollCents   int64     `gorm:"not null" json:"payroll_cents"` // folha: pró-labore, salários e encargos
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// MonthIndex retorna o mês como número sequencial (ano*12 + mês - 1), útil para comparar períodos
+func (r *PJMonthlyRevenue) MonthIndex() int {
+	return r.Year*12 + r.Month - 1
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SchemaMigration registra uma migration SQL aplicada no banco
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	Name      string    `gorm:"not null" json:"name"`
+	Checksum  string    `gorm:"not null" json:"checksum"` // SHA-256 do arquivo .up.sql
+	AppliedAt time.Time `gorm:"not null" json:"applied_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Motivos de revogação de sessão
+const (
+	SessionRevokedLogout        = "logout"
+	SessionRevokedByUser        = "revoked"
+	SessionRevokedReuseDetected = "reuse_detected"
+)
+
+// Session representa um login ativo em um dispositivo.
+// Todos os refresh tokens rotacionados a partir do mesmo login pertencem à mesma sessão.
+type Session struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	UserID        uint       `gorm:"not null;index" json:"user_id"`
+	UserAgent     string     `json:"user_agent"`
+	IPAddress     string     `json:"ip_address"`
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	LastUsedAt    *time.Time `json:"last_used_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason string     `json:"revoked_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Relacionamentos
+	User          User           `gorm:"foreignKey:UserID" json:"-"`
+	RefreshTokens []RefreshToken `gorm:"foreignKey:SessionID" json:"-"`
+}
+
+// RefreshToken guarda o hash de um refresh token emitido para uma sessão
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	SessionID uint       `gorm:"not null;index" json:"session_id"`
+	TokenHash string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 do token
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // preenchido quando rotacionado
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Relacionamentos
+	Session Session `gorm:"foreignKey:SessionID" json:"-"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Settlement pagamento de acerto de contas entre dois membros da família
+type Settlement struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	FamilyAccountID uint      `gorm:"not null;index" json:"family_account_id"`
+	FromMemberID    uint      `gorm:"not null" json:"from_member_id"` // quem pagou o acerto
+	ToMemberID      uint      `gorm:"not null" json:"to_member_id"`   // quem recebeu
+	AmountCents     int64     `gorm:"not null" json:"amount_cents"`
+	ReferenceMonth  int       `gorm:"not null" json:"reference_month"` // mês cujo saldo está sendo acertado
+	ReferenceYear   int       `gorm:"not null" json:"reference_year"`
+	PaidAt          time.Time `gorm:"type:date;not null" json:"paid_at"`
+	Notes           string    `json:"notes"`
+	IsActive        bool      `gorm:"default:true" json:"is_active"`
+	CreatedByUserID *uint     `json:"created_by_user_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relacionamentos
+	FromMember FamilyMember `gorm:"foreignKey:FromMemberID" json:"from_member,omitempty"`
+	ToMember   FamilyMember `gorm:"foreignKey:ToMemberID" json:"to_member,omitempty"`
+}
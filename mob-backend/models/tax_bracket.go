@@ -24,17 +24,37 @@ type IRPFBracket struct {
 	Rate       float64   `gorm:"not null" json:"rate"`       // Alíquota (ex: 0.075 = 7.5%)
 	Deduction  float64   `gorm:"not null" json:"deduction"`  // Parcela a deduzir
 	Order      int       `gorm:"not null" json:"order"`      // Ordem da faixa
+	Annual     bool      `gorm:"default:false" json:"annual"` // true = faixa da tabela anual (declaração de ajuste)
 	IsActive   bool      `gorm:"default:true" json:"is_active"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// IRPFReducer redução mensal (ou anual, na declaração de ajuste) do IRPF por faixa de rendimentos
+// tributáveis (ex: Lei 15.270/2025): redução = FixedAmount - Rate × rendimentos, limitada ao imposto calculado
+type IRPFReducer struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Year        int       `gorm:"not null;index" json:"year"`   // Ano de vigência
+	MinValue    float64   `gorm:"not null" json:"min_value"`    // Rendimentos mínimos em reais
+	MaxValue    float64   `gorm:"not null" json:"max_value"`    // Rendimentos máximos em reais (0 = sem limite)
+	FixedAmount float64   `gorm:"not null" json:"fixed_amount"` // Ex: 978.62
+	Rate        float64   `gorm:"not null" json:"rate"`         // Ex: 0.133145
+	Order       int       `gorm:"not null" json:"order"`        // Ordem da faixa
+	Annual      bool      `gorm:"default:false" json:"annual"`  // true = faixa da declaração anual
+	IsActive    bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // TaxConfiguration armazena configurações gerais de impostos
 type TaxConfiguration struct {
 	ID                         uint      `gorm:"primaryKey" json:"id"`
 	Year                       int       `gorm:"not null;unique" json:"year"`
 	INSSDeductionPerDependent  float64   `gorm:"not null" json:"inss_deduction_per_dependent"` // Ex: 189.59
 	FGTSRate                   float64   `gorm:"not null" json:"fgts_rate"`                    // Ex: 0.08 (8%)
+	IRPFSimplifiedDiscount     float64   `gorm:"default:0" json:"irpf_simplified_discount"`    // Desconto simplificado mensal (0 = não há)
+	IRPFAnnualSimplifiedCap    float64   `gorm:"default:0" json:"irpf_annual_simplified_cap"`  // Teto do desconto simplificado anual (20%)
+	IRPFEducationCap           float64   `gorm:"default:0" json:"irpf_education_cap"`          // Limite anual de instrução por pessoa
 	IsActive                   bool      `gorm:"default:true" json:"is_active"`
 	CreatedAt                  time.Time `json:"created_at"`
 	UpdatedAt                  time.Time `json:"updated_at"`
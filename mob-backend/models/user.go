@@ -8,6 +8,7 @@ type User struct {
 	Username  string    `gorm:"uniqueIndex" json:"username"`
 	Password  string    `gorm:"not null" json:"-"` // never return password in JSON
 	Name      string    `gorm:"not null" json:"name"`
+	IsAdmin   bool      `gorm:"default:false" json:"is_admin"` // gerencia as tabelas de impostos
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// Upsert cria ou atualiza o orçamento da categoria no mês
+func (r *BudgetRepository) Upsert(budget *models.CategoryBudget) error {
+	return r.db.Omit("Category").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "family_account_id"}, {Name: "category_id"}, {Name: "year"}, {Name: "month"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount_cents", "rollover", "updated_at"}),
+	}).Create(budget).Error
+}
+
+// GetByFamilyIDUpTo busca os orçamentos da família que começam até o mês informado, em ordem cronológica
+func (r *BudgetRepository) GetByFamilyIDUpTo(familyID uint, month, year int) ([]models.CategoryBudget, error) {
+	var budgets []models.CategoryBudget
+	err := r.db.Where("family_account_id = ? AND year * 12 + month <= ?", familyID, year*12+month).
+		Order("year, month, category_id").
+		Find(&budgets).Error
+
+	return budgets, err
+}
+
+// Delete remove o orçamento da categoria definido no mês
+func (r *BudgetRepository) Delete(familyID, categoryID uint, month, year int) (bool, error) {
+	result := r.db.Where("family_account_id = ? AND category_id = ? AND year = ? AND month = ?",
+		familyID, categoryID, year, month).
+		Delete(&models.CategoryBudget{})
+
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+)
+
+type CategorizationRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewCategorizationRuleRepository(db *gorm.DB) *CategorizationRuleRepository {
+	return &CategorizationRuleRepository{db: db}
+}
+
+// Create cria a regra junto com o modelo de divisão
+func (r *CategorizationRuleRepository) Create(rule *models.CategorizationRule) error {
+	return r.db.Omit("Category").Create(rule).Error
+}
+
+// GetByID busca regra por ID com categoria e modelo de divisão
+func (r *CategorizationRuleRepository) GetByID(id uint) (*models.CategorizationRule, error) {
+	var rule models.CategorizationRule
+	err := r.db.Preload("Category").Preload("Splits").First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetActiveByFamilyID busca as regras ativas da família em ordem de avaliação (maior prioridade primeiro)
+func (r *CategorizationRuleRepository) GetActiveByFamilyID(familyID uint) ([]models.CategorizationRule, error) {
+	var rules []models.CategorizationRule
+	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Preload("Category").
+		Preload("Splits").
+		Order("priority DESC, id").
+		Find(&rules).Error
+
+	return rules, err
+}
+
+// Update atualiza a regra e substitui o modelo de divisão
+func (r *CategorizationRuleRepository) Update(rule *models.CategorizationRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("categorization_rule_id = ?", rule.ID).Delete(&models.CategorizationRuleSplit{}).Error; err != nil {
+			return err
+		}
+		for i := range rule.Splits {
+			rule.Splits[i].ID = 0
+			rule.Splits[i].CategorizationRuleID = rule.ID
+		}
+		if len(rule.Splits) > 0 {
+			if err := tx.Create(&rule.Splits).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Category", "Splits").Save(rule).Error
+	})
+}
+
+// Delete exclui uma regra (soft delete)
+func (r *CategorizationRuleRepository) Delete(id uint) error {
+	return r.db.Model(&models.CategorizationRule{}).
+		Where("id = ?", id).
+		Update("is_active", false).Error
+}
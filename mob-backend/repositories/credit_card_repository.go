@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+)
+
+type CreditCardRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditCardRepository(db *gorm.DB) *CreditCardRepository {
+	return &CreditCardRepository{db: db}
+}
+
+// Create cria um novo cartão
+func (r *CreditCardRepository) Create(card *models.CreditCard) error {
+	return r.db.Create(card).Error
+}
+
+// GetByID busca cartão por ID
+func (r *CreditCardRepository) GetByID(id uint) (*models.CreditCard, error) {
+	var card models.CreditCard
+	if err := r.db.First(&card, id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// GetByFamilyID busca cartões ativos de uma família
+func (r *CreditCardRepository) GetByFamilyID(familyID uint) ([]models.CreditCard, error) {
+	var cards []models.CreditCard
+	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Order("name").
+		Find(&cards).Error
+
+	return cards, err
+}
+
+// Update atualiza um cartão
+func (r *CreditCardRepository) Update(card *models.CreditCard) error {
+	return r.db.Save(card).Error
+}
+
+// Delete exclui um cartão (soft delete)
+func (r *CreditCardRepository) Delete(id uint) error {
+	return r.db.Model(&models.CreditCard{}).
+		Where("id = ?", id).
+		Update("is_active", false).Error
+}
+
+// CreatePurchase cria a compra junto com suas parcelas (expenses) e splits, na mesma transação
+func (r *CreditCardRepository) CreatePurchase(purchase *models.InstallmentPurchase) error {
+	return r.db.Create(purchase).Error
+}
+
+// GetPurchaseByID busca compra por ID com suas parcelas
+func (r *CreditCardRepository) GetPurchaseByID(id uint) (*models.InstallmentPurchase, error) {
+	var purchase models.InstallmentPurchase
+	err := r.db.Preload("Category").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installment_number")
+		}).
+		First(&purchase, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+// GetPurchasesByCardID busca compras ativas de um cartão
+func (r *CreditCardRepository) GetPurchasesByCardID(cardID uint) ([]models.InstallmentPurchase, error) {
+	var purchases []models.InstallmentPurchase
+	err := r.db.Where("credit_card_id = ? AND is_active = ?", cardID, true).
+		Preload("Category").
+		Order("purchase_date DESC, id DESC").
+		Find(&purchases).Error
+
+	return purchases, err
+}
+
+// DeletePurchase desativa a compra e todas as suas parcelas
+func (r *CreditCardRepository) DeletePurchase(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.InstallmentPurchase{}).
+			Where("id = ?", id).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Expense{}).
+			Where("installment_purchase_id = ?", id).
+			Update("is_active", false).Error
+	})
+}
+
+// GetStatementItems busca as despesas ativas lançadas na fatura do mês
+func (r *CreditCardRepository) GetStatementItems(cardID uint, month, year int) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Where("credit_card_id = ? AND is_active = ? AND reference_month = ? AND reference_year = ?",
+		cardID, true, month, year).
+		Preload("Category").
+		Order("name, installment_number").
+		Find(&expenses).Error
+
+	return expenses, err
+}
+
+// StatementTotal total de uma fatura
+type StatementTotal struct {
+	Month      int
+	Year       int
+	TotalCents int64
+	Count      int
+}
+
+// GetStatementTotals soma as despesas ativas do cartão por fatura (mês/ano)
+func (r *CreditCardRepository) GetStatementTotals(cardID uint) ([]StatementTotal, error) {
+	var totals []StatementTotal
+	err := r.db.Model(&models.Expense{}).
+		Select("reference_month AS month, reference_year AS year, SUM(amount_cents) AS total_cents, COUNT(*) AS count").
+		Where("credit_card_id = ? AND is_active = ?", cardID, true).
+		Group("reference_year, reference_month").
+		Order("reference_year, reference_month").
+		Scan(&totals).Error
+
+	return totals, err
+}
@@ -44,10 +44,105 @@ func (r *ExpenseCategoryRepository) Create(category *models.ExpenseCategory) err
 
 // Update atualiza uma categoria
 func (r *ExpenseCategoryRepository) Update(category *models.ExpenseCategory) error {
-	return r.db.Save(category).Error
+	return r.db.Omit("Expenses", "Subcategories").Save(category).Error
 }
 
 // Delete exclui uma categoria (se não tiver despesas associadas)
 func (r *ExpenseCategoryRepository) Delete(id uint) error {
 	return r.db.Delete(&models.ExpenseCategory{}, id).Error
 }
+
+// GetByFamilyID busca as categorias ativas visíveis para a família (padrão + da família)
+func (r *ExpenseCategoryRepository) GetByFamilyID(familyID uint) ([]models.ExpenseCategory, error) {
+	var categories []models.ExpenseCategory
+	err := r.db.Where("is_active = ? AND (family_account_id IS NULL OR family_account_id = ?)", true, familyID).
+		Order("name").
+		Find(&categories).Error
+	return categories, err
+}
+
+// GetByIDForFamily busca uma categoria ativa visível para a família
+func (r *ExpenseCategoryRepository) GetByIDForFamily(id, familyID uint) (*models.ExpenseCategory, error) {
+	var category models.ExpenseCategory
+	err := r.db.Where("is_active = ? AND (family_account_id IS NULL OR family_account_id = ?)", true, familyID).
+		First(&category, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetChildren busca as subcategorias ativas de uma categoria
+func (r *ExpenseCategoryRepository) GetChildren(parentID uint) ([]models.ExpenseCategory, error) {
+	var categories []models.ExpenseCategory
+	err := r.db.Where("parent_id = ? AND is_active = ?", parentID, true).
+		Order("name").
+		Find(&categories).Error
+	return categories, err
+}
+
+// ExistsByName verifica se já existe categoria visível para a família com o mesmo nome no mesmo nível
+func (r *ExpenseCategoryRepository) ExistsByName(familyID uint, parentID *uint, name string, exceptID uint) (bool, error) {
+	query := r.db.Model(&models.ExpenseCategory{}).
+		Where("is_active = ? AND (family_account_id IS NULL OR family_account_id = ?)", true, familyID).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, exceptID)
+	
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// CountUsage conta despesas, compras no cartão e regras que usam as categorias
+func (r *ExpenseCategoryRepository) CountUsage(ids []uint) (int64, error) {
+	var total int64
+	for _, model := range []interface{}{&models.Expense{}, &models.InstallmentPurchase{}, &models.CategorizationRule{}} {
+		var count int64
+		if err := r.db.Model(model).Where("category_id IN ?", ids).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// ReassignAndDeactivate move tudo que usa as categorias para a categoria destino e as desativa, na mesma transação.
+// targetID = 0 apenas desativa (usar quando não há uso). Os orçamentos das categorias são excluídos (cada categoria
+// tem seu histórico de orçamentos por mês, que não se mistura ao da categoria destino); retorna quantos foram excluídos.
+func (r *ExpenseCategoryRepository) ReassignAndDeactivate(ids []uint, targetID uint) (int64, error) {
+	var discardedBudgets int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if targetID != 0 {
+			for _, model := range []interface{}{&models.Expense{}, &models.InstallmentPurchase{}, &models.CategorizationRule{}} {
+				if err := tx.Model(model).Where("category_id IN ?", ids).Update("category_id", targetID).Error; err != nil {
+					return err
+				}
+			}
+		}
+		
+		// Linhas de importação em revisão recebem a nova categoria (ou ficam sem categoria)
+		var rowCategory interface{}
+		if targetID != 0 {
+			rowCategory = targetID
+		}
+		if err := tx.Model(&models.ImportRow{}).Where("category_id IN ?", ids).Update("category_id", rowCategory).Error; err != nil {
+			return err
+		}
+		
+		result := tx.Where("category_id IN ?", ids).Delete(&models.CategoryBudget{})
+		if result.Error != nil {
+			return result.Error
+		}
+		discardedBudgets = result.RowsAffected
+		
+		return tx.Model(&models.ExpenseCategory{}).
+			Where("id IN ?", ids).
+			Update("is_active", false).Error
+	})
+	return discardedBudgets, err
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"finance-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExpenseRepository struct {
@@ -42,7 +43,7 @@ func (r *ExpenseRepository) GetByFamilyID(familyID uint) ([]models.Expense, erro
 	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
 		Preload("Category").
 		Preload("Splits", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "expense_id", "family_member_id", "percentage", "amount_cents")
+			return db.Select("id", "expense_id", "family_member_id", "percentage", "amount_cents", "shares")
 		}).
 		Preload("Splits.FamilyMember", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "family_account_id")
@@ -60,7 +61,7 @@ func (r *ExpenseRepository) GetByFamilyIDAndMonth(familyID uint, month, year int
 		familyID, true, month, year).
 		Preload("Category").
 		Preload("Splits", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id", "expense_id", "family_member_id", "percentage", "amount_cents")
+			return db.Select("id", "expense_id", "family_member_id", "percentage", "amount_cents", "shares")
 		}).
 		Preload("Splits.FamilyMember", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "family_account_id")
@@ -161,6 +162,45 @@ func (r *ExpenseRepository) GetSplitsByMember(memberID uint) ([]models.ExpenseSp
 	return splits, err
 }
 
+// GetRecurringRoots busca as despesas originais com regra de recorrência ativa de uma família
+// que começam até o mês informado
+func (r *ExpenseRepository) GetRecurringRoots(familyID uint, month, year int) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Where("family_account_id = ? AND is_active = ? AND recurrence_parent_id IS NULL AND recurrence_rule <> ?",
+		familyID, true, models.RecurrenceNone).
+		Where("reference_year * 12 + reference_month <= ?", year*12+month).
+		Preload("Splits").
+		Order("id").
+		Find(&expenses).Error
+	
+	return expenses, err
+}
+
+// GetFamilyIDsWithRecurringExpenses lista as famílias que possuem regras de recorrência ativas
+func (r *ExpenseRepository) GetFamilyIDsWithRecurringExpenses() ([]uint, error) {
+	var familyIDs []uint
+	err := r.db.Model(&models.Expense{}).
+		Where("is_active = ? AND recurrence_parent_id IS NULL AND recurrence_rule <> ?", true, models.RecurrenceNone).
+		Distinct().
+		Pluck("family_account_id", &familyIDs).Error
+	
+	return familyIDs, err
+}
+
+// CreateOccurrence cria a ocorrência de uma despesa recorrente; retorna false se ela já existia no mês
+func (r *ExpenseRepository) CreateOccurrence(occurrence *models.Expense) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(occurrence)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateWithTransaction cria despesas dentro de uma transação
+func (r *ExpenseRepository) CreateWithTransaction(fn func(*ExpenseRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &ExpenseRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
 // UpdateWithTransaction atualiza uma despesa dentro de uma transação
 func (r *ExpenseRepository) UpdateWithTransaction(fn func(*ExpenseRepository) error) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
@@ -168,3 +208,46 @@ func (r *ExpenseRepository) UpdateWithTransaction(fn func(*ExpenseRepository) er
 		return fn(txRepo)
 	})
 }
+
+// GetByFamilyIDBetweenMonths busca as despesas ativas de uma família entre dois meses (inclusive), sem relacionamentos
+func (r *ExpenseRepository) GetByFamilyIDBetweenMonths(familyID uint, fromMonth, fromYear, toMonth, toYear int) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Where("reference_year * 12 + reference_month BETWEEN ? AND ?", fromYear*12+fromMonth, toYear*12+toMonth).
+		Find(&expenses).Error
+	
+	return expenses, err
+}
+
+// GetWithSplitsBetweenMonths busca as despesas ativas de uma família entre dois meses (inclusive), com as divisões
+func (r *ExpenseRepository) GetWithSplitsBetweenMonths(familyID uint, fromMonth, fromYear, toMonth, toYear int) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Where("reference_year * 12 + reference_month BETWEEN ? AND ?", fromYear*12+fromMonth, toYear*12+toMonth).
+		Preload("Splits").
+		Order("reference_year, reference_month, id").
+		Find(&expenses).Error
+	
+	return expenses, err
+}
+
+// CategoryMonthTotal total de despesas de uma categoria em um mês
+type CategoryMonthTotal struct {
+	CategoryID uint
+	Month      int
+	Year       int
+	TotalCents int64
+}
+
+// GetMonthlyTotalsByCategory soma as despesas ativas por categoria e mês de referência entre dois meses (inclusive)
+func (r *ExpenseRepository) GetMonthlyTotalsByCategory(familyID uint, fromMonth, fromYear, toMonth, toYear int) ([]CategoryMonthTotal, error) {
+	var totals []CategoryMonthTotal
+	err := r.db.Model(&models.Expense{}).
+		Select("category_id, reference_month AS month, reference_year AS year, SUM(amount_cents) AS total_cents").
+		Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Where("reference_year * 12 + reference_month BETWEEN ? AND ?", fromYear*12+fromMonth, toYear*12+toMonth).
+		Group("category_id, reference_month, reference_year").
+		Scan(&totals).Error
+	
+	return totals, err
+}
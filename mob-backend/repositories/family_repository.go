@@ -3,6 +3,7 @@ package repositories
 import (
 	"finance-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FamilyRepository struct {
@@ -90,7 +91,7 @@ func (r *FamilyRepository) GetMembers(familyID uint) ([]models.FamilyMember, err
 			return db.Select("id", "name", "email")
 		}).
 		Preload("Incomes", func(db *gorm.DB) *gorm.DB {
-			return db.Where("is_active = ?", true).Select("id", "family_member_id", "type", "gross_monthly_cents", "net_monthly_cents")
+			return db.Scopes(effectiveIncomes(currentMonthIndex())).Select("id", "family_member_id", "type", "gross_monthly_cents", "net_monthly_cents")
 		}).
 		Find(&members).Error
 	
@@ -124,6 +125,32 @@ func (r *FamilyRepository) GetMemberByID(memberID uint) (*models.FamilyMember, e
 	return &member, nil
 }
 
+// CountDependentsDeclaredBy conta os dependentes ativos da família que o membro deduz no IRPF
+// (cada dependente tem um único declarante; ver FamilyMember.IRPFDeclarantID)
+func (r *FamilyRepository) CountDependentsDeclaredBy(familyID, memberID uint) (int, error) {
+	var family models.FamilyAccount
+	if err := r.db.Select("id", "owner_user_id").First(&family, familyID).Error; err != nil {
+		return 0, err
+	}
+	
+	var members []models.FamilyMember
+	err := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true).
+		Order("id").
+		Find(&members).Error
+	if err != nil {
+		return 0, err
+	}
+	
+	defaultID := models.DefaultIRPFDeclarantID(members, family.OwnerUserID)
+	count := 0
+	for i := range members {
+		if members[i].Role == models.RoleDependent && members[i].IRPFDeclarantID(members, defaultID) == memberID {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // MemberBelongsToFamily verifica se um membro pertence a uma família
 func (r *FamilyRepository) MemberBelongsToFamily(memberID, familyID uint) (bool, error) {
 	var count int64
@@ -138,3 +165,85 @@ func (r *FamilyRepository) MemberBelongsToFamily(memberID, familyID uint) (bool,
 	
 	return count > 0, nil
 }
+
+// GetUserRole retorna o papel do usuário na família e o membro vinculado a ele (se houver).
+// O papel owner vem de OwnerUserID; os demais, do registro ativo em family_members.
+// Retorna papel vazio quando o usuário não tem acesso.
+func (r *FamilyRepository) GetUserRole(userID, familyID uint) (models.MemberRole, *models.FamilyMember, error) {
+	var family models.FamilyAccount
+	if err := r.db.Select("id", "owner_user_id").First(&family, familyID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var members []models.FamilyMember
+	err := r.db.Where("family_account_id = ? AND user_id = ? AND is_active = ?", familyID, userID, true).
+		Limit(1).
+		Find(&members).Error
+	if err != nil {
+		return "", nil, err
+	}
+
+	var member *models.FamilyMember
+	if len(members) > 0 {
+		member = &members[0]
+	}
+
+	role := models.ResolveMemberRole(family.OwnerUserID, userID, member)
+	if role == "" {
+		return "", nil, nil
+	}
+	return role, member, nil
+}
+
+// GetAccessibleByUserID busca famílias das quais o usuário é dono ou membro ativo
+func (r *FamilyRepository) GetAccessibleByUserID(userID uint) ([]models.FamilyAccount, error) {
+	var families []models.FamilyAccount
+	err := r.db.Where("owner_user_id = ? OR id IN (?)", userID,
+		r.db.Model(&models.FamilyMember{}).
+			Select("family_account_id").
+			Where("user_id = ? AND is_active = ?", userID, true)).
+		Preload("Members").
+		Order("name").
+		Find(&families).Error
+
+	return families, err
+}
+
+// GetByIDForUpdate busca família bloqueando a linha (usar dentro de transação)
+func (r *FamilyRepository) GetByIDForUpdate(id uint) (*models.FamilyAccount, error) {
+	var family models.FamilyAccount
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&family, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &family, nil
+}
+
+// GetActiveMemberByUserID busca o membro ativo vinculado ao usuário na família
+func (r *FamilyRepository) GetActiveMemberByUserID(familyID, userID uint) (*models.FamilyMember, error) {
+	var member models.FamilyMember
+	err := r.db.Where("family_account_id = ? AND user_id = ? AND is_active = ?", familyID, userID, true).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateOwner altera o dono da família
+func (r *FamilyRepository) UpdateOwner(familyID, ownerUserID uint) error {
+	return r.db.Model(&models.FamilyAccount{}).
+		Where("id = ?", familyID).
+		Update("owner_user_id", ownerUserID).Error
+}
+
+// UpdateWithTransaction executa alterações da família dentro de uma transação
+func (r *FamilyRepository) UpdateWithTransaction(fn func(*FamilyRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &FamilyRepository{db: tx}
+		return fn(txRepo)
+	})
+}
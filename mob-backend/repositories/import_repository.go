@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// Create cria o lote junto com suas linhas
+func (r *ImportRepository) Create(batch *models.ImportBatch) error {
+	return r.db.Create(batch).Error
+}
+
+// GetByID busca lote por ID com as linhas em ordem do arquivo
+func (r *ImportRepository) GetByID(id uint) (*models.ImportBatch, error) {
+	var batch models.ImportBatch
+	err := r.db.Preload("Rows", func(db *gorm.DB) *gorm.DB {
+		return db.Order("line_number, id")
+	}).First(&batch, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// GetByIDForUpdate busca lote por ID com lock de linha (usar dentro de transação)
+func (r *ImportRepository) GetByIDForUpdate(id uint) (*models.ImportBatch, error) {
+	var batch models.ImportBatch
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&batch, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("import_batch_id = ?", id).Order("line_number, id").Find(&batch.Rows).Error; err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// GetByFamilyID lista os lotes de uma família (sem as linhas), mais recentes primeiro
+func (r *ImportRepository) GetByFamilyID(familyID uint) ([]models.ImportBatch, error) {
+	var batches []models.ImportBatch
+	err := r.db.Where("family_account_id = ?", familyID).
+		Order("created_at DESC").
+		Find(&batches).Error
+
+	return batches, err
+}
+
+// UpdateBatch atualiza os dados do lote
+func (r *ImportRepository) UpdateBatch(batch *models.ImportBatch) error {
+	return r.db.Omit("Rows").Save(batch).Error
+}
+
+// UpdateRow atualiza uma linha do lote
+func (r *ImportRepository) UpdateRow(row *models.ImportRow) error {
+	return r.db.Save(row).Error
+}
+
+// Delete exclui o lote e suas linhas
+func (r *ImportRepository) Delete(id uint) error {
+	return r.db.Delete(&models.ImportBatch{}, id).Error
+}
+
+// CreateExpense cria a despesa de uma linha importada com seus splits
+func (r *ImportRepository) CreateExpense(expense *models.Expense) error {
+	return r.db.Create(expense).Error
+}
+
+// DeactivateExpenses desativa as despesas criadas pelo lote; retorna quantas foram afetadas
+func (r *ImportRepository) DeactivateExpenses(batchID uint) (int64, error) {
+	result := r.db.Model(&models.Expense{}).
+		Where("id IN (?) AND is_active = ?",
+			r.db.Model(&models.ImportRow{}).Select("expense_id").Where("import_batch_id = ? AND expense_id IS NOT NULL", batchID),
+			true).
+		Update("is_active", false)
+
+	return result.RowsAffected, result.Error
+}
+
+// CreateIncome cria a renda de uma entrada importada
+func (r *ImportRepository) CreateIncome(income *models.Income) error {
+	return r.db.Create(income).Error
+}
+
+// DeactivateIncomes desativa as rendas criadas pelo lote; retorna quantas foram afetadas
+func (r *ImportRepository) DeactivateIncomes(batchID uint) (int64, error) {
+	result := r.db.Model(&models.Income{}).
+		Where("id IN (?) AND is_active = ?",
+			r.db.Model(&models.ImportRow{}).Select("income_id").Where("import_batch_id = ? AND income_id IS NOT NULL", batchID),
+			true).
+		Update("is_active", false)
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateWithTransaction executa a confirmação ou reversão do lote dentro de uma transação
+func (r *ImportRepository) UpdateWithTransaction(fn func(*ImportRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &ImportRepository{db: tx}
+		return fn(txRepo)
+	})
+}
@@ -1,10 +1,40 @@
 package repositories
 
 import (
+	"database/sql"
 	"finance-backend/models"
 	"gorm.io/gorm"
+	"time"
 )
 
+// effectiveIncomeCondition filtra as rendas vigentes em um mês (@month = ano*12 + mês - 1): para cada fonte
+// de renda, a versão ativa que começou mais recentemente até o mês (no mesmo mês vale a de maior ID),
+// desde que a fonte não tenha terminado antes do mês
+const effectiveIncomeCondition = `incomes.is_active = true
+	AND incomes.reference_year * 12 + incomes.reference_month - 1 <= @month
+	AND (incomes.end_year IS NULL OR incomes.end_year * 12 + incomes.end_month - 1 >= @month)
+	AND NOT EXISTS (
+		SELECT 1 FROM incomes newer
+		WHERE COALESCE(newer.source_id, newer.id) = COALESCE(incomes.source_id, incomes.id)
+		  AND newer.is_active = true
+		  AND newer.reference_year * 12 + newer.reference_month - 1 <= @month
+		  AND (newer.reference_year * 12 + newer.reference_month, newer.id)
+		      > (incomes.reference_year * 12 + incomes.reference_month, incomes.id)
+	)`
+
+// effectiveIncomes aplica effectiveIncomeCondition para o mês informado (ano*12 + mês - 1)
+func effectiveIncomes(monthIndex int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(effectiveIncomeCondition, sql.Named("month", monthIndex))
+	}
+}
+
+// currentMonthIndex retorna o mês corrente como ano*12 + mês - 1
+func currentMonthIndex() int {
+	now := time.Now()
+	return now.Year()*12 + int(now.Month()) - 1
+}
+
 type IncomeRepository struct {
 	db *gorm.DB
 }
@@ -28,17 +58,21 @@ func (r *IncomeRepository) GetByID(id uint) (*models.Income, error) {
 	return &income, nil
 }
 
-// GetByMemberID busca rendas de um membro
+// GetByMemberID busca o histórico de rendas de um membro (mais recente primeiro)
 func (r *IncomeRepository) GetByMemberID(memberID uint) ([]models.Income, error) {
 	var incomes []models.Income
-	err := r.db.Where("family_member_id = ?", memberID).Find(&incomes).Error
+	err := r.db.Where("family_member_id = ? AND is_active = ?", memberID, true).
+		Order("reference_year DESC, reference_month DESC, id DESC").
+		Find(&incomes).Error
 	return incomes, err
 }
 
-// GetActiveByMemberID busca renda ativa de um membro
+// GetActiveByMemberID busca a principal renda vigente (maior bruto) de um membro no mês corrente
 func (r *IncomeRepository) GetActiveByMemberID(memberID uint) (*models.Income, error) {
 	var income models.Income
-	err := r.db.Where("family_member_id = ? AND is_active = ?", memberID, true).
+	err := r.db.Where("family_member_id = ?", memberID).
+		Scopes(effectiveIncomes(currentMonthIndex())).
+		Order("gross_monthly_cents DESC, id").
 		First(&income).Error
 	
 	if err != nil {
@@ -47,80 +81,93 @@ func (r *IncomeRepository) GetActiveByMemberID(memberID uint) (*models.Income, e
 	return &income, nil
 }
 
-// GetByFamilyID busca todas as rendas de uma família
+// GetEffectiveByMemberID busca as fontes de renda de um membro vigentes no mês/ano informado
+func (r *IncomeRepository) GetEffectiveByMemberID(memberID uint, month, year int) ([]models.Income, error) {
+	var incomes []models.Income
+	err := r.db.Where("family_member_id = ?", memberID).
+		Scopes(effectiveIncomes(year*12 + month - 1)).
+		Order("id").
+		Find(&incomes).Error
+	return incomes, err
+}
+
+// GetByFamilyID busca as rendas da família vigentes no mês corrente
 func (r *IncomeRepository) GetByFamilyID(familyID uint) ([]models.Income, error) {
+	now := time.Now()
+	return r.GetByFamilyIDAndMonth(familyID, int(now.Month()), now.Year())
+}
+
+// GetByFamilyIDAndMonth busca as rendas da família vigentes no mês/ano informado
+// (para cada fonte de renda, a última versão que começou até aquele mês)
+func (r *IncomeRepository) GetByFamilyIDAndMonth(familyID uint, month, year int) ([]models.Income, error) {
 	var incomes []models.Income
+	monthIndex := year*12 + month - 1
 	err := r.db.Joins("JOIN family_members ON family_members.id = incomes.family_member_id").
-		Where("family_members.family_account_id = ? AND incomes.is_active = ?", familyID, true).
+		Where("family_members.family_account_id = ?", familyID).
+		Scopes(effectiveIncomes(monthIndex)).
 		Preload("FamilyMember", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "family_account_id", "role")
 		}).
 		Select("incomes.*").
+		Order("incomes.family_member_id, incomes.gross_monthly_cents DESC, incomes.id").
 		Find(&incomes).Error
 	
 	return incomes, err
 }
 
-// GetByFamilyIDAndMonth busca rendas de uma família filtradas por mês/ano
-// Se não encontrar rendas no mês específico, retorna a renda mais recente de cada membro como padrão
-func (r *IncomeRepository) GetByFamilyIDAndMonth(familyID uint, month, year int) ([]models.Income, error) {
-	var incomes []models.Income
+// GetByFamilyIDForYear busca as rendas da família vigentes em cada mês do ano (índice 0 = janeiro) com uma
+// única consulta: lê as versões que começaram até dezembro e aplica em memória o critério de effectiveIncomeCondition
+func (r *IncomeRepository) GetByFamilyIDForYear(familyID uint, year int) ([12][]models.Income, error) {
+	var months [12][]models.Income
+	var versions []models.Income
 	err := r.db.Joins("JOIN family_members ON family_members.id = incomes.family_member_id").
-		Where("family_members.family_account_id = ? AND incomes.is_active = ? AND incomes.reference_month = ? AND incomes.reference_year = ?", 
-			familyID, true, month, year).
+		Where("family_members.family_account_id = ?", familyID).
+		Where("incomes.is_active = true AND incomes.reference_year * 12 + incomes.reference_month - 1 <= ?", year*12+11).
 		Preload("FamilyMember", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name", "family_account_id", "role")
 		}).
 		Select("incomes.*").
-		Find(&incomes).Error
-	
+		Order("incomes.family_member_id, incomes.gross_monthly_cents DESC, incomes.id").
+		Find(&versions).Error
 	if err != nil {
-		return incomes, err
+		return months, err
 	}
 	
-	// Se não encontrou rendas para o mês específico, buscar a renda mais recente de cada membro
-	if len(incomes) == 0 {
-		var fallbackIncomes []models.Income
-		err = r.db.Raw(`
-			SELECT DISTINCT ON (incomes.family_member_id) incomes.*
-			FROM incomes 
-			JOIN family_members ON family_members.id = incomes.family_member_id
-			WHERE family_members.family_account_id = ? 
-			  AND incomes.is_active = true
-			ORDER BY incomes.family_member_id, incomes.reference_year DESC, incomes.reference_month DESC, incomes.created_at DESC
-		`, familyID).Scan(&fallbackIncomes).Error
-		
-		if err != nil {
-			return incomes, err
-		}
-		
-		// Preload FamilyMember para os fallbackIncomes
-		var memberIDs []uint
-		for _, income := range fallbackIncomes {
-			memberIDs = append(memberIDs, income.FamilyMemberID)
-		}
+	startOf := func(income *models.Income) int {
+		return income.ReferenceYear*12 + income.ReferenceMonth - 1
+	}
+	for month := range months {
+		monthIndex := year*12 + month
 		
-		if len(memberIDs) > 0 {
-			var members []models.FamilyMember
-			r.db.Where("id IN ?", memberIDs).Select("id", "name", "family_account_id", "role").Find(&members)
-			
-			// Map members to incomes
-			memberMap := make(map[uint]models.FamilyMember)
-			for _, member := range members {
-				memberMap[member.ID] = member
+		// Para cada fonte, a versão que começou mais recentemente até o mês (no mesmo mês, a de maior ID)
+		latest := map[uint]int{}
+		for i := range versions {
+			start := startOf(&versions[i])
+			if start > monthIndex {
+				continue
 			}
-			
-			for i := range fallbackIncomes {
-				if member, exists := memberMap[fallbackIncomes[i].FamilyMemberID]; exists {
-					fallbackIncomes[i].FamilyMember = member
+			current, ok := latest[versions[i].SourceKey()]
+			if ok {
+				currentStart := startOf(&versions[current])
+				if currentStart > start || (currentStart == start && versions[current].ID > versions[i].ID) {
+					continue
 				}
 			}
+			latest[versions[i].SourceKey()] = i
 		}
 		
-		return fallbackIncomes, nil
+		for i := range versions {
+			if current, ok := latest[versions[i].SourceKey()]; !ok || current != i {
+				continue
+			}
+			income := versions[i]
+			if income.EndYear != nil && (income.EndMonth == nil || *income.EndYear*12+*income.EndMonth-1 < monthIndex) {
+				continue
+			}
+			months[month] = append(months[month], income)
+		}
 	}
-	
-	return incomes, err
+	return months, nil
 }
 
 // Update atualiza uma renda
@@ -135,21 +182,15 @@ func (r *IncomeRepository) Delete(id uint) error {
 		Update("is_active", false).Error
 }
 
-// DeactivateOtherIncomes desativa outras rendas do mesmo membro (apenas uma ativa por vez)
-func (r *IncomeRepository) DeactivateOtherIncomes(memberID, exceptIncomeID uint) error {
-	return r.db.Model(&models.Income{}).
-		Where("family_member_id = ? AND id != ?", memberID, exceptIncomeID).
-		Update("is_active", false).Error
-}
-
-// CalculateTotalFamilyIncome calcula a renda líquida total da família
+// CalculateTotalFamilyIncome calcula a renda líquida total da família vigente no mês corrente
 func (r *IncomeRepository) CalculateTotalFamilyIncome(familyID uint) (int64, error) {
 	var total int64
 	
 	err := r.db.Model(&models.Income{}).
 		Select("COALESCE(SUM(net_monthly_cents), 0)").
 		Joins("JOIN family_members ON family_members.id = incomes.family_member_id").
-		Where("family_members.family_account_id = ? AND incomes.is_active = ?", familyID, true).
+		Where("family_members.family_account_id = ?", familyID).
+		Scopes(effectiveIncomes(currentMonthIndex())).
 		Scan(&total).Error
 	
 	return total, err
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"time"
+
+	"finance-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+// Create cria um novo convite
+func (r *InvitationRepository) Create(invitation *models.FamilyInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// GetByID busca convite por ID
+func (r *InvitationRepository) GetByID(id uint) (*models.FamilyInvitation, error) {
+	var invitation models.FamilyInvitation
+	if err := r.db.First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetByTokenHash busca convite pelo hash do token, bloqueando a linha para o aceite
+func (r *InvitationRepository) GetByTokenHash(tokenHash string) (*models.FamilyInvitation, error) {
+	var invitation models.FamilyInvitation
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&invitation).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingByFamilyID busca convites pendentes (não aceitos, não revogados e não expirados)
+func (r *InvitationRepository) GetPendingByFamilyID(familyID uint) ([]models.FamilyInvitation, error) {
+	var invitations []models.FamilyInvitation
+	err := r.db.Where("family_account_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", familyID, time.Now()).
+		Preload("FamilyMember").
+		Order("created_at DESC").
+		Find(&invitations).Error
+
+	return invitations, err
+}
+
+// HasPendingForMember verifica se já existe convite pendente para um membro
+func (r *InvitationRepository) HasPendingForMember(memberID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.FamilyInvitation{}).
+		Where("family_member_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", memberID, time.Now()).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// Revoke revoga um convite pendente
+func (r *InvitationRepository) Revoke(id uint) error {
+	return r.db.Model(&models.FamilyInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// MarkAccepted registra o aceite do convite
+func (r *InvitationRepository) MarkAccepted(id, userID uint) error {
+	return r.db.Model(&models.FamilyInvitation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"accepted_at":         time.Now(),
+			"accepted_by_user_id": userID,
+		}).Error
+}
+
+// GetMemberByID busca membro por ID (dentro da transação de aceite)
+func (r *InvitationRepository) GetMemberByID(memberID uint) (*models.FamilyMember, error) {
+	var member models.FamilyMember
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&member, memberID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// SaveMember cria ou atualiza o membro vinculado ao convite
+func (r *InvitationRepository) SaveMember(member *models.FamilyMember) error {
+	return r.db.Save(member).Error
+}
+
+// AcceptWithTransaction executa o aceite do convite dentro de uma transação
+func (r *InvitationRepository) AcceptWithTransaction(fn func(*InvitationRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &InvitationRepository{db: tx}
+		return fn(txRepo)
+	})
+}
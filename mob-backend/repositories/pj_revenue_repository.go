@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PJRevenueRepository struct {
+	db *gorm.DB
+}
+
+func NewPJRevenueRepository(db *gorm.DB) *PJRevenueRepository {
+	return &PJRevenueRepository{db: db}
+}
+
+// Upsert cria ou atualiza o faturamento da fonte no mês
+func (r *PJRevenueRepository) Upsert(revenue *models.PJMonthlyRevenue) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "income_source_id"}, {Name: "year"}, {Name: "month"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revenue_cents", "payroll_cents", "updated_at"}),
+	}).Create(revenue).Error
+}
+
+// GetBySourceID busca o histórico de faturamento da fonte (mais recente primeiro)
+func (r *PJRevenueRepository) GetBySourceID(sourceID uint) ([]models.PJMonthlyRevenue, error) {
+	var revenues []models.PJMonthlyRevenue
+	err := r.db.Where("income_source_id = ?", sourceID).
+		Order("year DESC, month DESC").
+		Find(&revenues).Error
+
+	return revenues, err
+}
+
+// GetBySourceIDBetween busca o faturamento da fonte entre dois meses (ano*12 + mês - 1), inclusive
+func (r *PJRevenueRepository) GetBySourceIDBetween(sourceID uint, fromIndex, toIndex int) ([]models.PJMonthlyRevenue, error) {
+	var revenues []models.PJMonthlyRevenue
+	err := r.db.Where("income_source_id = ? AND year * 12 + month - 1 BETWEEN ? AND ?", sourceID, fromIndex, toIndex).
+		Order("year, month").
+		Find(&revenues).Error
+
+	return revenues, err
+}
+
+// Delete remove o faturamento da fonte no mês
+func (r *PJRevenueRepository) Delete(sourceID uint, month, year int) (bool, error) {
+	result := r.db.Where("income_source_id = ? AND year = ? AND month = ?", sourceID, year, month).
+		Delete(&models.PJMonthlyRevenue{})
+
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"time"
+
+	"finance-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// Create cria uma nova sessão
+func (r *SessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+// GetByID busca sessão por ID
+func (r *SessionRepository) GetByID(id uint) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetActiveByUserID busca as sessões não revogadas e não expiradas de um usuário
+func (r *SessionRepository) GetActiveByUserID(userID uint) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("COALESCE(last_used_at, created_at) DESC").
+		Find(&sessions).Error
+
+	return sessions, err
+}
+
+// IsActive verifica se a sessão pertence ao usuário e ainda é válida
+func (r *SessionRepository) IsActive(sessionID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, userID, time.Now()).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// Touch registra o uso da sessão e estende sua expiração
+func (r *SessionRepository) Touch(sessionID uint, expiresAt time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ?", sessionID).
+		Updates(map[string]interface{}{
+			"last_used_at": time.Now(),
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+// Revoke revoga uma sessão (e, com ela, todos os seus refresh tokens)
+func (r *SessionRepository) Revoke(sessionID uint, reason string) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+// RevokeAllByUserID revoga todas as sessões do usuário, exceto exceptSessionID (0 = nenhuma exceção)
+func (r *SessionRepository) RevokeAllByUserID(userID, exceptSessionID uint, reason string) (int64, error) {
+	result := r.db.Model(&models.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Updates(map[string]interface{}{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		})
+
+	return result.RowsAffected, result.Error
+}
+
+// CreateRefreshToken registra um refresh token emitido para uma sessão
+func (r *SessionRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByHash busca um refresh token pelo hash, bloqueando a linha para rotação
+func (r *SessionRepository) GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Session").
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed marca o token como rotacionado; retorna false se ele já havia sido usado
+func (r *SessionRepository) MarkRefreshTokenUsed(tokenID uint) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// RotateWithTransaction executa a rotação de refresh token dentro de uma transação
+func (r *SessionRepository) RotateWithTransaction(fn func(*SessionRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := &SessionRepository{db: tx}
+		return fn(txRepo)
+	})
+}
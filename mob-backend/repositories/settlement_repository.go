@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"finance-backend/models"
+	"gorm.io/gorm"
+)
+
+type SettlementRepository struct {
+	db *gorm.DB
+}
+
+func NewSettlementRepository(db *gorm.DB) *SettlementRepository {
+	return &SettlementRepository{db: db}
+}
+
+// Create registra um pagamento de acerto
+func (r *SettlementRepository) Create(settlement *models.Settlement) error {
+	return r.db.Omit("FromMember", "ToMember").Create(settlement).Error
+}
+
+// GetByID busca um acerto por ID
+func (r *SettlementRepository) GetByID(id uint) (*models.Settlement, error) {
+	var settlement models.Settlement
+	err := r.db.First(&settlement, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+// GetByFamilyID busca os acertos ativos da família; com month e year > 0 filtra pelo mês de referência
+func (r *SettlementRepository) GetByFamilyID(familyID uint, month, year int) ([]models.Settlement, error) {
+	var settlements []models.Settlement
+	query := r.db.Where("family_account_id = ? AND is_active = ?", familyID, true)
+	if month > 0 && year > 0 {
+		query = query.Where("reference_month = ? AND reference_year = ?", month, year)
+	}
+
+	err := query.
+		Preload("FromMember", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "family_account_id")
+		}).
+		Preload("ToMember", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "family_account_id")
+		}).
+		Order("paid_at DESC, id DESC").
+		Find(&settlements).Error
+
+	return settlements, err
+}
+
+// Delete exclui um acerto (soft delete)
+func (r *SettlementRepository) Delete(id uint) error {
+	return r.db.Model(&models.Settlement{}).
+		Where("id = ?", id).
+		Update("is_active", false).Error
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"finance-backend/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -27,13 +28,43 @@ func (r *TaxRepository) GetINSSBrackets(year int) ([]models.INSSBracket, error)
 // GetIRPFBrackets retorna faixas de IRPF para um ano específico
 func (r *TaxRepository) GetIRPFBrackets(year int) ([]models.IRPFBracket, error) {
 	var brackets []models.IRPFBracket
-	err := r.db.Where("year = ? AND is_active = ?", year, true).
+	err := r.db.Where("year = ? AND is_active = ? AND annual = ?", year, true, false).
+		Order("\"order\" ASC").
+		Find(&brackets).Error
+	
+	return brackets, err
+}
+
+// GetIRPFAnnualBrackets retorna as faixas da tabela anual do IRPF (declaração de ajuste) ativas de um ano
+func (r *TaxRepository) GetIRPFAnnualBrackets(year int) ([]models.IRPFBracket, error) {
+	var brackets []models.IRPFBracket
+	err := r.db.Where("year = ? AND is_active = ? AND annual = ?", year, true, true).
 		Order("\"order\" ASC").
 		Find(&brackets).Error
 	
 	return brackets, err
 }
 
+// GetIRPFReducers retorna as faixas de redução mensal do IRPF ativas de um ano
+func (r *TaxRepository) GetIRPFReducers(year int) ([]models.IRPFReducer, error) {
+	var reducers []models.IRPFReducer
+	err := r.db.Where("year = ? AND is_active = ? AND annual = ?", year, true, false).
+		Order("\"order\" ASC").
+		Find(&reducers).Error
+	
+	return reducers, err
+}
+
+// GetIRPFAnnualReducers retorna as faixas de redução anual do IRPF (declaração de ajuste) ativas de um ano
+func (r *TaxRepository) GetIRPFAnnualReducers(year int) ([]models.IRPFReducer, error) {
+	var reducers []models.IRPFReducer
+	err := r.db.Where("year = ? AND is_active = ? AND annual = ?", year, true, true).
+		Order("\"order\" ASC").
+		Find(&reducers).Error
+	
+	return reducers, err
+}
+
 // GetTaxConfiguration retorna configuração de impostos para um ano
 func (r *TaxRepository) GetTaxConfiguration(year int) (*models.TaxConfiguration, error) {
 	var config models.TaxConfiguration
@@ -51,6 +82,21 @@ func (r *TaxRepository) GetCurrentYear() int {
 	return time.Now().Year()
 }
 
+// GetLatestYearUpTo retorna o ano mais recente com configuração ativa até o ano informado (0 se não houver)
+func (r *TaxRepository) GetLatestYearUpTo(year int) (int, error) {
+	var years []int
+	err := r.db.Model(&models.TaxConfiguration{}).
+		Where("year <= ? AND is_active = ?", year, true).
+		Order("year DESC").
+		Limit(1).
+		Pluck("year", &years).Error
+	
+	if err != nil || len(years) == 0 {
+		return 0, err
+	}
+	return years[0], nil
+}
+
 // CreateINSSBracket cria nova faixa de INSS
 func (r *TaxRepository) CreateINSSBracket(bracket *models.INSSBracket) error {
 	return r.db.Create(bracket).Error
@@ -118,6 +164,14 @@ func (r *TaxRepository) ActivateYearConfiguration(year int) error {
 		err = tx.Model(&models.IRPFBracket{}).
 			Where("year = ?", year).
 			Update("is_active", true).Error
+		if err != nil {
+			return err
+		}
+		
+		// Ativar redutores IRPF
+		err = tx.Model(&models.IRPFReducer{}).
+			Where("year = ?", year).
+			Update("is_active", true).Error
 		
 		return err
 	})
@@ -133,3 +187,109 @@ func (r *TaxRepository) GetAvailableYears() ([]int, error) {
 	
 	return years, err
 }
+
+// GetYearTable retorna configuração e faixas de um ano (IRPF mensais e anuais), ativas ou não
+func (r *TaxRepository) GetYearTable(year int) (*models.TaxConfiguration, []models.INSSBracket, []models.IRPFBracket, error) {
+	var config models.TaxConfiguration
+	if err := r.db.Where("year = ?", year).First(&config).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	
+	var inssBrackets []models.INSSBracket
+	if err := r.db.Where("year = ?", year).Order("\"order\" ASC").Find(&inssBrackets).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	
+	var irpfBrackets []models.IRPFBracket
+	if err := r.db.Where("year = ?", year).Order("annual, \"order\" ASC").Find(&irpfBrackets).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	
+	return &config, inssBrackets, irpfBrackets, nil
+}
+
+// GetYearIRPFReducers retorna as faixas de redução do IRPF de um ano (mensais e anuais), ativas ou não
+func (r *TaxRepository) GetYearIRPFReducers(year int) ([]models.IRPFReducer, error) {
+	var reducers []models.IRPFReducer
+	err := r.db.Where("year = ?", year).Order("annual, \"order\" ASC").Find(&reducers).Error
+	return reducers, err
+}
+
+// GetConfigurations lista as configurações de todos os anos (mais recente primeiro)
+func (r *TaxRepository) GetConfigurations() ([]models.TaxConfiguration, error) {
+	var configs []models.TaxConfiguration
+	err := r.db.Order("year DESC").Find(&configs).Error
+	return configs, err
+}
+
+// GetPreviousYear retorna o ano cadastrado mais recente antes do ano informado, ativo ou não (0 se não houver)
+func (r *TaxRepository) GetPreviousYear(year int) (int, error) {
+	var years []int
+	err := r.db.Model(&models.TaxConfiguration{}).
+		Where("year < ?", year).
+		Order("year DESC").
+		Limit(1).
+		Pluck("year", &years).Error
+	
+	if err != nil || len(years) == 0 {
+		return 0, err
+	}
+	return years[0], nil
+}
+
+// ReplaceYearTable grava a configuração e substitui todas as faixas (e redutores) de um ano em uma transação.
+// Com activate o ano já fica ativo (usado nos cálculos); sem activate um ano novo aguarda ActivateYearConfiguration
+// e um ano já ativo continua ativo. Retorna se o ano ficou ativo
+func (r *TaxRepository) ReplaceYearTable(config *models.TaxConfiguration, inssBrackets []models.INSSBracket, irpfBrackets []models.IRPFBracket, irpfReducers []models.IRPFReducer, activate bool) (bool, error) {
+	active := activate
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if !active {
+			var current []bool
+			err := tx.Model(&models.TaxConfiguration{}).
+				Where("year = ?", config.Year).
+				Pluck("is_active", &current).Error
+			if err != nil {
+				return err
+			}
+			active = len(current) > 0 && current[0]
+		}
+		
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "year"}},
+			DoUpdates: clause.AssignmentColumns([]string{"inss_deduction_per_dependent", "fgts_rate", "irpf_simplified_discount", "irpf_annual_simplified_cap", "irpf_education_cap", "updated_at"}),
+		}).Create(config).Error
+		if err != nil {
+			return err
+		}
+		
+		if err := tx.Where("year = ?", config.Year).Delete(&models.INSSBracket{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("year = ?", config.Year).Delete(&models.IRPFBracket{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&inssBrackets).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&irpfBrackets).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("year = ?", config.Year).Delete(&models.IRPFReducer{}).Error; err != nil {
+			return err
+		}
+		if len(irpfReducers) > 0 {
+			if err := tx.Create(&irpfReducers).Error; err != nil {
+				return err
+			}
+		}
+		
+		// is_active tem default true no banco: o estado é gravado explicitamente
+		for _, model := range []interface{}{&models.TaxConfiguration{}, &models.INSSBracket{}, &models.IRPFBracket{}, &models.IRPFReducer{}} {
+			if err := tx.Model(model).Where("year = ?", config.Year).Update("is_active", active).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return active, err
+}
@@ -6,6 +6,7 @@ import (
 	"finance-backend/config"
 	"finance-backend/controllers"
 	"finance-backend/middleware"
+	"finance-backend/models"
 	"finance-backend/repositories"
 	"finance-backend/services"
 )
@@ -14,17 +15,39 @@ func SetupRoutes(r *gin.Engine) {
 	// Inicializar repositories
 	familyRepo := repositories.NewFamilyRepository(config.DB)
 	incomeRepo := repositories.NewIncomeRepository(config.DB)
+	taxRepo := repositories.NewTaxRepository(config.DB)
 	expenseRepo := repositories.NewExpenseRepository(config.DB)
 	categoryRepo := repositories.NewExpenseCategoryRepository(config.DB)
 	investmentRepo := repositories.NewInvestmentRepository(config.DB)
 	emergencyRepo := repositories.NewEmergencyFundRepository(config.DB)
+	userRepo := repositories.NewUserRepository(config.DB)
+	sessionRepo := repositories.NewSessionRepository(config.DB)
+	invitationRepo := repositories.NewInvitationRepository(config.DB)
+	creditCardRepo := repositories.NewCreditCardRepository(config.DB)
+	importRepo := repositories.NewImportRepository(config.DB)
+	ruleRepo := repositories.NewCategorizationRuleRepository(config.DB)
+	budgetRepo := repositories.NewBudgetRepository(config.DB)
+	settlementRepo := repositories.NewSettlementRepository(config.DB)
+	revenueRepo := repositories.NewPJRevenueRepository(config.DB)
 	
 	// Inicializar services
-	familyService := services.NewFamilyService(familyRepo)
-	incomeService := services.NewIncomeService(incomeRepo, familyRepo)
-	expenseService := services.NewExpenseService(expenseRepo, familyRepo, categoryRepo)
+	familyService := services.NewFamilyService(familyRepo, userRepo)
+	incomeService := services.NewIncomeService(incomeRepo, familyRepo, taxRepo, revenueRepo)
+	expenseService := services.NewExpenseService(expenseRepo, familyRepo, categoryRepo, ruleRepo, incomeRepo)
 	investmentService := services.NewInvestmentService(investmentRepo, expenseRepo)
 	emergencyService := services.NewEmergencyFundService(emergencyRepo, expenseRepo, incomeRepo)
+	authService := services.NewAuthService(userRepo, sessionRepo)
+	invitationService := services.NewInvitationService(invitationRepo, familyRepo, userRepo)
+	recurrenceService := services.NewRecurrenceService(expenseRepo, expenseService)
+	creditCardService := services.NewCreditCardService(creditCardRepo, categoryRepo, expenseService)
+	importService := services.NewImportService(importRepo, expenseRepo, familyRepo, categoryRepo, ruleRepo, expenseService)
+	categorizationService := services.NewCategorizationService(ruleRepo, categoryRepo, expenseRepo, expenseService)
+	categoryService := services.NewCategoryService(categoryRepo)
+	budgetService := services.NewBudgetService(budgetRepo, expenseRepo, categoryRepo)
+	settlementService := services.NewSettlementService(settlementRepo, expenseRepo, familyRepo)
+	taxTableService := services.NewTaxTableService(taxRepo)
+	simulationService := services.NewSimulationService(taxRepo)
+	irpfService := services.NewIRPFDeclarationService(incomeService, expenseRepo, categoryRepo, familyRepo, taxRepo)
 	
 	// Inicializar controllers
 	familyCtrl := controllers.NewFamilyController(familyService)
@@ -32,17 +55,42 @@ func SetupRoutes(r *gin.Engine) {
 	expenseCtrl := controllers.NewExpenseController(expenseService)
 	investmentCtrl := controllers.NewInvestmentController(investmentService)
 	emergencyCtrl := controllers.NewEmergencyFundController(emergencyService)
-	dashboardCtrl := controllers.NewDashboardController(incomeService, expenseService, investmentService, emergencyService)
+	dashboardCtrl := controllers.NewDashboardController(incomeService, expenseService, investmentService, emergencyService, budgetService)
+	authCtrl := controllers.NewAuthController(authService)
+	invitationCtrl := controllers.NewInvitationController(invitationService)
+	recurrenceCtrl := controllers.NewRecurrenceController(recurrenceService)
+	creditCardCtrl := controllers.NewCreditCardController(creditCardService)
+	importCtrl := controllers.NewImportController(importService)
+	ruleCtrl := controllers.NewCategorizationRuleController(categorizationService)
+	categoryCtrl := controllers.NewCategoryController(categoryService)
+	budgetCtrl := controllers.NewBudgetController(budgetService)
+	settlementCtrl := controllers.NewSettlementController(settlementService)
+	taxTableCtrl := controllers.NewTaxTableController(taxTableService)
+	simulationCtrl := controllers.NewSimulationController(simulationService)
+	irpfCtrl := controllers.NewIRPFController(irpfService)
 	
 	// ===== ROTAS PÚBLICAS =====
-	r.POST("/api/auth/register", controllers.Register)
-	r.POST("/api/auth/login", controllers.Login)
+	r.POST("/api/auth/register", authCtrl.Register)
+	r.POST("/api/auth/login", authCtrl.Login)
+	r.POST("/api/auth/refresh", authCtrl.Refresh)
 	
 	// ===== ROTAS PROTEGIDAS =====
 	api := r.Group("/api")
-	api.Use(middleware.AuthMiddleware())
+	api.Use(middleware.AuthMiddleware(sessionRepo))
 	api.Use(middleware.ErrorHandler())
 	{
+		// ===== SESSÕES =====
+		auth := api.Group("/auth")
+		{
+			auth.POST("/logout", authCtrl.Logout)
+			auth.GET("/sessions", authCtrl.GetSessions)
+			auth.DELETE("/sessions", authCtrl.RevokeOtherSessions)
+			auth.DELETE("/sessions/:sessionId", authCtrl.RevokeSession)
+		}
+		
+		// ===== CONVITES (aceite pelo usuário convidado) =====
+		api.POST("/invitations/:token/accept", invitationCtrl.AcceptInvitation)
+		
 		// ===== FAMÍLIAS =====
 		families := api.Group("/families")
 		{
@@ -53,57 +101,144 @@ func SetupRoutes(r *gin.Engine) {
 			family := families.Group("/:familyId")
 			family.Use(middleware.TenantMiddleware(familyRepo))
 			{
-				family.GET("", familyCtrl.GetFamily)
-				family.PUT("", familyCtrl.UpdateFamily)
-				family.DELETE("", familyCtrl.DeleteFamily)
+				// Permissões por papel (ver models.Permission)
+				canRead := middleware.RequirePermission(models.PermFinancesRead)
+				canWrite := middleware.RequirePermission(models.PermFinancesWrite)
+				canManageMembers := middleware.RequirePermission(models.PermMembersManage)
+				canManageInvitations := middleware.RequirePermission(models.PermInvitationsManage)
+				
+				family.GET("", middleware.RequirePermission(models.PermFamilyRead), familyCtrl.GetFamily)
+				family.PUT("", middleware.RequirePermission(models.PermFamilyUpdate), familyCtrl.UpdateFamily)
+				family.DELETE("", middleware.RequirePermission(models.PermFamilyDelete), familyCtrl.DeleteFamily)
+				family.POST("/transfer-ownership", middleware.RequirePermission(models.PermFamilyTransfer), familyCtrl.TransferOwnership)
 				
 				// Membros
-				family.GET("/members", familyCtrl.GetMembers)
-				family.POST("/members", familyCtrl.AddMember)
-				family.PUT("/members/:memberId", familyCtrl.UpdateMember)
-				family.DELETE("/members/:memberId", familyCtrl.RemoveMember)
+				family.GET("/members", middleware.RequirePermission(models.PermMembersRead), familyCtrl.GetMembers)
+				family.POST("/members", canManageMembers, familyCtrl.AddMember)
+				family.PUT("/members/:memberId", canManageMembers, familyCtrl.UpdateMember)
+				family.DELETE("/members/:memberId", canManageMembers, familyCtrl.RemoveMember)
+				
+				// Convites
+				family.POST("/invitations", canManageInvitations, invitationCtrl.CreateInvitation)
+				family.GET("/invitations", canManageInvitations, invitationCtrl.GetPendingInvitations)
+				family.DELETE("/invitations/:invitationId", canManageInvitations, invitationCtrl.RevokeInvitation)
 				
 				// ===== RENDAS =====
-				family.POST("/incomes", incomeCtrl.CreateIncome)
-				family.GET("/incomes", incomeCtrl.GetFamilyIncomes)
-				family.GET("/incomes/summary", incomeCtrl.GetFamilyIncomeSummary)
-				family.GET("/incomes/:incomeId", incomeCtrl.GetIncome)
-				family.GET("/incomes/:incomeId/breakdown", incomeCtrl.GetIncomeBreakdown)
-				family.PUT("/incomes/:incomeId", incomeCtrl.UpdateIncome)
-				family.DELETE("/incomes/:incomeId", incomeCtrl.DeleteIncome)
+				family.POST("/incomes", canWrite, incomeCtrl.CreateIncome)
+				family.GET("/incomes", canRead, incomeCtrl.GetFamilyIncomes)
+				family.GET("/incomes/summary", canRead, incomeCtrl.GetFamilyIncomeSummary)
+				family.GET("/incomes/annual", canRead, incomeCtrl.GetAnnualIncomeProjection)
+				family.GET("/incomes/:incomeId", canRead, incomeCtrl.GetIncome)
+				family.GET("/incomes/:incomeId/breakdown", canRead, incomeCtrl.GetIncomeBreakdown)
+				family.GET("/members/:memberId/incomes", canRead, incomeCtrl.GetMemberIncomeHistory)
+				family.PUT("/incomes/:incomeId", canWrite, incomeCtrl.UpdateIncome)
+				family.DELETE("/incomes/:incomeId", canWrite, incomeCtrl.DeleteIncome)
+				
+				// Faturamento mensal das rendas PJ (RBT12 e Fator R do Simples Nacional) e MEI (limite anual; YYYY-MM)
+				family.GET("/incomes/:incomeId/revenues", canRead, incomeCtrl.GetRevenues)
+				family.PUT("/incomes/:incomeId/revenues/:month", canWrite, incomeCtrl.SetRevenue)
+				family.DELETE("/incomes/:incomeId/revenues/:month", canWrite, incomeCtrl.DeleteRevenue)
+				
+				// Declaração de ajuste anual do IRPF (ano-calendário) e ficha de pagamentos efetuados (?format=csv)
+				family.GET("/members/:memberId/irpf/:year", canRead, irpfCtrl.SimulateDeclaration)
+				family.GET("/members/:memberId/irpf/:year/payments", canRead, irpfCtrl.GetPaymentsReport)
 				
 				// ===== DESPESAS =====
-				family.GET("/categories", expenseCtrl.GetCategories)
-				family.POST("/expenses", expenseCtrl.CreateExpense)
-				family.GET("/expenses", expenseCtrl.GetFamilyExpenses)
-				family.GET("/expenses/summary", expenseCtrl.GetExpensesSummary)
-				family.GET("/expenses/by-category", expenseCtrl.GetExpensesByCategory)
-				family.GET("/expenses/:expenseId", expenseCtrl.GetExpense)
-				family.PUT("/expenses/:expenseId", expenseCtrl.UpdateExpense)
-				family.DELETE("/expenses/:expenseId", expenseCtrl.DeleteExpense)
+				family.GET("/categories", middleware.RequirePermission(models.PermFamilyRead), categoryCtrl.GetCategories)
+				family.GET("/categories/:categoryId", middleware.RequirePermission(models.PermFamilyRead), categoryCtrl.GetCategory)
+				family.POST("/categories", canWrite, categoryCtrl.CreateCategory)
+				family.PUT("/categories/:categoryId", canWrite, categoryCtrl.UpdateCategory)
+				family.DELETE("/categories/:categoryId", canWrite, categoryCtrl.DeleteCategory)
+				family.POST("/expenses", canWrite, expenseCtrl.CreateExpense)
+				family.GET("/expenses", canRead, expenseCtrl.GetFamilyExpenses)
+				family.GET("/expenses/mine", middleware.RequirePermission(models.PermOwnSplitsRead), expenseCtrl.GetMyExpenses)
+				family.GET("/expenses/summary", canRead, expenseCtrl.GetExpensesSummary)
+				family.GET("/expenses/by-category", canRead, expenseCtrl.GetExpensesByCategory)
+				family.GET("/expenses/:expenseId", canRead, expenseCtrl.GetExpense)
+				family.PUT("/expenses/:expenseId", canWrite, expenseCtrl.UpdateExpense)
+				family.DELETE("/expenses/:expenseId", canWrite, expenseCtrl.DeleteExpense)
+				
+				// Regras de categorização automática
+				family.POST("/rules", canWrite, ruleCtrl.CreateRule)
+				family.GET("/rules", canRead, ruleCtrl.GetRules)
+				family.POST("/rules/preview", canRead, ruleCtrl.PreviewRule)
+				family.GET("/rules/:ruleId", canRead, ruleCtrl.GetRule)
+				family.PUT("/rules/:ruleId", canWrite, ruleCtrl.UpdateRule)
+				family.DELETE("/rules/:ruleId", canWrite, ruleCtrl.DeleteRule)
+				
+				// ===== ORÇAMENTOS POR CATEGORIA (YYYY-MM) =====
+				family.GET("/budgets/:month", canRead, budgetCtrl.GetBudgetReport)
+				family.PUT("/budgets/:month", canWrite, budgetCtrl.SetBudgets)
+				family.DELETE("/budgets/:month/:categoryId", canWrite, budgetCtrl.DeleteBudget)
+				
+				// ===== ACERTO DE CONTAS ENTRE MEMBROS (?month=YYYY-MM) =====
+				family.GET("/balances", canRead, settlementCtrl.GetBalances)
+				family.GET("/settlements", canRead, settlementCtrl.GetSettlements)
+				family.POST("/settlements", canWrite, settlementCtrl.CreateSettlement)
+				family.DELETE("/settlements/:settlementId", canWrite, settlementCtrl.DeleteSettlement)
+				
+				// Recorrência: gera as despesas recorrentes do mês (YYYY-MM)
+				family.POST("/months/:month/rollover", canWrite, recurrenceCtrl.Rollover)
+				
+				// ===== CARTÕES DE CRÉDITO =====
+				family.POST("/credit-cards", canWrite, creditCardCtrl.CreateCard)
+				family.GET("/credit-cards", canRead, creditCardCtrl.GetCards)
+				family.GET("/credit-cards/:cardId", canRead, creditCardCtrl.GetCard)
+				family.PUT("/credit-cards/:cardId", canWrite, creditCardCtrl.UpdateCard)
+				family.DELETE("/credit-cards/:cardId", canWrite, creditCardCtrl.DeleteCard)
+				family.POST("/credit-cards/:cardId/purchases", canWrite, creditCardCtrl.CreatePurchase)
+				family.GET("/credit-cards/:cardId/purchases", canRead, creditCardCtrl.GetPurchases)
+				family.DELETE("/credit-cards/:cardId/purchases/:purchaseId", canWrite, creditCardCtrl.DeletePurchase)
+				family.GET("/credit-cards/:cardId/statements", canRead, creditCardCtrl.GetStatements)
+				family.GET("/credit-cards/:cardId/statements/:month", canRead, creditCardCtrl.GetStatement)
+				
+				// ===== IMPORTAÇÃO DE EXTRATOS (OFX/CSV) =====
+				family.GET("/imports/layouts", canRead, importCtrl.GetLayouts)
+				family.POST("/imports", canWrite, importCtrl.UploadImport)
+				family.GET("/imports", canRead, importCtrl.GetImports)
+				family.GET("/imports/:importId", canRead, importCtrl.GetImport)
+				family.PUT("/imports/:importId/rows", canWrite, importCtrl.UpdateRows)
+				family.POST("/imports/:importId/commit", canWrite, importCtrl.CommitImport)
+				family.POST("/imports/:importId/undo", canWrite, importCtrl.UndoImport)
+				family.DELETE("/imports/:importId", canWrite, importCtrl.DiscardImport)
 				
 				// ===== INVESTIMENTOS =====
-				family.POST("/investments", investmentCtrl.CreateInvestment)
-				family.GET("/investments", investmentCtrl.GetFamilyInvestments)
-				family.GET("/investments/summary", investmentCtrl.GetInvestmentsSummary)
-				family.GET("/investments/projection", investmentCtrl.GetFamilyInvestmentsProjection)
-				family.GET("/investments/:investmentId", investmentCtrl.GetInvestment)
-				family.GET("/investments/:investmentId/projection", investmentCtrl.GetInvestmentProjection)
-				family.PUT("/investments/:investmentId", investmentCtrl.UpdateInvestment)
-				family.DELETE("/investments/:investmentId", investmentCtrl.DeleteInvestment)
+				family.POST("/investments", canWrite, investmentCtrl.CreateInvestment)
+				family.GET("/investments", canRead, investmentCtrl.GetFamilyInvestments)
+				family.GET("/investments/summary", canRead, investmentCtrl.GetInvestmentsSummary)
+				family.GET("/investments/projection", canRead, investmentCtrl.GetFamilyInvestmentsProjection)
+				family.GET("/investments/:investmentId", canRead, investmentCtrl.GetInvestment)
+				family.GET("/investments/:investmentId/projection", canRead, investmentCtrl.GetInvestmentProjection)
+				family.PUT("/investments/:investmentId", canWrite, investmentCtrl.UpdateInvestment)
+				family.DELETE("/investments/:investmentId", canWrite, investmentCtrl.DeleteInvestment)
 				
 				// ===== RESERVA DE EMERGÊNCIA =====
-				family.POST("/emergency-fund", emergencyCtrl.CreateOrUpdateEmergencyFund)
-				family.GET("/emergency-fund", emergencyCtrl.GetEmergencyFund)
-				family.GET("/emergency-fund/progress", emergencyCtrl.GetEmergencyFundProgress)
-				family.GET("/emergency-fund/suggest", emergencyCtrl.SuggestMonthlyGoal)
-				family.GET("/emergency-fund/projection", emergencyCtrl.GetEmergencyFundProjection)
-				family.PUT("/emergency-fund/amount", emergencyCtrl.UpdateCurrentAmount)
-				family.DELETE("/emergency-fund", emergencyCtrl.DeleteEmergencyFund)
+				family.POST("/emergency-fund", canWrite, emergencyCtrl.CreateOrUpdateEmergencyFund)
+				family.GET("/emergency-fund", canRead, emergencyCtrl.GetEmergencyFund)
+				family.GET("/emergency-fund/progress", canRead, emergencyCtrl.GetEmergencyFundProgress)
+				family.GET("/emergency-fund/suggest", canRead, emergencyCtrl.SuggestMonthlyGoal)
+				family.GET("/emergency-fund/projection", canRead, emergencyCtrl.GetEmergencyFundProjection)
+				family.PUT("/emergency-fund/amount", canWrite, emergencyCtrl.UpdateCurrentAmount)
+				family.DELETE("/emergency-fund", canWrite, emergencyCtrl.DeleteEmergencyFund)
 				
 				// ===== DASHBOARD =====
-				family.GET("/dashboard", dashboardCtrl.GetDashboard)
+				family.GET("/dashboard", canRead, dashboardCtrl.GetDashboard)
 			}
 		}
+		
+		// ===== SIMULAÇÕES =====
+		api.POST("/simulations/clt-vs-pj", simulationCtrl.CompareCLTvsPJ)
+		
+		// ===== ADMINISTRAÇÃO (somente users.is_admin) =====
+		admin := api.Group("/admin")
+		admin.Use(middleware.AdminMiddleware(userRepo))
+		{
+			// Tabelas de impostos por ano (INSS, IRPF e configuração)
+			admin.GET("/tax-years", taxTableCtrl.ListYears)
+			admin.GET("/tax-years/:year", taxTableCtrl.GetTable)
+			admin.GET("/tax-years/:year/diff", taxTableCtrl.GetDiff)
+			admin.PUT("/tax-years/:year", taxTableCtrl.ImportTable)
+			admin.POST("/tax-years/:year/activate", taxTableCtrl.ActivateYear)
+		}
 	}
 }
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("usuário ou senha inválidos")
+	ErrUserAlreadyExists   = errors.New("usuário já existe")
+	ErrInvalidRefreshToken = errors.New("refresh token inválido ou expirado")
+	ErrRefreshTokenReused  = errors.New("refresh token reutilizado; sessão revogada")
+	ErrSessionNotFound     = errors.New("sessão não encontrada")
+)
+
+type AuthService struct {
+	userRepo    *repositories.UserRepository
+	sessionRepo *repositories.SessionRepository
+}
+
+func NewAuthService(userRepo *repositories.UserRepository, sessionRepo *repositories.SessionRepository) *AuthService {
+	return &AuthService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// SessionClient identifica o dispositivo que abriu a sessão
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// AuthTokens par de tokens emitido no login/refresh
+type AuthTokens struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	SessionID             uint      `json:"session_id"`
+}
+
+// Register cria um usuário e abre a primeira sessão
+func (s *AuthService) Register(name, email, username, password string, client SessionClient) (*models.User, *AuthTokens, error) {
+	// Usar email como username se username não for fornecido (e vice-versa)
+	if username == "" {
+		username = email
+	}
+	if email == "" {
+		email = username
+	}
+	if name == "" {
+		name = username
+	}
+
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateRequiredString(username, "username"))
+	validator.Add(utils.ValidateRequiredString(password, "password"))
+
+	if validator.HasErrors() {
+		return nil, nil, validator.GetErrors()
+	}
+
+	exists, err := s.userRepo.ExistsByUsernameOrEmail(username, email)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, ErrUserAlreadyExists
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &models.User{
+		Name:     name,
+		Email:    email,
+		Username: username,
+		Password: string(hash),
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, nil, ErrUserAlreadyExists
+	}
+
+	tokens, err := s.openSession(user.ID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// Login valida as credenciais e abre uma nova sessão para o dispositivo
+func (s *AuthService) Login(username, password string, client SessionClient) (*models.User, *AuthTokens, error) {
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidCredentials
+		}
+		return nil, nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	tokens, err := s.openSession(user.ID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// Refresh rotaciona o refresh token e emite um novo access token.
+// Apresentar um token já rotacionado indica roubo: a sessão inteira é revogada.
+func (s *AuthService) Refresh(refreshToken string, client SessionClient) (*AuthTokens, error) {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var tokens *AuthTokens
+	var reusedSession *models.Session
+
+	err := s.sessionRepo.RotateWithTransaction(func(txRepo *repositories.SessionRepository) error {
+		current, err := txRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		session := current.Session
+		if session.RevokedAt != nil {
+			return ErrInvalidRefreshToken
+		}
+
+		marked, err := txRepo.MarkRefreshTokenUsed(current.ID)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			// Reuso detectado: revogar a família de tokens (commit da revogação)
+			reusedSession = &session
+			return txRepo.Revoke(session.ID, models.SessionRevokedReuseDetected)
+		}
+
+		now := time.Now()
+		if now.After(current.ExpiresAt) || now.After(session.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		tokens, err = s.issueTokens(txRepo, session.UserID, session.ID)
+		return err
+	})
+
+	if err == nil && reusedSession != nil {
+		utils.GetLogger().Warning("Reuso de refresh token detectado, sessão revogada", map[string]interface{}{
+			"user_id":    reusedSession.UserID,
+			"session_id": reusedSession.ID,
+			"ip":         client.IPAddress,
+			"user_agent": client.UserAgent,
+		})
+		return nil, ErrRefreshTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Logout revoga a sessão atual
+func (s *AuthService) Logout(sessionID uint) error {
+	return s.sessionRepo.Revoke(sessionID, models.SessionRevokedLogout)
+}
+
+// GetActiveSessions lista as sessões ativas do usuário
+func (s *AuthService) GetActiveSessions(userID uint) ([]models.Session, error) {
+	return s.sessionRepo.GetActiveByUserID(userID)
+}
+
+// RevokeSession revoga uma sessão específica do usuário
+func (s *AuthService) RevokeSession(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.sessionRepo.Revoke(sessionID, models.SessionRevokedByUser)
+}
+
+// RevokeOtherSessions revoga todas as sessões do usuário, exceto a atual
+func (s *AuthService) RevokeOtherSessions(userID, currentSessionID uint) (int64, error) {
+	return s.sessionRepo.RevokeAllByUserID(userID, currentSessionID, models.SessionRevokedByUser)
+}
+
+// openSession cria a sessão do dispositivo e emite o primeiro par de tokens
+func (s *AuthService) openSession(userID uint, client SessionClient) (*AuthTokens, error) {
+	var tokens *AuthTokens
+
+	err := s.sessionRepo.RotateWithTransaction(func(txRepo *repositories.SessionRepository) error {
+		now := time.Now()
+		session := &models.Session{
+			UserID:     userID,
+			UserAgent:  client.UserAgent,
+			IPAddress:  client.IPAddress,
+			ExpiresAt:  now.Add(utils.RefreshTokenTTL()),
+			LastUsedAt: &now,
+		}
+		if err := txRepo.Create(session); err != nil {
+			return err
+		}
+
+		var err error
+		tokens, err = s.issueTokens(txRepo, userID, session.ID)
+		return err
+	})
+
+	return tokens, err
+}
+
+// issueTokens gera um novo refresh token para a sessão e o access token correspondente
+func (s *AuthService) issueTokens(txRepo *repositories.SessionRepository, userID, sessionID uint) (*AuthTokens, error) {
+	refreshToken, refreshHash, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshExpiresAt := time.Now().Add(utils.RefreshTokenTTL())
+	if err := txRepo.CreateRefreshToken(&models.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: refreshHash,
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.Touch(sessionID, refreshExpiresAt); err != nil {
+		return nil, err
+	}
+
+	accessToken, accessExpiresAt, err := utils.GenerateAccessToken(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+		SessionID:             sessionID,
+	}, nil
+}
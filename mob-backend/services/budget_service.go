@@ -0,0 +1,304 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+var ErrBudgetNotFound = errors.New("orçamento não encontrado")
+
+// Faixas de consumo do orçamento que geram alerta
+const (
+	BudgetWarningPercent  = 80.0
+	BudgetExceededPercent = 100.0
+)
+
+// Status do orçamento de uma categoria no mês
+const (
+	BudgetStatusOK       = "ok"
+	BudgetStatusWarning  = "warning"  // atingiu 80%
+	BudgetStatusExceeded = "exceeded" // atingiu 100%
+)
+
+type BudgetService struct {
+	budgetRepo   *repositories.BudgetRepository
+	expenseRepo  *repositories.ExpenseRepository
+	categoryRepo *repositories.ExpenseCategoryRepository
+}
+
+func NewBudgetService(
+	budgetRepo *repositories.BudgetRepository,
+	expenseRepo *repositories.ExpenseRepository,
+	categoryRepo *repositories.ExpenseCategoryRepository,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:   budgetRepo,
+		expenseRepo:  expenseRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// BudgetInput orçamento de uma categoria a partir de um mês
+type BudgetInput struct {
+	CategoryID  uint  `json:"category_id"`
+	AmountCents int64 `json:"amount_cents"` // 0 encerra o orçamento a partir do mês
+	Rollover    bool  `json:"rollover"`     // sobra do mês passa para o mês seguinte
+}
+
+// BudgetReportItem planejado x realizado de uma categoria no mês
+type BudgetReportItem struct {
+	BudgetID      uint    `json:"budget_id"`
+	CategoryID    uint    `json:"category_id"`
+	CategoryName  string  `json:"category_name"`
+	ParentID      *uint   `json:"parent_id,omitempty"`
+	EffectiveFrom string  `json:"effective_from"` // YYYY-MM em que o valor foi definido
+	Rollover      bool    `json:"rollover"`
+	Planned       float64 `json:"planned"`      // orçamento do mês
+	CarriedOver   float64 `json:"carried_over"` // sobra acumulada dos meses anteriores
+	Available     float64 `json:"available"`    // planejado + sobra
+	Actual        float64 `json:"actual"`       // despesas da categoria (e subcategorias)
+	Remaining     float64 `json:"remaining"`    // negativo quando estourado
+	PercentUsed   float64 `json:"percent_used"`
+	Status        string  `json:"status"` // ok, warning ou exceeded
+}
+
+// UnbudgetedSpending gasto do mês em categoria sem orçamento
+type UnbudgetedSpending struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Actual       float64 `json:"actual"`
+}
+
+// BudgetReport relatório mensal de orçamento por categoria
+type BudgetReport struct {
+	Month          int                  `json:"month"`
+	Year           int                  `json:"year"`
+	Categories     []BudgetReportItem   `json:"categories"`
+	Unbudgeted     []UnbudgetedSpending `json:"unbudgeted"`
+	TotalAvailable float64              `json:"total_available"`
+	TotalActual    float64              `json:"total_actual"` // somente categorias com orçamento
+	TotalRemaining float64              `json:"total_remaining"`
+}
+
+// SetBudgets define o orçamento das categorias a partir do mês informado e retorna o relatório do mês
+func (s *BudgetService) SetBudgets(familyID uint, month, year int, inputs []BudgetInput) (*BudgetReport, error) {
+	validator := utils.NewValidator()
+	if len(inputs) == 0 {
+		validator.AddError(utils.ValidationError{Field: "budgets", Message: "informe ao menos um orçamento"})
+	}
+
+	seen := make(map[uint]bool)
+	for i, input := range inputs {
+		field := fmt.Sprintf("budgets[%d]", i)
+		validator.Add(utils.ValidateNonNegativeAmount(input.AmountCents, field+".amount_cents"))
+		if seen[input.CategoryID] {
+			validator.AddError(utils.ValidationError{Field: field + ".category_id", Message: "categoria repetida"})
+			continue
+		}
+		seen[input.CategoryID] = true
+		if _, err := s.categoryRepo.GetByIDForFamily(input.CategoryID, familyID); err != nil {
+			validator.AddError(utils.ValidationError{Field: field + ".category_id", Message: "categoria não encontrada"})
+		}
+	}
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+
+	for _, input := range inputs {
+		budget := &models.CategoryBudget{
+			FamilyAccountID: familyID,
+			CategoryID:      input.CategoryID,
+			Year:            year,
+			Month:           month,
+			AmountCents:     input.AmountCents,
+			Rollover:        input.Rollover,
+		}
+		if err := s.budgetRepo.Upsert(budget); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetBudgetReport(familyID, month, year)
+}
+
+// DeleteBudget remove o orçamento definido no mês (volta a valer o definido anteriormente, se houver)
+func (s *BudgetService) DeleteBudget(familyID, categoryID uint, month, year int) error {
+	deleted, err := s.budgetRepo.Delete(familyID, categoryID, month, year)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBudgetNotFound
+	}
+	return nil
+}
+
+// GetBudgetReport calcula planejado x realizado x restante por categoria no mês.
+// Despesas de subcategorias contam também no orçamento da categoria pai.
+func (s *BudgetService) GetBudgetReport(familyID uint, month, year int) (*BudgetReport, error) {
+	report := &BudgetReport{
+		Month:      month,
+		Year:       year,
+		Categories: []BudgetReportItem{},
+		Unbudgeted: []UnbudgetedSpending{},
+	}
+	target := year*12 + month - 1
+
+	categories, err := s.categoryRepo.GetByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+	categoryByID := make(map[uint]models.ExpenseCategory, len(categories))
+	for _, category := range categories {
+		categoryByID[category.ID] = category
+	}
+
+	budgets, err := s.budgetRepo.GetByFamilyIDUpTo(familyID, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	// Histórico de cada categoria (em ordem cronológica); categorias excluídas são ignoradas
+	history := make(map[uint][]models.CategoryBudget)
+	order := []uint{}
+	start := target
+	for _, budget := range budgets {
+		if _, ok := categoryByID[budget.CategoryID]; !ok {
+			continue
+		}
+		if _, exists := history[budget.CategoryID]; !exists {
+			order = append(order, budget.CategoryID)
+		}
+		history[budget.CategoryID] = append(history[budget.CategoryID], budget)
+		if budget.MonthIndex() < start {
+			start = budget.MonthIndex()
+		}
+	}
+
+	totals, err := s.expenseRepo.GetMonthlyTotalsByCategory(familyID, start%12+1, start/12, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gasto por categoria e mês, somando as subcategorias no pai
+	actual := make(map[uint]map[int]int64)
+	addActual := func(categoryID uint, index int, cents int64) {
+		if actual[categoryID] == nil {
+			actual[categoryID] = make(map[int]int64)
+		}
+		actual[categoryID][index] += cents
+	}
+	for _, total := range totals {
+		index := total.Year*12 + total.Month - 1
+		addActual(total.CategoryID, index, total.TotalCents)
+		if category, ok := categoryByID[total.CategoryID]; ok && category.ParentID != nil {
+			addActual(*category.ParentID, index, total.TotalCents)
+		}
+	}
+
+	budgeted := make(map[uint]bool)
+	for _, categoryID := range order {
+		item, ok := buildBudgetItem(history[categoryID], actual[categoryID], target)
+		if !ok {
+			continue
+		}
+		category := categoryByID[categoryID]
+		item.CategoryName = category.Name
+		item.ParentID = category.ParentID
+		budgeted[categoryID] = true
+
+		report.Categories = append(report.Categories, *item)
+		report.TotalAvailable += item.Available
+		report.TotalActual += item.Actual
+		report.TotalRemaining += item.Remaining
+	}
+
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		return report.Categories[i].PercentUsed > report.Categories[j].PercentUsed
+	})
+
+	// Gastos do mês sem orçamento na categoria nem na categoria pai, agrupados no pai
+	unbudgeted := make(map[uint]int64)
+	unbudgetedOrder := []uint{}
+	for _, total := range totals {
+		if total.Year*12+total.Month-1 != target || budgeted[total.CategoryID] {
+			continue
+		}
+		categoryID := total.CategoryID
+		if category, ok := categoryByID[categoryID]; ok && category.ParentID != nil {
+			if budgeted[*category.ParentID] {
+				continue
+			}
+			categoryID = *category.ParentID
+		}
+		if _, exists := unbudgeted[categoryID]; !exists {
+			unbudgetedOrder = append(unbudgetedOrder, categoryID)
+		}
+		unbudgeted[categoryID] += total.TotalCents
+	}
+	for _, categoryID := range unbudgetedOrder {
+		report.Unbudgeted = append(report.Unbudgeted, UnbudgetedSpending{
+			CategoryID:   categoryID,
+			CategoryName: categoryByID[categoryID].Name,
+			Actual:       utils.CentsToFloat(unbudgeted[categoryID]),
+		})
+	}
+
+	return report, nil
+}
+
+// buildBudgetItem percorre o histórico da categoria mês a mês até o mês alvo, acumulando a sobra
+// dos meses com rollover. Retorna false se a categoria não tem orçamento no mês alvo.
+func buildBudgetItem(history []models.CategoryBudget, actual map[int]int64, target int) (*BudgetReportItem, bool) {
+	current := 0
+	carry := int64(0)
+	for index := history[0].MonthIndex(); index < target; index++ {
+		for current+1 < len(history) && history[current+1].MonthIndex() <= index {
+			current++
+		}
+		budget := history[current]
+		if budget.AmountCents == 0 || !budget.Rollover {
+			carry = 0
+			continue
+		}
+		carry = utils.MaxCents(0, budget.AmountCents+carry-actual[index])
+	}
+
+	for current+1 < len(history) && history[current+1].MonthIndex() <= target {
+		current++
+	}
+	budget := history[current]
+	if budget.AmountCents == 0 {
+		return nil, false
+	}
+
+	available := budget.AmountCents + carry
+	spent := actual[target]
+	percent := utils.CalculatePercentageOf(spent, available)
+
+	status := BudgetStatusOK
+	if percent >= BudgetExceededPercent {
+		status = BudgetStatusExceeded
+	} else if percent >= BudgetWarningPercent {
+		status = BudgetStatusWarning
+	}
+
+	return &BudgetReportItem{
+		BudgetID:      budget.ID,
+		CategoryID:    budget.CategoryID,
+		EffectiveFrom: fmt.Sprintf("%04d-%02d", budget.Year, budget.Month),
+		Rollover:      budget.Rollover,
+		Planned:       utils.CentsToFloat(budget.AmountCents),
+		CarriedOver:   utils.CentsToFloat(carry),
+		Available:     utils.CentsToFloat(available),
+		Actual:        utils.CentsToFloat(spent),
+		Remaining:     utils.CentsToFloat(available - spent),
+		PercentUsed:   percent,
+		Status:        status,
+	}, true
+}
@@ -0,0 +1,110 @@
+package calculation
+
+// CLTAnnualInput vínculo CLT ao longo de um ano (valores em centavos)
+type CLTAnnualInput struct {
+	MonthlyGrossCents    [12]int64 // salário bruto de cada mês (0 = sem vínculo no mês)
+	MonthlyBenefitsCents [12]int64 // benefícios pagos com o salário (vales e bônus)
+	Dependents           int       // dependentes deduzidos no IRPF
+	VacationMonth        int       // mês de gozo das férias (1-12; 0 = sem férias no ano)
+}
+
+// CLTAnnualMonth valores de um mês da projeção anual (em centavos)
+type CLTAnnualMonth struct {
+	Month              int
+	SalaryCents        int64 // salário ou remuneração das férias
+	VacationBonusCents int64 // 1/3 constitucional de férias
+	ThirteenthCents    int64 // parcela do 13º paga no mês
+	BenefitsCents      int64
+	INSSCents          int64 // inclui o INSS do 13º na parcela final
+	IRPFCents          int64 // inclui o IRPF do 13º na parcela final
+	FGTSCents          int64 // informativo, não desconta do salário
+	NetCents           int64
+}
+
+// CLTAnnualResult projeção anual de um vínculo CLT
+type CLTAnnualResult struct {
+	Months              [12]CLTAnnualMonth
+	ThirteenthCents     int64 // 13º bruto (proporcional aos meses trabalhados)
+	ThirteenthINSSCents int64
+	ThirteenthIRPFCents int64
+	VacationBonusCents  int64
+	GrossCents          int64 // salários + 1/3 de férias + 13º
+	INSSCents           int64
+	IRPFCents           int64
+	FGTSCents           int64
+	NetCents            int64 // inclui benefícios
+}
+
+// CalculateCLTAnnual projeta mês a mês um vínculo CLT no ano da tabela do calculador:
+//
+//   - no mês de férias o salário é pago com o 1/3 constitucional e o INSS/IRPF incidem sobre o total
+//   - o 13º é proporcional aos meses com salário (1/12 por mês) sobre o último salário do ano; com vínculo
+//     em novembro e dezembro é pago em duas parcelas (metade em novembro, sem descontos, e o restante em
+//     dezembro), senão é pago inteiro no último mês do vínculo
+//   - o 13º tem tributação exclusiva: INSS e IRPF são calculados sobre ele isoladamente e descontados na
+//     última parcela
+//
+// O vínculo é calculado isoladamente (sem considerar o teto do INSS somado a outras fontes).
+func (tc *TaxCalculator) CalculateCLTAnnual(input CLTAnnualInput) CLTAnnualResult {
+	var result CLTAnnualResult
+
+	workedMonths, lastMonth := 0, 0
+	for i, gross := range input.MonthlyGrossCents {
+		if gross > 0 {
+			workedMonths++
+			lastMonth = i + 1
+		}
+	}
+
+	for i := range result.Months {
+		month := &result.Months[i]
+		month.Month = i + 1
+		month.SalaryCents = input.MonthlyGrossCents[i]
+		if month.SalaryCents == 0 {
+			continue
+		}
+
+		if month.Month == input.VacationMonth {
+			month.VacationBonusCents = month.SalaryCents / 3
+		}
+		remuneration := month.SalaryCents + month.VacationBonusCents
+		month.BenefitsCents = input.MonthlyBenefitsCents[i]
+		month.INSSCents = tc.CalculateINSS(remuneration)
+		month.IRPFCents = tc.CalculateIRPF(remuneration, month.INSSCents, input.Dependents)
+		month.FGTSCents = tc.CalculateFGTS(remuneration)
+		result.VacationBonusCents += month.VacationBonusCents
+	}
+
+	if workedMonths > 0 {
+		result.ThirteenthCents = input.MonthlyGrossCents[lastMonth-1] * int64(workedMonths) / 12
+		result.ThirteenthINSSCents = tc.CalculateINSS(result.ThirteenthCents)
+		result.ThirteenthIRPFCents = tc.CalculateIRPF(result.ThirteenthCents, result.ThirteenthINSSCents, input.Dependents)
+
+		final := &result.Months[lastMonth-1]
+		if lastMonth == 12 && input.MonthlyGrossCents[10] > 0 {
+			first := &result.Months[10]
+			first.ThirteenthCents = result.ThirteenthCents / 2
+			first.FGTSCents += tc.CalculateFGTS(first.ThirteenthCents)
+			final.ThirteenthCents = result.ThirteenthCents - first.ThirteenthCents
+		} else {
+			final.ThirteenthCents = result.ThirteenthCents
+		}
+		final.FGTSCents += tc.CalculateFGTS(final.ThirteenthCents)
+		final.INSSCents += result.ThirteenthINSSCents
+		final.IRPFCents += result.ThirteenthIRPFCents
+	}
+
+	for i := range result.Months {
+		month := &result.Months[i]
+		gross := month.SalaryCents + month.VacationBonusCents + month.ThirteenthCents
+		month.NetCents = gross + month.BenefitsCents - month.INSSCents - month.IRPFCents
+
+		result.GrossCents += gross
+		result.INSSCents += month.INSSCents
+		result.IRPFCents += month.IRPFCents
+		result.FGTSCents += month.FGTSCents
+		result.NetCents += month.NetCents
+	}
+
+	return result
+}
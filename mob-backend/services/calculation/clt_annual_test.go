@@ -0,0 +1,139 @@
+package calculation
+
+import "testing"
+
+// cltMonths salário bruto (em reais) do mês from ao mês to, inclusive
+func cltMonths(from, to int, salary int64) [12]int64 {
+	var months [12]int64
+	for month := from; month <= to; month++ {
+		months[month-1] = salary * 100
+	}
+	return months
+}
+
+func TestCalculateCLTAnnual(t *testing.T) {
+	tests := []struct {
+		name           string
+		monthly        [12]int64
+		vacationMonth  int
+		thirteenth     int64 // valores em centavos
+		thirteenthINSS int64
+		thirteenthIRPF int64
+		vacationBonus  int64
+		// parcelas do 13º por mês (1-12)
+		installments map[int]int64
+		// INSS e IRPF do mês da última parcela (salário + 13º, calculados separadamente)
+		finalMonth int
+		finalINSS  int64
+		finalIRPF  int64
+		gross      int64
+		inss       int64
+		irpf       int64
+		fgts       int64
+		net        int64
+	}{
+		{
+			// Sobre 8.000 + 8.000 o IRPF do mês seria maior: o 13º é tributado isoladamente
+			name:           "ano inteiro com férias em julho",
+			monthly:        cltMonths(1, 12, 8000),
+			vacationMonth:  7,
+			thirteenth:     800000,
+			thirteenthINSS: 92151,
+			thirteenthIRPF: 103785,
+			vacationBonus:  266666,
+			installments:   map[int]int64{11: 400000, 12: 400000},
+			finalMonth:     12,
+			finalINSS:      92151 + 92151,
+			finalIRPF:      103785 + 103785,
+			gross:          10666666,
+			inss:           1204621,
+			irpf:           1420708,
+			fgts:           853333,
+			net:            8041337,
+		},
+		{
+			// 9 meses: 13º de 6.000 × 9/12, isento de IRPF com a redução
+			name:           "admissão em abril",
+			monthly:        cltMonths(4, 12, 6000),
+			thirteenth:     450000,
+			thirteenthINSS: 43151,
+			thirteenthIRPF: 0,
+			installments:   map[int]int64{11: 225000, 12: 225000},
+			finalMonth:     12,
+			finalINSS:      64151 + 43151,
+			finalIRPF:      38510,
+			gross:          5850000,
+			inss:           620510,
+			irpf:           346590,
+			fgts:           468000,
+			net:            4882900,
+		},
+		{
+			name:           "desligamento em junho paga o 13º inteiro no último mês",
+			monthly:        cltMonths(1, 6, 6000),
+			thirteenth:     300000,
+			thirteenthINSS: 24860,
+			thirteenthIRPF: 0,
+			installments:   map[int]int64{6: 300000},
+			finalMonth:     6,
+			finalINSS:      64151 + 24860,
+			finalIRPF:      38510,
+			gross:          3900000,
+			inss:           409766,
+			irpf:           231060,
+			fgts:           312000,
+			net:            3259174,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calculator2026().CalculateCLTAnnual(CLTAnnualInput{
+				MonthlyGrossCents: tt.monthly,
+				VacationMonth:     tt.vacationMonth,
+			})
+
+			if result.ThirteenthCents != tt.thirteenth {
+				t.Errorf("13º = %d, esperado %d", result.ThirteenthCents, tt.thirteenth)
+			}
+			if result.ThirteenthINSSCents != tt.thirteenthINSS {
+				t.Errorf("INSS do 13º = %d, esperado %d", result.ThirteenthINSSCents, tt.thirteenthINSS)
+			}
+			if result.ThirteenthIRPFCents != tt.thirteenthIRPF {
+				t.Errorf("IRPF do 13º = %d, esperado %d", result.ThirteenthIRPFCents, tt.thirteenthIRPF)
+			}
+			if result.VacationBonusCents != tt.vacationBonus {
+				t.Errorf("1/3 de férias = %d, esperado %d", result.VacationBonusCents, tt.vacationBonus)
+			}
+			for _, month := range result.Months {
+				if month.ThirteenthCents != tt.installments[month.Month] {
+					t.Errorf("parcela do 13º no mês %d = %d, esperado %d", month.Month, month.ThirteenthCents, tt.installments[month.Month])
+				}
+			}
+
+			final := result.Months[tt.finalMonth-1]
+			if final.INSSCents != tt.finalINSS {
+				t.Errorf("INSS do mês %d = %d, esperado %d", tt.finalMonth, final.INSSCents, tt.finalINSS)
+			}
+			if final.IRPFCents != tt.finalIRPF {
+				t.Errorf("IRPF do mês %d = %d, esperado %d", tt.finalMonth, final.IRPFCents, tt.finalIRPF)
+			}
+
+			if result.GrossCents != tt.gross {
+				t.Errorf("bruto = %d, esperado %d", result.GrossCents, tt.gross)
+			}
+			if result.INSSCents != tt.inss {
+				t.Errorf("INSS = %d, esperado %d", result.INSSCents, tt.inss)
+			}
+			if result.IRPFCents != tt.irpf {
+				t.Errorf("IRPF = %d, esperado %d", result.IRPFCents, tt.irpf)
+			}
+			if result.FGTSCents != tt.fgts {
+				t.Errorf("FGTS = %d, esperado %d", result.FGTSCents, tt.fgts)
+			}
+			if result.NetCents != tt.net {
+				t.Errorf("líquido = %d, esperado %d", result.NetCents, tt.net)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package calculation
+
+import (
+	"math"
+
+	"finance-backend/models"
+)
+
+// Teto do salário de contribuição do INSS usado no fallback (2025)
+const inssCeilingFallbackCents = 778602
+
+// Alíquota de INSS retida do contribuinte individual: autônomo que presta serviço a empresas e sócio
+// sobre o pró-labore
+const individualINSSRate = 0.11
+
+// SourceTaxes impostos retidos em uma fonte de renda (em centavos)
+type SourceTaxes struct {
+	INSSCents          int64
+	IRPFCents          int64
+	FGTSCents          int64
+	IRPFReductionCents int64 // redução do IRPF do ano já descontada de IRPFCents
+	IRPFSimplified     bool  // IRPF calculado com o desconto simplificado
+}
+
+// CalculateSourceTaxes calcula os impostos retidos em uma fonte de renda considerando as demais fontes
+// do membro vigentes no mesmo mês. dependents é deduzido da base do IRPF da fonte.
+//
+//   - CLT e freelance contribuem ao INSS até um único teto somando todas as fontes; a fonte calculada
+//     contribui apenas sobre o que as outras ainda não usaram (o trabalhador informa as outras fontes ao pagador)
+//   - cada pagador retém IRPF sobre a própria fonte; aluguéis somam-se no carnê-leão do mês, então o
+//     imposto de um aluguel é o acréscimo que ele causa no carnê-leão
+//   - aposentadoria e pensão não contribuem ao INSS
+//
+// PJ não é calculado aqui: o DAS é da empresa e o pró-labore usa CalculateProLaboreTaxes (via CalculatePJNet).
+func (tc *TaxCalculator) CalculateSourceTaxes(incomeType models.IncomeType, grossCents int64, others []models.Income, dependents int) SourceTaxes {
+	var contributionBase, rentCents int64
+	for _, other := range others {
+		switch other.Type {
+		case models.IncomeCLT, models.IncomeFreelance:
+			contributionBase += other.GrossMonthlyCents
+		case models.IncomeAluguel:
+			rentCents += other.GrossMonthlyCents
+		}
+	}
+
+	var taxes SourceTaxes
+	switch incomeType {
+	case models.IncomeCLT:
+		// INSS progressivo sobre o total das fontes, descontado o que as outras já recolhem
+		taxes.INSSCents = tc.CalculateINSS(contributionBase+grossCents) - tc.CalculateINSS(contributionBase)
+		taxes.FGTSCents = tc.CalculateFGTS(grossCents)
+		taxes.setIRPF(tc.CalculateIRPFDetailed(grossCents, taxes.INSSCents, dependents))
+	case models.IncomeFreelance:
+		remaining := tc.INSSCeilingCents() - contributionBase
+		if remaining > 0 {
+			base := grossCents
+			if base > remaining {
+				base = remaining
+			}
+			taxes.INSSCents = int64(math.Round(float64(base) * individualINSSRate))
+		}
+		taxes.setIRPF(tc.CalculateIRPFDetailed(grossCents, taxes.INSSCents, dependents))
+	case models.IncomeAluguel:
+		total := tc.CalculateIRPFDetailed(rentCents+grossCents, 0, dependents)
+		previous := tc.CalculateIRPFDetailed(rentCents, 0, dependents)
+		taxes.IRPFCents = total.TaxCents - previous.TaxCents
+		if total.ReductionCents > previous.ReductionCents {
+			taxes.IRPFReductionCents = total.ReductionCents - previous.ReductionCents
+		}
+		taxes.IRPFSimplified = total.Simplified
+	case models.IncomeAposentadoria, models.IncomePensao:
+		taxes.setIRPF(tc.CalculateIRPFDetailed(grossCents, 0, dependents))
+	}
+
+	return taxes
+}
+
+func (t *SourceTaxes) setIRPF(result IRPFResult) {
+	t.IRPFCents = result.TaxCents
+	t.IRPFReductionCents = result.ReductionCents
+	t.IRPFSimplified = result.Simplified
+}
+
+// CalculateProLaboreTaxes calcula o INSS (11% até o teto) e o IRPF retidos do sócio sobre o pró-labore
+// (em centavos)
+func (tc *TaxCalculator) CalculateProLaboreTaxes(proLaboreCents int64, dependents int) (inssCents, irpfCents int64) {
+	base := proLaboreCents
+	if ceiling := tc.INSSCeilingCents(); base > ceiling {
+		base = ceiling
+	}
+	inssCents = int64(math.Round(float64(base) * individualINSSRate))
+	irpfCents = tc.CalculateIRPF(proLaboreCents, inssCents, dependents)
+	return
+}
+
+// INSSCeilingCents retorna o teto do salário de contribuição (limite da última faixa do INSS)
+func (tc *TaxCalculator) INSSCeilingCents() int64 {
+	brackets, err := tc.inssBrackets()
+	if err != nil || len(brackets) == 0 {
+		return inssCeilingFallbackCents
+	}
+
+	maxValue := brackets[len(brackets)-1].MaxValue
+	if maxValue == 0 || maxValue > 999999999 {
+		return math.MaxInt64
+	}
+	return int64(math.Round(maxValue * 100))
+}
@@ -0,0 +1,119 @@
+package calculation
+
+import (
+	"testing"
+
+	"finance-backend/models"
+)
+
+// otherSource outra fonte do membro vigente no mês, com o bruto em reais
+func otherSource(incomeType models.IncomeType, gross int64) models.Income {
+	return models.Income{Type: incomeType, GrossMonthlyCents: gross * 100}
+}
+
+func TestCalculateSourceTaxes(t *testing.T) {
+	tests := []struct {
+		name       string
+		incomeType models.IncomeType
+		gross      int64 // em reais
+		others     []models.Income
+		dependents int
+		inss       int64 // valores em centavos
+		irpf       int64
+		fgts       int64
+		reduction  int64
+		simplified bool
+	}{
+		{
+			name:       "CLT sem outras fontes",
+			incomeType: models.IncomeCLT,
+			gross:      6000,
+			inss:       64151,
+			irpf:       38510,
+			fgts:       48000,
+			reduction:  17975,
+		},
+		{
+			// INSS(11.000, limitado ao teto) - INSS(5.000) = 988,09 - 501,51
+			name:       "CLT com outro vínculo divide o teto do INSS",
+			incomeType: models.IncomeCLT,
+			gross:      6000,
+			others:     []models.Income{otherSource(models.IncomeCLT, 5000)},
+			inss:       48658,
+			irpf:       39454,
+			fgts:       48000,
+			reduction:  17975,
+			simplified: true,
+		},
+		{
+			name:       "freelance sem outras fontes contribui com 11%",
+			incomeType: models.IncomeFreelance,
+			gross:      6000,
+			inss:       66000,
+			irpf:       38002,
+			reduction:  17975,
+		},
+		{
+			// 11% sobre o que falta até o teto: (8.475,55 - 7.000) × 11%
+			name:       "freelance contribui só até o teto",
+			incomeType: models.IncomeFreelance,
+			gross:      6000,
+			others:     []models.Income{otherSource(models.IncomeCLT, 7000)},
+			inss:       16231,
+			irpf:       39454,
+			reduction:  17975,
+			simplified: true,
+		},
+		{
+			name:       "freelance com o teto já atingido",
+			incomeType: models.IncomeFreelance,
+			gross:      6000,
+			others:     []models.Income{otherSource(models.IncomeCLT, 9000)},
+			inss:       0,
+			irpf:       39454,
+			reduction:  17975,
+			simplified: true,
+		},
+		{
+			// Carnê-leão sobre 6.000 menos o de 3.000 (isento)
+			name:       "aluguel soma no carnê-leão dos outros aluguéis",
+			incomeType: models.IncomeAluguel,
+			gross:      3000,
+			others:     []models.Income{otherSource(models.IncomeAluguel, 3000)},
+			irpf:       39454,
+			reduction:  17975,
+			simplified: true,
+		},
+		{
+			name:       "aposentadoria não contribui ao INSS",
+			incomeType: models.IncomeAposentadoria,
+			gross:      4000,
+			others:     []models.Income{otherSource(models.IncomeCLT, 5000)},
+			irpf:       0,
+			reduction:  11476,
+			simplified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxes := calculator2026().CalculateSourceTaxes(tt.incomeType, tt.gross*100, tt.others, tt.dependents)
+
+			if taxes.INSSCents != tt.inss {
+				t.Errorf("INSS = %d, esperado %d", taxes.INSSCents, tt.inss)
+			}
+			if taxes.IRPFCents != tt.irpf {
+				t.Errorf("IRPF = %d, esperado %d", taxes.IRPFCents, tt.irpf)
+			}
+			if taxes.FGTSCents != tt.fgts {
+				t.Errorf("FGTS = %d, esperado %d", taxes.FGTSCents, tt.fgts)
+			}
+			if taxes.IRPFReductionCents != tt.reduction {
+				t.Errorf("redução = %d, esperado %d", taxes.IRPFReductionCents, tt.reduction)
+			}
+			if taxes.IRPFSimplified != tt.simplified {
+				t.Errorf("simplificado = %v, esperado %v", taxes.IRPFSimplified, tt.simplified)
+			}
+		})
+	}
+}
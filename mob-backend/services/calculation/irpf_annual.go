@@ -0,0 +1,188 @@
+package calculation
+
+import (
+	"finance-backend/models"
+	"math"
+)
+
+// Valores de 2025 usados quando o ano não tem os parâmetros anuais cadastrados
+const (
+	annualSimplifiedCapFallback = 16754.34
+	educationCapFallback        = 3561.50
+	dependentDeductionFallback  = 189.59
+)
+
+// Limites legais da declaração de ajuste
+const (
+	annualSimplifiedRate = 0.20 // desconto simplificado: 20% dos rendimentos tributáveis, limitado ao teto do ano
+	pgblDeductionRate    = 0.12 // PGBL dedutível até 12% dos rendimentos tributáveis
+)
+
+// Modelos da declaração de ajuste
+const (
+	IRPFModelComplete   = "completa"
+	IRPFModelSimplified = "simplificada"
+)
+
+// IRPFAnnualInput rendimentos, retenções e despesas dedutíveis de um contribuinte no ano (em centavos)
+type IRPFAnnualInput struct {
+	TaxableIncomeCents int64 // rendimentos tributáveis (sem o 13º, de tributação exclusiva)
+	WithheldIRPFCents  int64 // IRPF retido na fonte e recolhido no carnê-leão
+	INSSCents          int64 // previdência oficial
+	Dependents         int
+	HealthCents        int64
+	EducationCents     []int64 // gastos com instrução de cada pessoa (titular e dependentes); o limite é por pessoa
+	PGBLCents          int64
+	AlimonyCents       int64 // pensão alimentícia judicial
+}
+
+// IRPFAnnualDeductions deduções aceitas no modelo completo, já limitadas (em centavos)
+type IRPFAnnualDeductions struct {
+	INSSCents       int64
+	DependentsCents int64
+	HealthCents     int64
+	EducationCents  int64 // gasto de cada pessoa limitado ao limite do ano
+	PGBLCents       int64 // limitado a 12% dos rendimentos tributáveis
+	AlimonyCents    int64
+	TotalCents      int64
+}
+
+// IRPFAnnualModel apuração do imposto em um modelo de declaração (em centavos)
+type IRPFAnnualModel struct {
+	DeductionsCents  int64
+	TaxableBaseCents int64
+	TaxCents         int64 // imposto devido após a redução anual
+	ReductionCents   int64
+	ResultCents      int64 // imposto devido - retido: positivo = a pagar, negativo = a restituir
+}
+
+// IRPFAnnualResult comparação dos modelos completo e simplificado
+type IRPFAnnualResult struct {
+	Deductions IRPFAnnualDeductions
+	Complete   IRPFAnnualModel
+	Simplified IRPFAnnualModel
+	BestModel  string // completa ou simplificada (a de menor imposto; simplificada no empate)
+
+	// Sem tabela anual cadastrada o imposto usa a tabela mensal × 12, que difere da anual oficial
+	// quando a tabela mensal mudou durante o ano
+	MonthlyTableApproximation bool
+}
+
+// CalculateIRPFAnnual apura a declaração de ajuste no ano do calculador. Usa a tabela anual do ano ou, sem
+// ela, a tabela mensal × 12 (MonthlyTableApproximation). O modelo completo deduz INSS, dependentes, saúde,
+// instrução (limite por pessoa), PGBL (até 12%) e pensão alimentícia; o simplificado desconta 20% dos
+// rendimentos, limitado ao teto do ano. A redução anual do ano (se cadastrada) é aplicada sobre os
+// rendimentos tributáveis nos dois modelos
+func (tc *TaxCalculator) CalculateIRPFAnnual(input IRPFAnnualInput) IRPFAnnualResult {
+	simplifiedCap, educationCap, dependentDeduction := annualSimplifiedCapFallback, educationCapFallback, dependentDeductionFallback
+	if config, err := tc.taxConfiguration(); err == nil {
+		dependentDeduction = config.INSSDeductionPerDependent
+		if config.IRPFAnnualSimplifiedCap > 0 {
+			simplifiedCap = config.IRPFAnnualSimplifiedCap
+		}
+		if config.IRPFEducationCap > 0 {
+			educationCap = config.IRPFEducationCap
+		}
+	}
+
+	var result IRPFAnnualResult
+	deductions := &result.Deductions
+	deductions.INSSCents = input.INSSCents
+	deductions.DependentsCents = int64(math.Round(dependentDeduction * 12 * 100 * float64(input.Dependents)))
+	deductions.HealthCents = input.HealthCents
+	for _, educationCents := range input.EducationCents {
+		deductions.EducationCents += minCents(educationCents, int64(math.Round(educationCap*100)))
+	}
+	deductions.PGBLCents = minCents(input.PGBLCents, int64(math.Round(float64(input.TaxableIncomeCents)*pgblDeductionRate)))
+	deductions.AlimonyCents = input.AlimonyCents
+	deductions.TotalCents = deductions.INSSCents + deductions.DependentsCents + deductions.HealthCents +
+		deductions.EducationCents + deductions.PGBLCents + deductions.AlimonyCents
+
+	simplifiedDiscount := minCents(int64(math.Round(float64(input.TaxableIncomeCents)*annualSimplifiedRate)), int64(math.Round(simplifiedCap*100)))
+
+	_, months := tc.annualBrackets()
+	result.MonthlyTableApproximation = months != 1
+	result.Complete = tc.irpfAnnualModel(input.TaxableIncomeCents, deductions.TotalCents, input.WithheldIRPFCents)
+	result.Simplified = tc.irpfAnnualModel(input.TaxableIncomeCents, simplifiedDiscount, input.WithheldIRPFCents)
+	result.BestModel = IRPFModelSimplified
+	if result.Complete.TaxCents < result.Simplified.TaxCents {
+		result.BestModel = IRPFModelComplete
+	}
+	return result
+}
+
+// Best retorna a apuração do modelo mais vantajoso
+func (r IRPFAnnualResult) Best() IRPFAnnualModel {
+	if r.BestModel == IRPFModelComplete {
+		return r.Complete
+	}
+	return r.Simplified
+}
+
+func (tc *TaxCalculator) irpfAnnualModel(taxableIncomeCents, deductionsCents, withheldCents int64) IRPFAnnualModel {
+	model := IRPFAnnualModel{DeductionsCents: deductionsCents}
+	model.TaxableBaseCents = taxableIncomeCents - deductionsCents
+	if model.TaxableBaseCents < 0 {
+		model.TaxableBaseCents = 0
+	}
+
+	taxCents := tc.annualTableTax(model.TaxableBaseCents)
+	if taxCents > 0 {
+		reducers, err := tc.irpfAnnualReducers()
+		if err == nil {
+			model.ReductionCents = reductionCents(reducers, float64(taxableIncomeCents)/100.0, taxCents)
+		}
+	}
+	model.TaxCents = taxCents - model.ReductionCents
+	model.ResultCents = model.TaxCents - withheldCents
+	return model
+}
+
+// annualBrackets faixas da tabela anual: as anuais cadastradas (months = 1) ou as mensais, que valem
+// multiplicadas por months = 12
+func (tc *TaxCalculator) annualBrackets() (brackets []models.IRPFBracket, months float64) {
+	if brackets, err := tc.irpfAnnualBrackets(); err == nil && len(brackets) > 0 {
+		return brackets, 1
+	}
+	brackets, err := tc.irpfBrackets()
+	if err != nil {
+		return nil, 12
+	}
+	return brackets, 12
+}
+
+// annualTableTax aplica a tabela progressiva anual (ou as faixas e parcelas a deduzir mensais × 12)
+func (tc *TaxCalculator) annualTableTax(baseCents int64) int64 {
+	if baseCents <= 0 {
+		return 0
+	}
+
+	brackets, months := tc.annualBrackets()
+	if len(brackets) == 0 {
+		return calculateIRPFFallback(int64(math.Round(float64(baseCents)/12)), 0, 0) * 12
+	}
+
+	base := float64(baseCents) / 100.0
+	var irpf float64
+	for _, bracket := range brackets {
+		maxValue := bracket.MaxValue * months
+		if bracket.MaxValue == 0 || bracket.MaxValue > 999999999 {
+			maxValue = math.MaxFloat64
+		}
+		if base <= maxValue {
+			irpf = base*bracket.Rate - bracket.Deduction*months
+			break
+		}
+	}
+	if irpf <= 0 {
+		return 0
+	}
+	return int64(math.Round(irpf * 100))
+}
+
+func minCents(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
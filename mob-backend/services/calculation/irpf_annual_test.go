@@ -0,0 +1,168 @@
+package calculation
+
+import "testing"
+
+func TestCalculateIRPFAnnual(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         IRPFAnnualInput
+		monthlyTable  bool  // sem a tabela anual cadastrada
+		education     int64 // valores em centavos
+		pgbl          int64
+		deductions    int64
+		completeTax   int64
+		simplifiedTax int64
+		reduction     int64 // redução anual do modelo escolhido
+		result        int64 // a pagar (positivo) ou a restituir (negativo) no modelo escolhido
+		bestModel     string
+	}{
+		{
+			// Desconto de 20% limitado a 16.754,34: (100.000 - 16.754,34) × 27,5% - 10.904,76 = 11.987,80
+			name:          "simplificado com o desconto no teto",
+			input:         IRPFAnnualInput{TaxableIncomeCents: 10000000, WithheldIRPFCents: 1500000, INSSCents: 1000000},
+			deductions:    1000000,
+			completeTax:   1384524,
+			simplifiedTax: 1198780,
+			result:        -301220,
+			bestModel:     IRPFModelSimplified,
+		},
+		{
+			// INSS 10.000 + dependente 2.275,08 + saúde 15.000 + instrução 3.561,50 + PGBL 12.000 = 42.836,58;
+			// (100.000 - 42.836,58) × 27,5% - 10.904,76 = 4.815,18
+			name: "completo com todas as deduções",
+			input: IRPFAnnualInput{
+				TaxableIncomeCents: 10000000,
+				WithheldIRPFCents:  1500000,
+				INSSCents:          1000000,
+				Dependents:         1,
+				HealthCents:        1500000,
+				EducationCents:     []int64{500000},
+				PGBLCents:          2000000,
+			},
+			education:     356150,
+			pgbl:          1200000,
+			deductions:    4283658,
+			completeTax:   481518,
+			simplifiedTax: 1198780,
+			result:        -1018482,
+			bestModel:     IRPFModelComplete,
+		},
+		{
+			// 3.561,50 + 2.000 + 3.561,50: o limite vale para cada pessoa, não para a soma
+			name: "instrução limitada por pessoa",
+			input: IRPFAnnualInput{
+				TaxableIncomeCents: 10000000,
+				INSSCents:          1000000,
+				EducationCents:     []int64{500000, 200000, 356150},
+			},
+			education:     912300,
+			deductions:    1912300,
+			completeTax:   1133642,
+			simplifiedTax: 1198780,
+			result:        1133642,
+			bestModel:     IRPFModelComplete,
+		},
+		{
+			// PGBL de 10.000 limitado a 12% de 50.000; a redução anual zera os dois modelos
+			name:          "PGBL limitado a 12% dos rendimentos",
+			input:         IRPFAnnualInput{TaxableIncomeCents: 5000000, INSSCents: 500000, PGBLCents: 1000000},
+			pgbl:          600000,
+			deductions:    1100000,
+			completeTax:   0,
+			simplifiedTax: 0,
+			reduction:     127008,
+			result:        0,
+			bestModel:     IRPFModelSimplified,
+		},
+		{
+			name:          "empate fica com o simplificado",
+			input:         IRPFAnnualInput{TaxableIncomeCents: 10000000, INSSCents: 1675434},
+			deductions:    1675434,
+			completeTax:   1198780,
+			simplifiedTax: 1198780,
+			result:        1198780,
+			bestModel:     IRPFModelSimplified,
+		},
+		{
+			// 8.429,73 - 0,095575 × 70.000 = 1.739,48 nos dois modelos, sobre os rendimentos tributáveis
+			name:          "redução anual na faixa decrescente",
+			input:         IRPFAnnualInput{TaxableIncomeCents: 7000000, WithheldIRPFCents: 300000, INSSCents: 700000},
+			deductions:    700000,
+			completeTax:   468076,
+			simplifiedTax: 275576,
+			reduction:     173948,
+			result:        -24424,
+			bestModel:     IRPFModelSimplified,
+		},
+		{
+			// (60.000 - 12.000) × 22,5% - 8.105,88 = 2.694,12, abaixo da redução de 2.694,15
+			name:          "R$ 60.000 isento com a redução",
+			input:         IRPFAnnualInput{TaxableIncomeCents: 6000000, WithheldIRPFCents: 100000},
+			completeTax:   290109,
+			simplifiedTax: 0,
+			reduction:     269412,
+			result:        -100000,
+			bestModel:     IRPFModelSimplified,
+		},
+		{
+			// A tabela mensal de 2026 × 12 coincide com a anual: mesmo imposto do modelo completo acima
+			name: "sem tabela anual usa a mensal × 12",
+			input: IRPFAnnualInput{
+				TaxableIncomeCents: 10000000,
+				WithheldIRPFCents:  1500000,
+				INSSCents:          1000000,
+				Dependents:         1,
+				HealthCents:        1500000,
+				EducationCents:     []int64{500000},
+				PGBLCents:          2000000,
+			},
+			monthlyTable:  true,
+			education:     356150,
+			pgbl:          1200000,
+			deductions:    4283658,
+			completeTax:   481518,
+			simplifiedTax: 1198780,
+			result:        -1018482,
+			bestModel:     IRPFModelComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := calculator2026()
+			if tt.monthlyTable {
+				tc.table.annualBrackets = nil
+			}
+			result := tc.CalculateIRPFAnnual(tt.input)
+
+			if result.MonthlyTableApproximation != tt.monthlyTable {
+				t.Errorf("aproximação pela tabela mensal = %v, esperado %v", result.MonthlyTableApproximation, tt.monthlyTable)
+			}
+			if result.Deductions.EducationCents != tt.education {
+				t.Errorf("instrução = %d, esperado %d", result.Deductions.EducationCents, tt.education)
+			}
+			if result.Deductions.PGBLCents != tt.pgbl {
+				t.Errorf("PGBL = %d, esperado %d", result.Deductions.PGBLCents, tt.pgbl)
+			}
+			if result.Deductions.TotalCents != tt.deductions {
+				t.Errorf("deduções = %d, esperado %d", result.Deductions.TotalCents, tt.deductions)
+			}
+			if result.Complete.TaxCents != tt.completeTax {
+				t.Errorf("imposto no completo = %d, esperado %d", result.Complete.TaxCents, tt.completeTax)
+			}
+			if result.Simplified.TaxCents != tt.simplifiedTax {
+				t.Errorf("imposto no simplificado = %d, esperado %d", result.Simplified.TaxCents, tt.simplifiedTax)
+			}
+			if result.BestModel != tt.bestModel {
+				t.Errorf("modelo = %s, esperado %s", result.BestModel, tt.bestModel)
+			}
+			best := result.Best()
+			if best.ReductionCents != tt.reduction {
+				t.Errorf("redução = %d, esperado %d", best.ReductionCents, tt.reduction)
+			}
+			if best.ResultCents != tt.result {
+				t.Errorf("resultado = %d, esperado %d", best.ResultCents, tt.result)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package calculation
+
+import (
+	"math"
+
+	"finance-backend/models"
+)
+
+// Alíquotas do Lucro Presumido (PIS/COFINS cumulativos)
+const (
+	pisRate                  = 0.0065
+	cofinsRate               = 0.03
+	irpjRate                 = 0.15
+	irpjAdditionalRate       = 0.10
+	irpjAdditionalLimitCents = 2000000 // adicional sobre o lucro presumido acima de R$ 20.000 por mês
+	csllRate                 = 0.09
+	employerINSSRate         = 0.20 // contribuição patronal sobre o pró-labore
+)
+
+// LucroPresumidoResult tributos mensais da empresa no Lucro Presumido (em centavos)
+type LucroPresumidoResult struct {
+	PISCents          int64
+	COFINSCents       int64
+	IRPJCents         int64 // inclui o adicional de 10%
+	CSLLCents         int64
+	EmployerINSSCents int64 // 20% sobre o pró-labore
+	TaxCents          int64
+}
+
+// CalculateLucroPresumido calcula os tributos do mês: PIS (0,65%) e COFINS (3%) sobre o faturamento,
+// IRPJ (15% + 10% do que passar de R$ 20.000) e CSLL (9%) sobre o lucro presumido — 32% do faturamento
+// em serviços; no comércio, 8% para o IRPJ e 12% para a CSLL — e o INSS patronal sobre o pró-labore.
+// IRPJ e CSLL são apurados por trimestre; aqui aparecem pela parcela mensal
+func CalculateLucroPresumido(revenueCents int64, activity string, proLaboreCents int64) LucroPresumidoResult {
+	irpjPresumption, csllPresumption := 0.32, 0.32
+	if activity == models.ActivityCommerce {
+		irpjPresumption, csllPresumption = 0.08, 0.12
+	}
+
+	revenue := float64(revenueCents)
+	irpjBase := revenue * irpjPresumption
+	irpj := irpjBase * irpjRate
+	if irpjBase > irpjAdditionalLimitCents {
+		irpj += (irpjBase - irpjAdditionalLimitCents) * irpjAdditionalRate
+	}
+
+	result := LucroPresumidoResult{
+		PISCents:          int64(math.Round(revenue * pisRate)),
+		COFINSCents:       int64(math.Round(revenue * cofinsRate)),
+		IRPJCents:         int64(math.Round(irpj)),
+		CSLLCents:         int64(math.Round(revenue * csllPresumption * csllRate)),
+		EmployerINSSCents: int64(math.Round(float64(proLaboreCents) * employerINSSRate)),
+	}
+	result.TaxCents = result.PISCents + result.COFINSCents + result.IRPJCents + result.CSLLCents + result.EmployerINSSCents
+	return result
+}
+
+// CalculateLucroPresumidoNet calcula os tributos da empresa no Lucro Presumido e a renda do sócio
+// (pró-labore líquido + lucros distribuídos)
+func (tc *TaxCalculator) CalculateLucroPresumidoNet(grossMonthlyCents int64, activity string, proLaboreCents, benefitsCents int64, dependents int) (LucroPresumidoResult, PJResult) {
+	taxes := CalculateLucroPresumido(grossMonthlyCents, activity, proLaboreCents)
+	return taxes, tc.companyNet(grossMonthlyCents, taxes.TaxCents, proLaboreCents, benefitsCents, dependents)
+}
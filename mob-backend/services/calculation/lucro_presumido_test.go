@@ -0,0 +1,115 @@
+package calculation
+
+import (
+	"testing"
+
+	"finance-backend/models"
+)
+
+func TestCalculateLucroPresumido(t *testing.T) {
+	tests := []struct {
+		name      string
+		revenue   int64 // faturamento do mês em reais
+		activity  string
+		proLabore int64 // em reais
+		pis       int64 // valores em centavos
+		cofins    int64
+		irpj      int64
+		csll      int64
+		employer  int64
+		tax       int64
+	}{
+		{
+			// Lucro presumido de 32%: 9.600 × 15% de IRPJ e 9.600 × 9% de CSLL
+			name:      "serviços sem adicional de IRPJ",
+			revenue:   30000,
+			activity:  models.ActivityServices,
+			proLabore: 5000,
+			pis:       19500,
+			cofins:    90000,
+			irpj:      144000,
+			csll:      86400,
+			employer:  100000,
+			tax:       439900,
+		},
+		{
+			// 62.500 × 32% = 20.000: no limite, sem adicional
+			name:     "serviços com o lucro presumido no limite do adicional",
+			revenue:  62500,
+			activity: models.ActivityServices,
+			pis:      40625,
+			cofins:   187500,
+			irpj:     300000,
+			csll:     180000,
+			tax:      708125,
+		},
+		{
+			// 32.000 × 15% + (32.000 - 20.000) × 10%
+			name:     "serviços com adicional de IRPJ",
+			revenue:  100000,
+			activity: models.ActivityServices,
+			pis:      65000,
+			cofins:   300000,
+			irpj:     600000,
+			csll:     288000,
+			tax:      1253000,
+		},
+		{
+			// IRPJ sobre 8% (8.000) e CSLL sobre 12% (12.000)
+			name:      "comércio sem adicional de IRPJ",
+			revenue:   100000,
+			activity:  models.ActivityCommerce,
+			proLabore: 3000,
+			pis:       65000,
+			cofins:    300000,
+			irpj:      120000,
+			csll:      108000,
+			employer:  60000,
+			tax:       653000,
+		},
+		{
+			// 300.000 × 8% = 24.000: 24.000 × 15% + 4.000 × 10%
+			name:     "comércio com adicional de IRPJ",
+			revenue:  300000,
+			activity: models.ActivityCommerce,
+			pis:      195000,
+			cofins:   900000,
+			irpj:     400000,
+			csll:     324000,
+			tax:      1819000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, pj := calculator2026().CalculateLucroPresumidoNet(tt.revenue*100, tt.activity, tt.proLabore*100, 0, 0)
+
+			if result.PISCents != tt.pis {
+				t.Errorf("PIS = %d, esperado %d", result.PISCents, tt.pis)
+			}
+			if result.COFINSCents != tt.cofins {
+				t.Errorf("COFINS = %d, esperado %d", result.COFINSCents, tt.cofins)
+			}
+			if result.IRPJCents != tt.irpj {
+				t.Errorf("IRPJ = %d, esperado %d", result.IRPJCents, tt.irpj)
+			}
+			if result.CSLLCents != tt.csll {
+				t.Errorf("CSLL = %d, esperado %d", result.CSLLCents, tt.csll)
+			}
+			if result.EmployerINSSCents != tt.employer {
+				t.Errorf("INSS patronal = %d, esperado %d", result.EmployerINSSCents, tt.employer)
+			}
+			if result.TaxCents != tt.tax {
+				t.Errorf("tributos = %d, esperado %d", result.TaxCents, tt.tax)
+			}
+
+			// O que sobra depois dos tributos e do pró-labore é distribuído como lucro
+			if profit := tt.revenue*100 - tt.tax - tt.proLabore*100; pj.ProfitDistributionCents != profit {
+				t.Errorf("lucros distribuídos = %d, esperado %d", pj.ProfitDistributionCents, profit)
+			}
+			if pj.CompanyTaxCents != tt.tax {
+				t.Errorf("tributos da empresa = %d, esperado %d", pj.CompanyTaxCents, tt.tax)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package calculation
+
+import (
+	"math"
+
+	"finance-backend/models"
+)
+
+// Limite anual de faturamento do MEI (R$ 81.000) e a tolerância de 20% antes do desenquadramento retroativo
+const (
+	meiAnnualCeilingCents = 8100000
+	meiCeilingTolerance   = 0.2
+)
+
+// Salário mínimo nacional por ano (em centavos): o INSS do MEI é 5% dele
+var meiMinimumWages = []struct {
+	Year  int
+	Cents int64
+}{
+	{2024, 141200},
+	{2025, 151800},
+	{2026, 162100},
+}
+
+// ICMS e ISS fixos do DAS-SIMEI (em centavos)
+const (
+	meiICMSCents = 100
+	meiISSCents  = 500
+)
+
+// MEIDAS valor mensal do DAS-SIMEI (em centavos)
+type MEIDAS struct {
+	INSSCents  int64 // 5% do salário mínimo
+	ICMSCents  int64 // comércio e indústria
+	ISSCents   int64 // serviços
+	TotalCents int64
+}
+
+// CalculateMEIDAS calcula o DAS fixo do MEI no ano: 5% do salário mínimo vigente (o do ano ou o mais
+// recente anterior), mais R$ 1 de ICMS no comércio e R$ 5 de ISS em serviços
+func CalculateMEIDAS(year int, activity string) MEIDAS {
+	minimumWage := meiMinimumWages[0].Cents
+	for _, wage := range meiMinimumWages {
+		if wage.Year <= year {
+			minimumWage = wage.Cents
+		}
+	}
+
+	das := MEIDAS{INSSCents: int64(math.Round(float64(minimumWage) * 0.05))}
+	if activity == models.ActivityCommerce || activity == models.ActivityCommerceServices {
+		das.ICMSCents = meiICMSCents
+	}
+	if activity == models.ActivityServices || activity == models.ActivityCommerceServices {
+		das.ISSCents = meiISSCents
+	}
+	das.TotalCents = das.INSSCents + das.ICMSCents + das.ISSCents
+	return das
+}
+
+// MEICeiling faturamento do MEI no ano comparado ao limite
+type MEICeiling struct {
+	RevenueCents      int64 // faturamento do ano
+	CeilingCents      int64 // R$ 81.000, ou R$ 6.750 por mês de atividade no ano de abertura
+	Exceeded          bool  // acima do limite: DAS complementar sobre o excesso e desenquadramento no ano seguinte
+	ToleranceExceeded bool  // acima do limite + 20%: desenquadramento retroativo ao início do ano
+}
+
+// CheckMEICeiling soma o faturamento dos meses do ano e compara com o limite do MEI. firstMonth é o mês
+// de abertura quando a empresa abriu no ano (1 nos demais anos)
+func CheckMEICeiling(monthlyRevenueCents [12]int64, firstMonth int) MEICeiling {
+	if firstMonth < 1 {
+		firstMonth = 1
+	}
+
+	var ceiling MEICeiling
+	for _, revenue := range monthlyRevenueCents {
+		ceiling.RevenueCents += revenue
+	}
+	ceiling.CeilingCents = meiAnnualCeilingCents * int64(13-firstMonth) / 12
+	ceiling.Exceeded = ceiling.RevenueCents > ceiling.CeilingCents
+	ceiling.ToleranceExceeded = float64(ceiling.RevenueCents) > float64(ceiling.CeilingCents)*(1+meiCeilingTolerance)
+	return ceiling
+}
@@ -0,0 +1,164 @@
+package calculation
+
+import (
+	"testing"
+
+	"finance-backend/models"
+)
+
+func TestCalculateMEIDAS(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     int
+		activity string
+		inss     int64 // valores em centavos
+		icms     int64
+		iss      int64
+		total    int64
+	}{
+		{
+			// 5% de R$ 1.621,00
+			name:     "serviços em 2026",
+			year:     2026,
+			activity: models.ActivityServices,
+			inss:     8105,
+			iss:      500,
+			total:    8605,
+		},
+		{
+			name:     "comércio em 2026",
+			year:     2026,
+			activity: models.ActivityCommerce,
+			inss:     8105,
+			icms:     100,
+			total:    8205,
+		},
+		{
+			name:     "comércio e serviços em 2026",
+			year:     2026,
+			activity: models.ActivityCommerceServices,
+			inss:     8105,
+			icms:     100,
+			iss:      500,
+			total:    8705,
+		},
+		{
+			// 5% de R$ 1.518,00
+			name:     "serviços em 2025",
+			year:     2025,
+			activity: models.ActivityServices,
+			inss:     7590,
+			iss:      500,
+			total:    8090,
+		},
+		{
+			name:     "ano sem salário mínimo cadastrado usa o mais recente anterior",
+			year:     2027,
+			activity: models.ActivityServices,
+			inss:     8105,
+			iss:      500,
+			total:    8605,
+		},
+		{
+			name:     "ano anterior à tabela usa o salário mínimo mais antigo",
+			year:     2023,
+			activity: models.ActivityCommerce,
+			inss:     7060,
+			icms:     100,
+			total:    7160,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			das := CalculateMEIDAS(tt.year, tt.activity)
+
+			if das.INSSCents != tt.inss {
+				t.Errorf("INSS = %d, esperado %d", das.INSSCents, tt.inss)
+			}
+			if das.ICMSCents != tt.icms {
+				t.Errorf("ICMS = %d, esperado %d", das.ICMSCents, tt.icms)
+			}
+			if das.ISSCents != tt.iss {
+				t.Errorf("ISS = %d, esperado %d", das.ISSCents, tt.iss)
+			}
+			if das.TotalCents != tt.total {
+				t.Errorf("DAS = %d, esperado %d", das.TotalCents, tt.total)
+			}
+		})
+	}
+}
+
+// meiRevenue faturamento mensal (em reais) igual em todos os meses a partir de from
+func meiRevenue(from int, revenue int64) [12]int64 {
+	var months [12]int64
+	for month := from; month <= 12; month++ {
+		months[month-1] = revenue * 100
+	}
+	return months
+}
+
+func TestCheckMEICeiling(t *testing.T) {
+	tests := []struct {
+		name              string
+		revenue           [12]int64
+		firstMonth        int
+		ceiling           int64 // em reais
+		exceeded          bool
+		toleranceExceeded bool
+	}{
+		{
+			name:       "exatamente no limite",
+			revenue:    meiRevenue(1, 6750),
+			firstMonth: 1,
+			ceiling:    81000,
+		},
+		{
+			name:       "acima do limite dentro da tolerância",
+			revenue:    meiRevenue(1, 7500),
+			firstMonth: 1,
+			ceiling:    81000,
+			exceeded:   true,
+		},
+		{
+			// 97.200 = 81.000 + 20%
+			name:       "exatamente no limite da tolerância",
+			revenue:    meiRevenue(1, 8100),
+			firstMonth: 1,
+			ceiling:    81000,
+			exceeded:   true,
+		},
+		{
+			name:              "acima da tolerância",
+			revenue:           meiRevenue(1, 8200),
+			firstMonth:        1,
+			ceiling:           81000,
+			exceeded:          true,
+			toleranceExceeded: true,
+		},
+		{
+			// 6 meses de atividade: 6.750 × 6 = 40.500
+			name:       "abertura em julho tem o limite proporcional",
+			revenue:    meiRevenue(7, 7000),
+			firstMonth: 7,
+			ceiling:    40500,
+			exceeded:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CheckMEICeiling(tt.revenue, tt.firstMonth)
+
+			if result.CeilingCents != tt.ceiling*100 {
+				t.Errorf("limite = %d, esperado %d", result.CeilingCents, tt.ceiling*100)
+			}
+			if result.Exceeded != tt.exceeded {
+				t.Errorf("acima do limite = %v, esperado %v", result.Exceeded, tt.exceeded)
+			}
+			if result.ToleranceExceeded != tt.toleranceExceeded {
+				t.Errorf("acima da tolerância = %v, esperado %v", result.ToleranceExceeded, tt.toleranceExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package calculation
+
+import "sort"
+
+// maxExactSettlementMembers limite de membros com saldo para a busca exata (2^n subconjuntos)
+const maxExactSettlementMembers = 16
+
+// MemberNetBalance saldo líquido de um membro: positivo = tem a receber, negativo = deve
+type MemberNetBalance struct {
+	MemberID uint
+	NetCents int64
+}
+
+// SettlementTransfer transferência sugerida para zerar os saldos
+type SettlementTransfer struct {
+	FromMemberID uint
+	ToMemberID   uint
+	AmountCents  int64
+}
+
+// MinimizeTransfers sugere o menor número de transferências que zera os saldos.
+// Os saldos devem somar zero. O mínimo é (membros com saldo) - (máximo de grupos que somam zero);
+// os grupos são encontrados por busca exata em subconjuntos e quitados dentro de cada grupo.
+// Acima de maxExactSettlementMembers usa só a quitação gulosa (no máximo n-1 transferências).
+func MinimizeTransfers(balances []MemberNetBalance) []SettlementTransfer {
+	nonZero := []MemberNetBalance{}
+	for _, balance := range balances {
+		if balance.NetCents != 0 {
+			nonZero = append(nonZero, balance)
+		}
+	}
+	sort.Slice(nonZero, func(i, j int) bool { return nonZero[i].MemberID < nonZero[j].MemberID })
+
+	if len(nonZero) > maxExactSettlementMembers {
+		return settleGroup(nonZero)
+	}
+
+	transfers := []SettlementTransfer{}
+	for _, group := range zeroSumGroups(nonZero) {
+		transfers = append(transfers, settleGroup(group)...)
+	}
+	return transfers
+}
+
+// zeroSumGroups particiona os saldos no maior número possível de grupos que somam zero
+func zeroSumGroups(balances []MemberNetBalance) [][]MemberNetBalance {
+	n := len(balances)
+	if n == 0 {
+		return nil
+	}
+
+	full := 1<<n - 1
+	sum := make([]int64, full+1)
+	groups := make([]int, full+1) // máximo de grupos de soma zero em que o subconjunto pode ser dividido
+	removed := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		low := 0
+		for mask&(1<<low) == 0 {
+			low++
+		}
+		sum[mask] = sum[mask&^(1<<low)] + balances[low].NetCents
+
+		best, bestIndex := -1, 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && groups[mask&^(1<<i)] > best {
+				best, bestIndex = groups[mask&^(1<<i)], i
+			}
+		}
+		groups[mask] = best
+		if sum[mask] == 0 {
+			groups[mask]++
+		}
+		removed[mask] = bestIndex
+	}
+
+	// Refaz o caminho: cada subconjunto de soma zero no caminho fecha um grupo
+	result := [][]MemberNetBalance{}
+	current := []MemberNetBalance{}
+	for mask := full; mask != 0; {
+		if sum[mask] == 0 && len(current) > 0 {
+			result = append(result, current)
+			current = []MemberNetBalance{}
+		}
+		i := removed[mask]
+		current = append(current, balances[i])
+		mask &^= 1 << i
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// settleGroup quita um grupo que soma zero casando sempre o maior devedor com o maior credor
+func settleGroup(group []MemberNetBalance) []SettlementTransfer {
+	remaining := make([]MemberNetBalance, len(group))
+	copy(remaining, group)
+
+	transfers := []SettlementTransfer{}
+	for {
+		debtor, creditor := -1, -1
+		for i, balance := range remaining {
+			if balance.NetCents < 0 && (debtor < 0 || balance.NetCents < remaining[debtor].NetCents) {
+				debtor = i
+			}
+			if balance.NetCents > 0 && (creditor < 0 || balance.NetCents > remaining[creditor].NetCents) {
+				creditor = i
+			}
+		}
+		if debtor < 0 || creditor < 0 {
+			return transfers
+		}
+
+		amount := -remaining[debtor].NetCents
+		if remaining[creditor].NetCents < amount {
+			amount = remaining[creditor].NetCents
+		}
+		transfers = append(transfers, SettlementTransfer{
+			FromMemberID: remaining[debtor].MemberID,
+			ToMemberID:   remaining[creditor].MemberID,
+			AmountCents:  amount,
+		})
+		remaining[debtor].NetCents += amount
+		remaining[creditor].NetCents -= amount
+	}
+}
@@ -0,0 +1,155 @@
+package calculation
+
+import "testing"
+
+// cycleBalances saldos líquidos de dívidas em ciclo: cada membro deve ao seguinte
+func cycleBalances(debts map[[2]uint]int64) []MemberNetBalance {
+	net := map[uint]int64{}
+	order := []uint{}
+	add := func(memberID uint, cents int64) {
+		if _, ok := net[memberID]; !ok {
+			order = append(order, memberID)
+		}
+		net[memberID] += cents
+	}
+	for pair, cents := range debts {
+		add(pair[0], -cents)
+		add(pair[1], cents)
+	}
+	balances := make([]MemberNetBalance, 0, len(order))
+	for _, memberID := range order {
+		balances = append(balances, MemberNetBalance{MemberID: memberID, NetCents: net[memberID]})
+	}
+	return balances
+}
+
+// manyMembers um credor recebendo de n devedores, ou n pares iguais quando paired
+func manyMembers(n int, paired bool) []MemberNetBalance {
+	balances := []MemberNetBalance{}
+	if paired {
+		for i := 0; i < n; i++ {
+			balances = append(balances,
+				MemberNetBalance{MemberID: uint(2*i + 1), NetCents: 10000},
+				MemberNetBalance{MemberID: uint(2*i + 2), NetCents: -10000})
+		}
+		return balances
+	}
+	balances = append(balances, MemberNetBalance{MemberID: 1, NetCents: int64(n) * 2500})
+	for i := 0; i < n; i++ {
+		balances = append(balances, MemberNetBalance{MemberID: uint(i + 2), NetCents: -2500})
+	}
+	return balances
+}
+
+func TestMinimizeTransfers(t *testing.T) {
+	tests := []struct {
+		name      string
+		balances  []MemberNetBalance
+		transfers int
+	}{
+		{
+			name:      "sem membros",
+			balances:  nil,
+			transfers: 0,
+		},
+		{
+			name: "todos zerados",
+			balances: []MemberNetBalance{
+				{MemberID: 1, NetCents: 0},
+				{MemberID: 2, NetCents: 0},
+				{MemberID: 3, NetCents: 0},
+			},
+			transfers: 0,
+		},
+		{
+			name: "um devedor e um credor",
+			balances: []MemberNetBalance{
+				{MemberID: 1, NetCents: 15000},
+				{MemberID: 2, NetCents: -15000},
+			},
+			transfers: 1,
+		},
+		{
+			name: "pares que somam zero",
+			balances: []MemberNetBalance{
+				{MemberID: 1, NetCents: 10000},
+				{MemberID: 2, NetCents: -4000},
+				{MemberID: 3, NetCents: 4000},
+				{MemberID: 4, NetCents: -25000},
+				{MemberID: 5, NetCents: 25000},
+				{MemberID: 6, NetCents: -10000},
+			},
+			transfers: 3,
+		},
+		{
+			// a quitação gulosa casaria 900 com -700 e faria 5 transferências
+			name: "grupos que a quitação gulosa não encontra",
+			balances: []MemberNetBalance{
+				{MemberID: 1, NetCents: 100},
+				{MemberID: 2, NetCents: 900},
+				{MemberID: 3, NetCents: 600},
+				{MemberID: 4, NetCents: -500},
+				{MemberID: 5, NetCents: -400},
+				{MemberID: 6, NetCents: -700},
+			},
+			transfers: 4,
+		},
+		{
+			name: "ciclo de 3 com valores iguais",
+			balances: cycleBalances(map[[2]uint]int64{
+				{1, 2}: 10000,
+				{2, 3}: 10000,
+				{3, 1}: 10000,
+			}),
+			transfers: 0,
+		},
+		{
+			name: "ciclo de 3 com valores diferentes",
+			balances: cycleBalances(map[[2]uint]int64{
+				{1, 2}: 10000,
+				{2, 3}: 6000,
+				{3, 1}: 3000,
+			}),
+			transfers: 2,
+		},
+		{
+			name:      "mais de 16 membros em pares (gulosa)",
+			balances:  manyMembers(10, true),
+			transfers: 10,
+		},
+		{
+			name:      "mais de 16 membros com um credor (gulosa)",
+			balances:  manyMembers(20, false),
+			transfers: 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfers := MinimizeTransfers(tt.balances)
+			if len(transfers) != tt.transfers {
+				t.Fatalf("transferências = %d, esperado %d: %+v", len(transfers), tt.transfers, transfers)
+			}
+
+			net := map[uint]int64{}
+			for _, balance := range tt.balances {
+				net[balance.MemberID] += balance.NetCents
+			}
+			for _, transfer := range transfers {
+				if transfer.AmountCents <= 0 {
+					t.Errorf("transferência sem valor positivo: %+v", transfer)
+				}
+				if transfer.FromMemberID == transfer.ToMemberID {
+					t.Errorf("transferência para o próprio membro: %+v", transfer)
+				}
+				net[transfer.FromMemberID] += transfer.AmountCents
+				net[transfer.ToMemberID] -= transfer.AmountCents
+			}
+			for memberID, cents := range net {
+				if cents != 0 {
+					t.Errorf("membro %d ficou com saldo %d", memberID, cents)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package calculation
+
+import "math"
+
+// Anexos do Simples Nacional para prestadores de serviço sujeitos ao Fator R
+const (
+	SimplesAnnexIII = "III"
+	SimplesAnnexV   = "V"
+)
+
+// Fator R (folha ÷ receita dos últimos 12 meses) a partir do qual o serviço é tributado no Anexo III
+const fatorRThreshold = 0.28
+
+// simplesBracket faixa do Simples Nacional: receita bruta em 12 meses até LimitCents
+type simplesBracket struct {
+	LimitCents     int64
+	NominalRate    float64 // alíquota nominal (ex: 0.112 = 11,2%)
+	DeductionCents int64   // parcela a deduzir
+}
+
+// Anexos III e V da LC 123/2006 (redação da LC 155/2016, vigentes desde 2018)
+var simplesAnnexes = map[string][]simplesBracket{
+	SimplesAnnexIII: {
+		{18000000, 0.06, 0},
+		{36000000, 0.112, 936000},
+		{72000000, 0.135, 1764000},
+		{180000000, 0.16, 3564000},
+		{360000000, 0.21, 12564000},
+		{480000000, 0.33, 64800000},
+	},
+	SimplesAnnexV: {
+		{18000000, 0.155, 0},
+		{36000000, 0.18, 450000},
+		{72000000, 0.195, 990000},
+		{180000000, 0.205, 1710000},
+		{360000000, 0.23, 6210000},
+		{480000000, 0.305, 54000000},
+	},
+}
+
+// SimplesRevenue faturamento e folha de um mês anterior (em centavos)
+type SimplesRevenue struct {
+	RevenueCents int64
+	PayrollCents int64 // folha do mês: pró-labore, salários e encargos
+}
+
+// SimplesResult cálculo do DAS de um mês
+type SimplesResult struct {
+	Annex         string
+	RBT12Cents    int64   // receita bruta dos 12 meses anteriores (proporcionalizada no início de atividade)
+	FatorR        float64 // folha ÷ receita dos 12 meses anteriores
+	NominalRate   float64 // em % (ex: 11.2)
+	EffectiveRate float64 // em % com 4 casas (ex: 8.5333)
+	TaxCents      int64
+}
+
+// CalculateSimplesNacional calcula o DAS do mês pelos Anexos III/V a partir do histórico dos até 12 meses
+// anteriores. O Fator R escolhe o anexo (≥ 28% = Anexo III) e a alíquota efetiva é
+// (RBT12 × alíquota nominal - parcela a deduzir) ÷ RBT12.
+//
+// Com menos de 12 meses de histórico (início de atividade) o RBT12 é a média dos meses existentes × 12;
+// sem histórico usa o próprio mês × 12, e o Fator R usa a folha e a receita do mês.
+func CalculateSimplesNacional(revenueCents, payrollCents int64, history []SimplesRevenue) SimplesResult {
+	var revenueTotal, payrollTotal int64
+	for _, month := range history {
+		revenueTotal += month.RevenueCents
+		payrollTotal += month.PayrollCents
+	}
+
+	var result SimplesResult
+	if len(history) == 0 {
+		revenueTotal, payrollTotal = revenueCents, payrollCents
+		result.RBT12Cents = revenueCents * 12
+	} else {
+		result.RBT12Cents = revenueTotal * 12 / int64(len(history))
+	}
+
+	if revenueTotal > 0 {
+		result.FatorR = math.Round(float64(payrollTotal)/float64(revenueTotal)*10000) / 10000
+	}
+	result.Annex = SimplesAnnexV
+	if result.FatorR >= fatorRThreshold {
+		result.Annex = SimplesAnnexIII
+	}
+
+	brackets := simplesAnnexes[result.Annex]
+	bracket := brackets[len(brackets)-1]
+	for _, candidate := range brackets {
+		if result.RBT12Cents <= candidate.LimitCents {
+			bracket = candidate
+			break
+		}
+	}
+
+	result.NominalRate = math.Round(bracket.NominalRate*100*10000) / 10000
+	if result.RBT12Cents > 0 {
+		effective := (float64(result.RBT12Cents)*bracket.NominalRate - float64(bracket.DeductionCents)) / float64(result.RBT12Cents)
+		result.EffectiveRate = math.Round(effective*100*10000) / 10000
+	}
+	result.TaxCents = int64(math.Round(float64(revenueCents) * result.EffectiveRate / 100))
+
+	return result
+}
@@ -0,0 +1,185 @@
+package calculation
+
+import (
+	"math"
+	"testing"
+)
+
+// simplesHistory n meses anteriores com o mesmo faturamento e folha (em reais)
+func simplesHistory(n int, revenue, payroll int64) []SimplesRevenue {
+	history := make([]SimplesRevenue, n)
+	for i := range history {
+		history[i] = SimplesRevenue{RevenueCents: revenue * 100, PayrollCents: payroll * 100}
+	}
+	return history
+}
+
+func TestCalculateSimplesNacional(t *testing.T) {
+	tests := []struct {
+		name          string
+		revenue       int64 // faturamento do mês em reais
+		payroll       int64 // folha do mês em reais
+		history       []SimplesRevenue
+		annex         string
+		rbt12         int64 // em reais
+		fatorR        float64
+		nominalRate   float64
+		effectiveRate float64
+		tax           int64 // em centavos
+	}{
+		{
+			name:          "Anexo III primeira faixa",
+			revenue:       10000,
+			payroll:       3000,
+			history:       simplesHistory(12, 10000, 3000),
+			annex:         SimplesAnnexIII,
+			rbt12:         120000,
+			fatorR:        0.3,
+			nominalRate:   6,
+			effectiveRate: 6,
+			tax:           60000,
+		},
+		{
+			// (360.000 × 11,2% - 9.360) ÷ 360.000 = 8,6%
+			name:          "Anexo III com RBT12 de 360 mil e Fator R de exatamente 28%",
+			revenue:       30000,
+			payroll:       8400,
+			history:       simplesHistory(12, 30000, 8400),
+			annex:         SimplesAnnexIII,
+			rbt12:         360000,
+			fatorR:        0.28,
+			nominalRate:   11.2,
+			effectiveRate: 8.6,
+			tax:           258000,
+		},
+		{
+			// (360.000 × 18% - 4.500) ÷ 360.000 = 16,75%
+			name:          "Anexo V com Fator R logo abaixo de 28%",
+			revenue:       30000,
+			payroll:       8397,
+			history:       simplesHistory(12, 30000, 8397),
+			annex:         SimplesAnnexV,
+			rbt12:         360000,
+			fatorR:        0.2799,
+			nominalRate:   18,
+			effectiveRate: 16.75,
+			tax:           502500,
+		},
+		{
+			// (600.000 × 13,5% - 17.640) ÷ 600.000 = 10,56%
+			name:          "Anexo III terceira faixa",
+			revenue:       50000,
+			payroll:       15000,
+			history:       simplesHistory(12, 50000, 15000),
+			annex:         SimplesAnnexIII,
+			rbt12:         600000,
+			fatorR:        0.3,
+			nominalRate:   13.5,
+			effectiveRate: 10.56,
+			tax:           528000,
+		},
+		{
+			name:          "Anexo V primeira faixa sem folha",
+			revenue:       10000,
+			payroll:       0,
+			history:       simplesHistory(12, 10000, 0),
+			annex:         SimplesAnnexV,
+			rbt12:         120000,
+			fatorR:        0,
+			nominalRate:   15.5,
+			effectiveRate: 15.5,
+			tax:           155000,
+		},
+		{
+			// (1.200.000 × 20,5% - 17.100) ÷ 1.200.000 = 19,075%
+			name:          "Anexo V quarta faixa",
+			revenue:       100000,
+			payroll:       10000,
+			history:       simplesHistory(12, 100000, 10000),
+			annex:         SimplesAnnexV,
+			rbt12:         1200000,
+			fatorR:        0.1,
+			nominalRate:   20.5,
+			effectiveRate: 19.075,
+			tax:           1907500,
+		},
+		{
+			// RBT12 = 60.000 ÷ 3 × 12 = 240.000; (240.000 × 11,2% - 9.360) ÷ 240.000 = 7,3%
+			name:          "início de atividade com 3 meses de histórico",
+			revenue:       20000,
+			payroll:       6000,
+			history:       simplesHistory(3, 20000, 6000),
+			annex:         SimplesAnnexIII,
+			rbt12:         240000,
+			fatorR:        0.3,
+			nominalRate:   11.2,
+			effectiveRate: 7.3,
+			tax:           146000,
+		},
+		{
+			name:    "início de atividade com meses diferentes",
+			revenue: 40000,
+			payroll: 0,
+			history: []SimplesRevenue{
+				{RevenueCents: 1000000, PayrollCents: 300000},
+				{RevenueCents: 2000000, PayrollCents: 600000},
+				{RevenueCents: 3000000, PayrollCents: 900000},
+			},
+			annex:         SimplesAnnexIII,
+			rbt12:         240000,
+			fatorR:        0.3,
+			nominalRate:   11.2,
+			effectiveRate: 7.3,
+			tax:           292000,
+		},
+		{
+			name:          "sem histórico usa o próprio mês",
+			revenue:       15000,
+			payroll:       4500,
+			history:       nil,
+			annex:         SimplesAnnexIII,
+			rbt12:         180000,
+			fatorR:        0.3,
+			nominalRate:   6,
+			effectiveRate: 6,
+			tax:           90000,
+		},
+		{
+			name:          "sem faturamento",
+			revenue:       0,
+			payroll:       0,
+			history:       nil,
+			annex:         SimplesAnnexV,
+			rbt12:         0,
+			fatorR:        0,
+			nominalRate:   15.5,
+			effectiveRate: 0,
+			tax:           0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CalculateSimplesNacional(tt.revenue*100, tt.payroll*100, tt.history)
+
+			if result.Annex != tt.annex {
+				t.Errorf("anexo = %s, esperado %s", result.Annex, tt.annex)
+			}
+			if result.RBT12Cents != tt.rbt12*100 {
+				t.Errorf("RBT12 = %d, esperado %d", result.RBT12Cents, tt.rbt12*100)
+			}
+			if math.Abs(result.FatorR-tt.fatorR) > 1e-9 {
+				t.Errorf("Fator R = %v, esperado %v", result.FatorR, tt.fatorR)
+			}
+			if math.Abs(result.NominalRate-tt.nominalRate) > 1e-9 {
+				t.Errorf("alíquota nominal = %v, esperado %v", result.NominalRate, tt.nominalRate)
+			}
+			if math.Abs(result.EffectiveRate-tt.effectiveRate) > 1e-9 {
+				t.Errorf("alíquota efetiva = %v, esperado %v", result.EffectiveRate, tt.effectiveRate)
+			}
+			if result.TaxCents != tt.tax {
+				t.Errorf("DAS = %d, esperado %d", result.TaxCents, tt.tax)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package calculation
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// AllocateCents divide totalCents proporcionalmente aos pesos (método do maior resto).
+// Cada parte recebe o piso da sua fração e os centavos restantes vão, um a um, para as maiores
+// sobras; empates ficam com o menor índice. A soma das partes é sempre exatamente totalCents.
+func AllocateCents(totalCents int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+	if totalCents <= 0 {
+		return parts
+	}
+
+	sumWeights := uint64(0)
+	for _, weight := range weights {
+		if weight > 0 {
+			sumWeights += uint64(weight)
+		}
+	}
+	if sumWeights == 0 {
+		return parts
+	}
+
+	remainders := make([]uint64, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		// total * peso / soma em 128 bits: o quociente nunca passa de total
+		hi, lo := bits.Mul64(uint64(totalCents), uint64(weight))
+		quotient, remainder := bits.Div64(hi, lo, sumWeights)
+		parts[i] = int64(quotient)
+		remainders[i] = remainder
+		allocated += parts[i]
+	}
+
+	order := make([]int, 0, len(weights))
+	for i, weight := range weights {
+		if weight > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+
+	for i := int64(0); i < totalCents-allocated; i++ {
+		parts[order[i]]++
+	}
+	return parts
+}
@@ -0,0 +1,122 @@
+package calculation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAllocateCents(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{
+			name:    "sem pesos",
+			total:   1000,
+			weights: []int64{},
+			want:    []int64{},
+		},
+		{
+			name:    "divisão exata",
+			total:   1000,
+			weights: []int64{1, 3},
+			want:    []int64{250, 750},
+		},
+		{
+			name:    "centavo de sobra para a maior sobra",
+			total:   10,
+			weights: []int64{1, 2},
+			want:    []int64{3, 7},
+		},
+		{
+			name:    "empate fica com o menor índice",
+			total:   100,
+			weights: []int64{1, 1, 1},
+			want:    []int64{34, 33, 33},
+		},
+		{
+			name:    "empate com mais de um centavo",
+			total:   2,
+			weights: []int64{1, 1, 1},
+			want:    []int64{1, 1, 0},
+		},
+		{
+			name:    "percentuais em centésimos",
+			total:   10001,
+			weights: []int64{3333, 3333, 3334},
+			want:    []int64{3333, 3333, 3335},
+		},
+		{
+			name:    "peso zero não recebe",
+			total:   500,
+			weights: []int64{0, 1, 0},
+			want:    []int64{0, 500, 0},
+		},
+		{
+			name:    "peso negativo não recebe",
+			total:   3,
+			weights: []int64{-5, 1, 1},
+			want:    []int64{0, 2, 1},
+		},
+		{
+			name:    "todos os pesos zerados",
+			total:   500,
+			weights: []int64{0, 0},
+			want:    []int64{0, 0},
+		},
+		{
+			name:    "total zero",
+			total:   0,
+			weights: []int64{1, 1},
+			want:    []int64{0, 0},
+		},
+		{
+			name:    "total negativo",
+			total:   -100,
+			weights: []int64{1, 1},
+			want:    []int64{0, 0},
+		},
+		{
+			// total * peso passa de 64 bits
+			name:    "valores grandes",
+			total:   1_000_000_000_000_000,
+			weights: []int64{1_000_000_000_000, 3_000_000_000_000},
+			want:    []int64{250_000_000_000_000, 750_000_000_000_000},
+		},
+		{
+			name:    "total e pesos máximos",
+			total:   math.MaxInt64,
+			weights: []int64{math.MaxInt64, math.MaxInt64},
+			want:    []int64{1 << 62, 1<<62 - 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AllocateCents(tt.total, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("AllocateCents(%d, %v) = %v, esperado %v", tt.total, tt.weights, got, tt.want)
+			}
+
+			if tt.total > 0 {
+				sum := int64(0)
+				positive := false
+				for i, part := range got {
+					sum += part
+					positive = positive || tt.weights[i] > 0
+				}
+				if positive && sum != tt.total {
+					t.Errorf("soma das partes = %d, esperado %d", sum, tt.total)
+				}
+			}
+
+			// a mesma entrada sempre dá o mesmo resultado
+			if again := AllocateCents(tt.total, tt.weights); !reflect.DeepEqual(again, got) {
+				t.Errorf("resultado não determinístico: %v e %v", got, again)
+			}
+		})
+	}
+}
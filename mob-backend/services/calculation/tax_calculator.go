@@ -1,14 +1,36 @@
 package calculation
 
 import (
+	"finance-backend/models"
 	"finance-backend/repositories"
 	"math"
+	"sync"
 )
 
 // TaxCalculator gerencia cálculos de impostos com dados do banco
 type TaxCalculator struct {
 	taxRepo *repositories.TaxRepository
 	year    int
+	
+	loadOnce sync.Once
+	table    taxYearTable
+}
+
+// taxYearTable tabela do ano, lida do banco uma única vez por calculador. Os erros são guardados
+// para que cada cálculo continue caindo no fallback como antes
+type taxYearTable struct {
+	config            *models.TaxConfiguration
+	configErr         error
+	inssBrackets      []models.INSSBracket
+	inssErr           error
+	irpfBrackets      []models.IRPFBracket
+	irpfErr           error
+	irpfReducers      []models.IRPFReducer
+	irpfReducersErr   error
+	annualReducers    []models.IRPFReducer
+	annualReducersErr error
+	annualBrackets    []models.IRPFBracket
+	annualBracketsErr error
 }
 
 // NewTaxCalculator cria novo calculador de impostos
@@ -27,9 +49,74 @@ func NewTaxCalculatorForYear(taxRepo *repositories.TaxRepository, year int) *Tax
 	}
 }
 
+// NewTaxCalculatorForReferenceYear cria calculador com a tabela vigente no ano de referência: a do próprio
+// ano ou, se ainda não cadastrada, a do ano anterior mais recente. Sem tabelas no banco usa o fallback.
+func NewTaxCalculatorForReferenceYear(taxRepo *repositories.TaxRepository, referenceYear int) *TaxCalculator {
+	year, err := taxRepo.GetLatestYearUpTo(referenceYear)
+	if err != nil || year == 0 {
+		year = FallbackTaxYear
+	}
+	return NewTaxCalculatorForYear(taxRepo, year)
+}
+
+// Year retorna o ano da tabela de impostos usada pelo calculador
+func (tc *TaxCalculator) Year() int {
+	return tc.year
+}
+
+// loadTable lê a tabela do ano na primeira vez que um cálculo precisa dela
+func (tc *TaxCalculator) loadTable() *taxYearTable {
+	tc.loadOnce.Do(func() {
+		table := &tc.table
+		table.config, table.configErr = tc.taxRepo.GetTaxConfiguration(tc.year)
+		table.inssBrackets, table.inssErr = tc.taxRepo.GetINSSBrackets(tc.year)
+		table.irpfBrackets, table.irpfErr = tc.taxRepo.GetIRPFBrackets(tc.year)
+		table.irpfReducers, table.irpfReducersErr = tc.taxRepo.GetIRPFReducers(tc.year)
+		table.annualReducers, table.annualReducersErr = tc.taxRepo.GetIRPFAnnualReducers(tc.year)
+		table.annualBrackets, table.annualBracketsErr = tc.taxRepo.GetIRPFAnnualBrackets(tc.year)
+	})
+	return &tc.table
+}
+
+// taxConfiguration a configuração do ano (carregada uma vez)
+func (tc *TaxCalculator) taxConfiguration() (*models.TaxConfiguration, error) {
+	table := tc.loadTable()
+	return table.config, table.configErr
+}
+
+// inssBrackets as faixas do INSS do ano (carregada uma vez)
+func (tc *TaxCalculator) inssBrackets() ([]models.INSSBracket, error) {
+	table := tc.loadTable()
+	return table.inssBrackets, table.inssErr
+}
+
+// irpfBrackets as faixas mensais do IRPF do ano (carregada uma vez)
+func (tc *TaxCalculator) irpfBrackets() ([]models.IRPFBracket, error) {
+	table := tc.loadTable()
+	return table.irpfBrackets, table.irpfErr
+}
+
+// irpfReducers as reduções mensais do IRPF do ano (carregada uma vez)
+func (tc *TaxCalculator) irpfReducers() ([]models.IRPFReducer, error) {
+	table := tc.loadTable()
+	return table.irpfReducers, table.irpfReducersErr
+}
+
+// irpfAnnualReducers as reduções anuais do IRPF do ano (carregada uma vez)
+func (tc *TaxCalculator) irpfAnnualReducers() ([]models.IRPFReducer, error) {
+	table := tc.loadTable()
+	return table.annualReducers, table.annualReducersErr
+}
+
+// irpfAnnualBrackets as faixas da tabela anual do IRPF do ano (carregada uma vez)
+func (tc *TaxCalculator) irpfAnnualBrackets() ([]models.IRPFBracket, error) {
+	table := tc.loadTable()
+	return table.annualBrackets, table.annualBracketsErr
+}
+
 // CalculateINSS calcula o INSS progressivo (em centavos)
 func (tc *TaxCalculator) CalculateINSS(grossMonthlyCents int64) int64 {
-	brackets, err := tc.taxRepo.GetINSSBrackets(tc.year)
+	brackets, err := tc.inssBrackets()
 	if err != nil || len(brackets) == 0 {
 		// Fallback: usar cálculo padrão se banco falhar
 		return calculateINSSFallback(grossMonthlyCents)
@@ -61,7 +148,7 @@ func (tc *TaxCalculator) CalculateINSS(grossMonthlyCents int64) int64 {
 
 // CalculateFGTS calcula o FGTS (8% - informativo, não desconta do salário)
 func (tc *TaxCalculator) CalculateFGTS(grossMonthlyCents int64) int64 {
-	config, err := tc.taxRepo.GetTaxConfiguration(tc.year)
+	config, err := tc.taxConfiguration()
 	if err != nil {
 		return int64(float64(grossMonthlyCents) * 0.08) // Fallback 8%
 	}
@@ -70,24 +157,43 @@ func (tc *TaxCalculator) CalculateFGTS(grossMonthlyCents int64) int64 {
 
 // CalculateIRPF calcula o Imposto de Renda (em centavos)
 func (tc *TaxCalculator) CalculateIRPF(grossMonthlyCents, inssCents int64, dependents int) int64 {
-	config, err := tc.taxRepo.GetTaxConfiguration(tc.year)
+	return tc.CalculateIRPFDetailed(grossMonthlyCents, inssCents, dependents).TaxCents
+}
+
+// IRPFResult detalhamento do IRPF mensal (valores em centavos)
+type IRPFResult struct {
+	TaxCents       int64 // imposto devido após a redução
+	ReductionCents int64 // redução mensal aplicada (ex: Lei 15.270/2025)
+	Simplified     bool  // true = desconto simplificado no lugar das deduções legais
+}
+
+// CalculateIRPFDetailed calcula o IRPF mensal: usa o desconto simplificado do ano quando ele supera as
+// deduções legais (INSS + dependentes), aplica a tabela progressiva e, por fim, a redução do ano sobre
+// os rendimentos tributáveis (bruto), limitada ao imposto
+func (tc *TaxCalculator) CalculateIRPFDetailed(grossMonthlyCents, inssCents int64, dependents int) IRPFResult {
+	config, err := tc.taxConfiguration()
 	if err != nil {
-		return calculateIRPFFallback(grossMonthlyCents, inssCents, dependents)
+		return IRPFResult{TaxCents: calculateIRPFFallback(grossMonthlyCents, inssCents, dependents)}
 	}
 	
-	brackets, err := tc.taxRepo.GetIRPFBrackets(tc.year)
+	brackets, err := tc.irpfBrackets()
 	if err != nil || len(brackets) == 0 {
-		return calculateIRPFFallback(grossMonthlyCents, inssCents, dependents)
+		return IRPFResult{TaxCents: calculateIRPFFallback(grossMonthlyCents, inssCents, dependents)}
 	}
 	
 	grossMonthly := float64(grossMonthlyCents) / 100.0
 	inss := float64(inssCents) / 100.0
 	
-	// Base de cálculo = Bruto - INSS - Dependentes
+	// Base de cálculo = Bruto - INSS - Dependentes, ou Bruto - desconto simplificado se for menor
+	var result IRPFResult
 	taxableBase := grossMonthly - inss - (float64(dependents) * config.INSSDeductionPerDependent)
+	if config.IRPFSimplifiedDiscount > 0 && grossMonthly-config.IRPFSimplifiedDiscount < taxableBase {
+		taxableBase = grossMonthly - config.IRPFSimplifiedDiscount
+		result.Simplified = true
+	}
 	
 	if taxableBase <= 0 {
-		return 0
+		return result
 	}
 	
 	var irpf float64
@@ -103,11 +209,46 @@ func (tc *TaxCalculator) CalculateIRPF(grossMonthlyCents, inssCents int64, depen
 		}
 	}
 	
-	if irpf < 0 {
-		return 0
+	if irpf <= 0 {
+		return result
 	}
 	
-	return int64(math.Round(irpf * 100))
+	taxCents := int64(math.Round(irpf * 100))
+	result.ReductionCents = tc.irpfReductionCents(grossMonthly, taxCents)
+	result.TaxCents = taxCents - result.ReductionCents
+	return result
+}
+
+// irpfReductionCents calcula a redução do IRPF do ano para os rendimentos tributáveis do mês
+func (tc *TaxCalculator) irpfReductionCents(grossMonthly float64, taxCents int64) int64 {
+	reducers, err := tc.irpfReducers()
+	if err != nil {
+		return 0
+	}
+	return reductionCents(reducers, grossMonthly, taxCents)
+}
+
+// reductionCents aplica a faixa de redução dos rendimentos: FixedAmount - Rate × rendimentos, nunca
+// negativa nem maior que o imposto
+func reductionCents(reducers []models.IRPFReducer, income float64, taxCents int64) int64 {
+	incomeCents := int64(math.Round(income * 100))
+	for _, reducer := range reducers {
+		fromCents := int64(math.Round(reducer.MinValue * 100))
+		toCents := int64(math.Round(reducer.MaxValue * 100))
+		if incomeCents < fromCents || (reducer.MaxValue > 0 && reducer.MaxValue <= 999999999 && incomeCents > toCents) {
+			continue
+		}
+		
+		reductionCents := int64(math.Round((reducer.FixedAmount - reducer.Rate*income) * 100))
+		if reductionCents <= 0 {
+			return 0
+		}
+		if reductionCents > taxCents {
+			return taxCents
+		}
+		return reductionCents
+	}
+	return 0
 }
 
 // CalculateCLTNet calcula o valor líquido para CLT
@@ -126,18 +267,40 @@ func (tc *TaxCalculator) CalculateSimplesTax(grossMonthlyCents int64, rate float
 	return int64(float64(grossMonthlyCents) * (rate / 100.0))
 }
 
+// PJResult renda do mês de uma empresa do membro (valores em centavos): a empresa fatura, paga os seus
+// tributos e o pró-labore (retendo INSS e IRPF do sócio) e distribui o restante como lucro isento
+type PJResult struct {
+	CompanyTaxCents         int64 // DAS do Simples Nacional ou tributos do Lucro Presumido
+	ProLaboreINSSCents      int64 // INSS retido do sócio (11% até o teto)
+	ProLaboreIRPFCents      int64 // IRPF retido sobre o pró-labore
+	ProfitDistributionCents int64 // faturamento - tributos da empresa - pró-labore
+	NetCents                int64 // pró-labore líquido + lucros distribuídos + benefícios
+}
+
 // CalculatePJNet calcula o valor líquido para PJ
-func (tc *TaxCalculator) CalculatePJNet(grossMonthlyCents int64, simplesRate float64, proLaboreCents, benefitsCents int64) (netCents, simplesTaxCents int64) {
-	simplesTaxCents = tc.CalculateSimplesTax(grossMonthlyCents, simplesRate)
-	netCents = grossMonthlyCents - simplesTaxCents - proLaboreCents + benefitsCents
+func (tc *TaxCalculator) CalculatePJNet(grossMonthlyCents int64, simplesRate float64, proLaboreCents, benefitsCents int64, dependents int) PJResult {
+	simplesTaxCents := tc.CalculateSimplesTax(grossMonthlyCents, simplesRate)
+	return tc.companyNet(grossMonthlyCents, simplesTaxCents, proLaboreCents, benefitsCents, dependents)
+}
+
+func (tc *TaxCalculator) companyNet(grossMonthlyCents, companyTaxCents, proLaboreCents, benefitsCents int64, dependents int) PJResult {
+	result := PJResult{CompanyTaxCents: companyTaxCents}
+	result.ProLaboreINSSCents, result.ProLaboreIRPFCents = tc.CalculateProLaboreTaxes(proLaboreCents, dependents)
+	result.ProfitDistributionCents = grossMonthlyCents - companyTaxCents - proLaboreCents
 	
-	return
+	proLaboreNet := proLaboreCents - result.ProLaboreINSSCents - result.ProLaboreIRPFCents
+	result.NetCents = proLaboreNet + result.ProfitDistributionCents + benefitsCents
+	
+	return result
 }
 
 // ============================================================
 // FALLBACK FUNCTIONS (caso banco esteja indisponível)
 // ============================================================
 
+// FallbackTaxYear ano das tabelas hardcoded usadas quando o banco não tem tabelas
+const FallbackTaxYear = 2025
+
 func calculateINSSFallback(grossMonthlyCents int64) int64 {
 	// Valores hardcoded de 2025 como fallback
 	grossMonthly := float64(grossMonthlyCents) / 100.0
@@ -242,7 +405,9 @@ func CalculateSimplesTax(grossMonthlyCents int64, rate float64) int64 {
 // CalculatePJNet função legada para compatibilidade
 func CalculatePJNet(grossMonthlyCents int64, simplesRate float64, proLaboreCents, benefitsCents int64) (netCents, simplesTaxCents int64) {
 	simplesTaxCents = CalculateSimplesTax(grossMonthlyCents, simplesRate)
-	netCents = grossMonthlyCents - simplesTaxCents - proLaboreCents + benefitsCents
+	inssCents := int64(math.Round(float64(min(proLaboreCents, inssCeilingFallbackCents)) * individualINSSRate))
+	irpfCents := CalculateIRPF(proLaboreCents, inssCents, 0)
+	netCents = grossMonthlyCents - simplesTaxCents - inssCents - irpfCents + benefitsCents
 	
 	return
 }
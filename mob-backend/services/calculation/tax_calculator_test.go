@@ -0,0 +1,161 @@
+package calculation
+
+import (
+	"testing"
+
+	"finance-backend/models"
+)
+
+// calculator2026 calculador com as tabelas oficiais de 2026, sem acesso ao banco
+func calculator2026() *TaxCalculator {
+	tc := &TaxCalculator{year: 2026}
+	tc.table = taxYearTable{
+		config: &models.TaxConfiguration{
+			Year:                      2026,
+			INSSDeductionPerDependent: 189.59,
+			FGTSRate:                  0.08,
+			IRPFSimplifiedDiscount:    607.20,
+			IRPFAnnualSimplifiedCap:   16754.34,
+			IRPFEducationCap:          3561.50,
+		},
+		inssBrackets: []models.INSSBracket{
+			{MinValue: 0, MaxValue: 1621.00, Rate: 0.075, Order: 1},
+			{MinValue: 1621.01, MaxValue: 2902.84, Rate: 0.09, Order: 2},
+			{MinValue: 2902.85, MaxValue: 4354.27, Rate: 0.12, Order: 3},
+			{MinValue: 4354.28, MaxValue: 8475.55, Rate: 0.14, Order: 4},
+		},
+		irpfBrackets: []models.IRPFBracket{
+			{MinValue: 0, MaxValue: 2428.80, Rate: 0, Deduction: 0, Order: 1},
+			{MinValue: 2428.81, MaxValue: 2826.65, Rate: 0.075, Deduction: 182.16, Order: 2},
+			{MinValue: 2826.66, MaxValue: 3751.05, Rate: 0.15, Deduction: 394.16, Order: 3},
+			{MinValue: 3751.06, MaxValue: 4664.68, Rate: 0.225, Deduction: 675.49, Order: 4},
+			{MinValue: 4664.69, MaxValue: 999999999.99, Rate: 0.275, Deduction: 908.73, Order: 5},
+		},
+		irpfReducers: []models.IRPFReducer{
+			{MinValue: 0, MaxValue: 5000.00, FixedAmount: 312.89, Rate: 0, Order: 1},
+			{MinValue: 5000.01, MaxValue: 7350.00, FixedAmount: 978.62, Rate: 0.133145, Order: 2},
+		},
+		annualReducers: []models.IRPFReducer{
+			{MinValue: 0, MaxValue: 60000.00, FixedAmount: 2694.15, Rate: 0, Order: 1, Annual: true},
+			{MinValue: 60000.01, MaxValue: 88200.00, FixedAmount: 8429.73, Rate: 0.095575, Order: 2, Annual: true},
+		},
+		// A tabela mensal não muda em 2026: a anual é a mensal × 12
+		annualBrackets: []models.IRPFBracket{
+			{MinValue: 0, MaxValue: 29145.60, Rate: 0, Deduction: 0, Order: 1, Annual: true},
+			{MinValue: 29145.61, MaxValue: 33919.80, Rate: 0.075, Deduction: 2185.92, Order: 2, Annual: true},
+			{MinValue: 33919.81, MaxValue: 45012.60, Rate: 0.15, Deduction: 4729.92, Order: 3, Annual: true},
+			{MinValue: 45012.61, MaxValue: 55976.16, Rate: 0.225, Deduction: 8105.88, Order: 4, Annual: true},
+			{MinValue: 55976.17, MaxValue: 999999999.99, Rate: 0.275, Deduction: 10904.76, Order: 5, Annual: true},
+		},
+	}
+	// Tabela já preenchida: loadTable não consulta o repositório
+	tc.loadOnce.Do(func() {})
+	return tc
+}
+
+func TestCalculateIRPFDetailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		gross      int64 // em centavos
+		dependents int
+		inss       int64 // em centavos
+		tax        int64 // em centavos, já com a redução
+		reduction  int64 // em centavos
+		simplified bool
+	}{
+		{
+			// (5.000 - 607,20) × 22,5% - 675,49 = 312,89: a redução zera o imposto
+			name:       "R$ 5.000 zera o imposto",
+			gross:      500000,
+			inss:       50151,
+			tax:        0,
+			reduction:  31289,
+			simplified: true,
+		},
+		{
+			// 978,62 - 0,133145 × 5.000,01 = 312,89
+			name:       "R$ 5.000,01 entra na faixa decrescente",
+			gross:      500001,
+			inss:       50152,
+			tax:        0,
+			reduction:  31289,
+			simplified: true,
+		},
+		{
+			// 978,62 - 0,133145 × 6.000 = 179,75
+			name:       "R$ 6.000 com redução parcial",
+			gross:      600000,
+			inss:       64151,
+			tax:        38510,
+			reduction:  17975,
+			simplified: false,
+		},
+		{
+			name:       "R$ 7.000 perto do fim da faixa",
+			gross:      700000,
+			inss:       78151,
+			tax:        75475,
+			reduction:  4660,
+			simplified: false,
+		},
+		{
+			// 978,62 - 0,133145 × 7.350 = 0,004
+			name:       "R$ 7.350 no fim da faixa",
+			gross:      735000,
+			inss:       83051,
+			tax:        88413,
+			reduction:  0,
+			simplified: false,
+		},
+		{
+			name:       "R$ 7.350,01 acima da faixa",
+			gross:      735001,
+			inss:       83052,
+			tax:        88413,
+			reduction:  0,
+			simplified: false,
+		},
+		{
+			name:       "R$ 10.000 acima do teto do INSS",
+			gross:      1000000,
+			inss:       98809,
+			tax:        156955,
+			reduction:  0,
+			simplified: false,
+		},
+		{
+			name:       "dependentes abaixo do desconto simplificado",
+			gross:      600000,
+			dependents: 2,
+			inss:       0,
+			tax:        39454,
+			reduction:  17975,
+			simplified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := calculator2026()
+
+			inss := tt.inss
+			if tt.dependents == 0 {
+				inss = tc.CalculateINSS(tt.gross)
+				if inss != tt.inss {
+					t.Errorf("INSS = %d, esperado %d", inss, tt.inss)
+				}
+			}
+
+			result := tc.CalculateIRPFDetailed(tt.gross, inss, tt.dependents)
+			if result.TaxCents != tt.tax {
+				t.Errorf("IRPF = %d, esperado %d", result.TaxCents, tt.tax)
+			}
+			if result.ReductionCents != tt.reduction {
+				t.Errorf("redução = %d, esperado %d", result.ReductionCents, tt.reduction)
+			}
+			if result.Simplified != tt.simplified {
+				t.Errorf("simplificado = %v, esperado %v", result.Simplified, tt.simplified)
+			}
+		})
+	}
+}
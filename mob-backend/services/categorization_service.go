@@ -0,0 +1,250 @@
+package services
+
+import (
+	"errors"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+var ErrRuleNotFound = errors.New("regra não encontrada")
+
+type CategorizationService struct {
+	ruleRepo       *repositories.CategorizationRuleRepository
+	categoryRepo   *repositories.ExpenseCategoryRepository
+	expenseRepo    *repositories.ExpenseRepository
+	expenseService *ExpenseService
+}
+
+func NewCategorizationService(
+	ruleRepo *repositories.CategorizationRuleRepository,
+	categoryRepo *repositories.ExpenseCategoryRepository,
+	expenseRepo *repositories.ExpenseRepository,
+	expenseService *ExpenseService,
+) *CategorizationService {
+	return &CategorizationService{
+		ruleRepo:       ruleRepo,
+		categoryRepo:   categoryRepo,
+		expenseRepo:    expenseRepo,
+		expenseService: expenseService,
+	}
+}
+
+// RuleInput dados de uma regra de categorização
+type RuleInput struct {
+	Name           string              `json:"name"`
+	MatchField     string              `json:"match_field"` // name, description ou any (padrão)
+	MatchType      string              `json:"match_type"`  // contains (padrão) ou regex
+	Pattern        string              `json:"pattern"`
+	MinAmountCents *int64              `json:"min_amount_cents"`
+	MaxAmountCents *int64              `json:"max_amount_cents"`
+	CategoryID     uint                `json:"category_id"`
+	IsFixed        *bool               `json:"is_fixed"`
+	Priority       int                 `json:"priority"` // maior = avaliada primeiro
+	Splits         []ExpenseSplitInput `json:"splits"`   // modelo de divisão (opcional)
+}
+
+// buildRule valida a entrada e monta a regra (sem persistir)
+func (s *CategorizationService) buildRule(familyID uint, input RuleInput) (*models.CategorizationRule, error) {
+	rule := &models.CategorizationRule{
+		FamilyAccountID: familyID,
+		Name:            input.Name,
+		MatchField:      models.RuleMatchField(input.MatchField),
+		MatchType:       models.RuleMatchType(input.MatchType),
+		Pattern:         input.Pattern,
+		MinAmountCents:  input.MinAmountCents,
+		MaxAmountCents:  input.MaxAmountCents,
+		CategoryID:      input.CategoryID,
+		IsFixed:         input.IsFixed,
+		Priority:        input.Priority,
+		IsActive:        true,
+	}
+	if rule.MatchField == "" {
+		rule.MatchField = models.RuleMatchAny
+	}
+	if rule.MatchType == "" {
+		rule.MatchType = models.RuleMatchContains
+	}
+	if rule.Name == "" {
+		rule.Name = input.Pattern
+	}
+
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateRequiredString(rule.Pattern, "pattern"))
+	validator.Add(utils.ValidateRange(rule.Priority, -1000, 1000, "priority"))
+
+	switch rule.MatchField {
+	case models.RuleMatchName, models.RuleMatchDescription, models.RuleMatchAny:
+	default:
+		validator.AddError(utils.ValidationError{Field: "match_field", Message: "deve ser name, description ou any"})
+	}
+
+	switch rule.MatchType {
+	case models.RuleMatchContains, models.RuleMatchRegex:
+		if _, err := rule.CompilePattern(); err != nil {
+			validator.AddError(utils.ValidationError{Field: "pattern", Message: "expressão regular inválida"})
+		}
+	default:
+		validator.AddError(utils.ValidationError{Field: "match_type", Message: "deve ser contains ou regex"})
+	}
+
+	if rule.MinAmountCents != nil {
+		validator.Add(utils.ValidateNonNegativeAmount(*rule.MinAmountCents, "min_amount_cents"))
+	}
+	if rule.MaxAmountCents != nil {
+		validator.Add(utils.ValidateNonNegativeAmount(*rule.MaxAmountCents, "max_amount_cents"))
+	}
+	if rule.MinAmountCents != nil && rule.MaxAmountCents != nil && *rule.MinAmountCents > *rule.MaxAmountCents {
+		validator.AddError(utils.ValidationError{Field: "max_amount_cents", Message: "deve ser maior ou igual ao valor mínimo"})
+	}
+
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+
+	category, err := s.categoryRepo.GetByIDForFamily(input.CategoryID, familyID)
+	if err != nil {
+		return nil, errors.New("categoria não encontrada")
+	}
+	rule.Category = *category
+
+	if len(input.Splits) > 0 {
+		if err := s.expenseService.ValidateSplits(familyID, input.Splits); err != nil {
+			return nil, err
+		}
+		for _, split := range input.Splits {
+			rule.Splits = append(rule.Splits, models.CategorizationRuleSplit{
+				FamilyMemberID: split.FamilyMemberID,
+				Percentage:     split.Percentage,
+			})
+		}
+	}
+
+	return rule, nil
+}
+
+// CreateRule cria uma regra de categorização
+func (s *CategorizationService) CreateRule(familyID uint, input RuleInput) (*models.CategorizationRule, error) {
+	rule, err := s.buildRule(familyID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetRules lista as regras ativas da família em ordem de avaliação
+func (s *CategorizationService) GetRules(familyID uint) ([]models.CategorizationRule, error) {
+	return s.ruleRepo.GetActiveByFamilyID(familyID)
+}
+
+// GetRule busca uma regra ativa da família
+func (s *CategorizationService) GetRule(familyID, ruleID uint) (*models.CategorizationRule, error) {
+	rule, err := s.ruleRepo.GetByID(ruleID)
+	if err != nil || rule.FamilyAccountID != familyID || !rule.IsActive {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// UpdateRule substitui os dados de uma regra
+func (s *CategorizationService) UpdateRule(familyID, ruleID uint, input RuleInput) (*models.CategorizationRule, error) {
+	existing, err := s.GetRule(familyID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.buildRule(familyID, input)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = existing.ID
+	rule.CreatedAt = existing.CreatedAt
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteRule desativa uma regra
+func (s *CategorizationService) DeleteRule(familyID, ruleID uint) error {
+	if _, err := s.GetRule(familyID, ruleID); err != nil {
+		return err
+	}
+	return s.ruleRepo.Delete(ruleID)
+}
+
+// RulePreviewItem despesa existente que a regra alteraria
+type RulePreviewItem struct {
+	ExpenseID           uint    `json:"expense_id"`
+	Name                string  `json:"name"`
+	Description         string  `json:"description"`
+	Amount              float64 `json:"amount"`
+	ReferenceMonth      int     `json:"reference_month"`
+	ReferenceYear       int     `json:"reference_year"`
+	CurrentCategoryID   uint    `json:"current_category_id"`
+	CurrentCategoryName string  `json:"current_category_name"`
+	NewCategoryID       uint    `json:"new_category_id"`
+	NewCategoryName     string  `json:"new_category_name"`
+	CurrentIsFixed      bool    `json:"current_is_fixed"`
+	NewIsFixed          bool    `json:"new_is_fixed"`
+}
+
+// RulePreview resultado da simulação de uma regra sobre as despesas existentes
+type RulePreview struct {
+	Rule         models.CategorizationRule `json:"rule"`
+	MatchedCount int                       `json:"matched_count"` // despesas que casam com a regra
+	ChangedCount int                       `json:"changed_count"` // despesas cuja categoria ou is_fixed mudaria
+	Changes      []RulePreviewItem         `json:"changes"`
+}
+
+// PreviewRule mostra quais despesas ativas da família a regra alteraria (sem gravar nada)
+func (s *CategorizationService) PreviewRule(familyID uint, input RuleInput) (*RulePreview, error) {
+	rule, err := s.buildRule(familyID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.expenseRepo.GetByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &RulePreview{Rule: *rule, Changes: []RulePreviewItem{}}
+	for _, expense := range expenses {
+		if !rule.Matches(expense.Name, expense.Description, expense.AmountCents) {
+			continue
+		}
+		preview.MatchedCount++
+
+		newIsFixed := expense.IsFixed
+		if rule.IsFixed != nil {
+			newIsFixed = *rule.IsFixed
+		}
+		if expense.CategoryID == rule.CategoryID && expense.IsFixed == newIsFixed {
+			continue
+		}
+
+		preview.Changes = append(preview.Changes, RulePreviewItem{
+			ExpenseID:           expense.ID,
+			Name:                expense.Name,
+			Description:         expense.Description,
+			Amount:              utils.CentsToFloat(expense.AmountCents),
+			ReferenceMonth:      expense.ReferenceMonth,
+			ReferenceYear:       expense.ReferenceYear,
+			CurrentCategoryID:   expense.CategoryID,
+			CurrentCategoryName: expense.Category.Name,
+			NewCategoryID:       rule.CategoryID,
+			NewCategoryName:     rule.Category.Name,
+			CurrentIsFixed:      expense.IsFixed,
+			NewIsFixed:          newIsFixed,
+		})
+	}
+	preview.ChangedCount = len(preview.Changes)
+
+	return preview, nil
+}
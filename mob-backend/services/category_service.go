@@ -0,0 +1,231 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+var (
+	ErrCategoryNotFound        = errors.New("categoria não encontrada")
+	ErrDefaultCategoryReadOnly = errors.New("categorias padrão não podem ser alteradas ou excluídas")
+	ErrCategoryNameTaken       = errors.New("já existe uma categoria com este nome")
+	ErrCategoryInUse           = errors.New("categoria em uso; informe reassign_to para mover os lançamentos")
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type CategoryService struct {
+	categoryRepo *repositories.ExpenseCategoryRepository
+}
+
+func NewCategoryService(categoryRepo *repositories.ExpenseCategoryRepository) *CategoryService {
+	return &CategoryService{categoryRepo: categoryRepo}
+}
+
+// CategoryInput dados de uma categoria da família
+type CategoryInput struct {
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Color    string `json:"color"`     // #RRGGBB
+	ParentID *uint  `json:"parent_id"` // categoria pai (padrão ou da família) para criar subcategoria
+
+	// Dedução do IRPF das despesas (saude, educacao, previdencia_privada, pensao_alimenticia); vazio herda da pai
+	IRPFDeduction string `json:"irpf_deduction"`
+}
+
+// GetCategories lista as categorias visíveis para a família (padrão + da família)
+func (s *CategoryService) GetCategories(familyID uint) ([]models.ExpenseCategory, error) {
+	return s.categoryRepo.GetByFamilyID(familyID)
+}
+
+// GetCategoryTree lista as categorias de primeiro nível com suas subcategorias
+func (s *CategoryService) GetCategoryTree(familyID uint) ([]models.ExpenseCategory, error) {
+	categories, err := s.categoryRepo.GetByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]models.ExpenseCategory)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	tree := []models.ExpenseCategory{}
+	for _, category := range categories {
+		if category.ParentID == nil {
+			category.Subcategories = children[category.ID]
+			tree = append(tree, category)
+		}
+	}
+	return tree, nil
+}
+
+// GetCategory busca uma categoria visível para a família
+func (s *CategoryService) GetCategory(familyID, categoryID uint) (*models.ExpenseCategory, error) {
+	category, err := s.categoryRepo.GetByIDForFamily(categoryID, familyID)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+// CreateCategory cria uma categoria (ou subcategoria) da família
+func (s *CategoryService) CreateCategory(familyID uint, input CategoryInput) (*models.ExpenseCategory, error) {
+	category := &models.ExpenseCategory{
+		FamilyAccountID: &familyID,
+		IsActive:        true,
+	}
+	if err := s.applyInput(familyID, category, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.Create(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory atualiza uma categoria da família
+func (s *CategoryService) UpdateCategory(familyID, categoryID uint, input CategoryInput) (*models.ExpenseCategory, error) {
+	category, err := s.getOwnCategory(familyID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		children, err := s.categoryRepo.GetChildren(category.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(children) > 0 {
+			return nil, utils.ValidationErrors{{
+				Field:   "parent_id",
+				Message: "categoria com subcategorias não pode virar subcategoria",
+			}}
+		}
+	}
+
+	if err := s.applyInput(familyID, category, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// applyInput valida a entrada e aplica na categoria
+func (s *CategoryService) applyInput(familyID uint, category *models.ExpenseCategory, input CategoryInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateRequiredString(input.Name, "name"))
+	if input.Color != "" && !hexColorPattern.MatchString(input.Color) {
+		validator.AddError(utils.ValidationError{Field: "color", Message: "deve estar no formato #RRGGBB"})
+	}
+	validator.Add(utils.ValidateIRPFDeduction(input.IRPFDeduction))
+	if input.ParentID != nil && *input.ParentID == category.ID && category.ID != 0 {
+		validator.AddError(utils.ValidationError{Field: "parent_id", Message: "categoria não pode ser pai de si mesma"})
+	}
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+
+	if input.ParentID != nil {
+		parent, err := s.categoryRepo.GetByIDForFamily(*input.ParentID, familyID)
+		if err != nil {
+			return utils.ValidationErrors{{Field: "parent_id", Message: "categoria pai não encontrada"}}
+		}
+		if parent.ParentID != nil {
+			return utils.ValidationErrors{{Field: "parent_id", Message: "subcategorias não podem ter subcategorias"}}
+		}
+	}
+
+	exists, err := s.categoryRepo.ExistsByName(familyID, input.ParentID, input.Name, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCategoryNameTaken
+	}
+
+	category.Name = input.Name
+	category.Icon = input.Icon
+	category.Color = input.Color
+	category.ParentID = input.ParentID
+	category.IRPFDeduction = models.IRPFDeduction(input.IRPFDeduction)
+	return nil
+}
+
+// CategoryDeletion resultado da exclusão de uma categoria
+type CategoryDeletion struct {
+	ReassignedCount       int64 `json:"reassigned_count"`        // despesas, compras e regras movidas
+	DiscardedBudgetsCount int64 `json:"discarded_budgets_count"` // orçamentos mensais excluídos com a categoria
+}
+
+// DeleteCategory exclui uma categoria da família e suas subcategorias.
+// Se houver despesas, compras ou regras usando-as, reassignTo (padrão ou da família) é obrigatório.
+// Os orçamentos das categorias excluídas não são movidos: são descartados e contados no resultado.
+func (s *CategoryService) DeleteCategory(familyID, categoryID, reassignTo uint) (*CategoryDeletion, error) {
+	category, err := s.getOwnCategory(familyID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []uint{category.ID}
+	children, err := s.categoryRepo.GetChildren(category.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		ids = append(ids, child.ID)
+	}
+
+	usage, err := s.categoryRepo.CountUsage(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if reassignTo != 0 {
+		for _, id := range ids {
+			if id == reassignTo {
+				return nil, utils.ValidationErrors{{
+					Field:   "reassign_to",
+					Message: "não pode ser a própria categoria ou uma de suas subcategorias",
+				}}
+			}
+		}
+		if _, err := s.categoryRepo.GetByIDForFamily(reassignTo, familyID); err != nil {
+			return nil, utils.ValidationErrors{{Field: "reassign_to", Message: "categoria de destino não encontrada"}}
+		}
+	} else if usage > 0 {
+		return nil, fmt.Errorf("%w (%d lançamentos)", ErrCategoryInUse, usage)
+	}
+
+	discardedBudgets, err := s.categoryRepo.ReassignAndDeactivate(ids, reassignTo)
+	if err != nil {
+		return nil, err
+	}
+	return &CategoryDeletion{ReassignedCount: usage, DiscardedBudgetsCount: discardedBudgets}, nil
+}
+
+// getOwnCategory busca uma categoria que pertence à família (categorias padrão são somente leitura)
+func (s *CategoryService) getOwnCategory(familyID, categoryID uint) (*models.ExpenseCategory, error) {
+	category, err := s.categoryRepo.GetByIDForFamily(categoryID, familyID)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	if !category.IsCustom() {
+		return nil, ErrDefaultCategoryReadOnly
+	}
+	return category, nil
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+// Status da fatura em relação à data atual
+const (
+	StatementOpen   = "open"   // ainda aceita compras
+	StatementClosed = "closed" // fechada, aguardando vencimento
+	StatementPast   = "past"   // vencimento já passou
+)
+
+type CreditCardService struct {
+	creditCardRepo *repositories.CreditCardRepository
+	categoryRepo   *repositories.ExpenseCategoryRepository
+	expenseService *ExpenseService
+}
+
+func NewCreditCardService(
+	creditCardRepo *repositories.CreditCardRepository,
+	categoryRepo *repositories.ExpenseCategoryRepository,
+	expenseService *ExpenseService,
+) *CreditCardService {
+	return &CreditCardService{
+		creditCardRepo: creditCardRepo,
+		categoryRepo:   categoryRepo,
+		expenseService: expenseService,
+	}
+}
+
+// CreateCard cria um cartão de crédito
+func (s *CreditCardService) CreateCard(card *models.CreditCard) error {
+	if err := validateCreditCard(card); err != nil {
+		return err
+	}
+	return s.creditCardRepo.Create(card)
+}
+
+// UpdateCard atualiza um cartão de crédito
+func (s *CreditCardService) UpdateCard(card *models.CreditCard) error {
+	if err := validateCreditCard(card); err != nil {
+		return err
+	}
+	return s.creditCardRepo.Update(card)
+}
+
+func validateCreditCard(card *models.CreditCard) error {
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateRequiredString(card.Name, "name"))
+	validator.Add(utils.ValidateRange(card.ClosingDay, 1, 31, "closing_day"))
+	validator.Add(utils.ValidateDueDay(card.DueDay))
+	validator.Add(utils.ValidateNonNegativeAmount(card.LimitCents, "limit_cents"))
+
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	return nil
+}
+
+// GetCard busca um cartão ativo da família
+func (s *CreditCardService) GetCard(familyID, cardID uint) (*models.CreditCard, error) {
+	card, err := s.creditCardRepo.GetByID(cardID)
+	if err != nil || card.FamilyAccountID != familyID || !card.IsActive {
+		return nil, errors.New("cartão não encontrado")
+	}
+	return card, nil
+}
+
+// GetCardsByFamilyID busca os cartões da família
+func (s *CreditCardService) GetCardsByFamilyID(familyID uint) ([]models.CreditCard, error) {
+	return s.creditCardRepo.GetByFamilyID(familyID)
+}
+
+// DeleteCard desativa um cartão (as parcelas já lançadas continuam nas despesas)
+func (s *CreditCardService) DeleteCard(familyID, cardID uint) error {
+	if _, err := s.GetCard(familyID, cardID); err != nil {
+		return err
+	}
+	return s.creditCardRepo.Delete(cardID)
+}
+
+// PurchaseInput dados de uma compra no cartão
+type PurchaseInput struct {
+	CategoryID       uint                `json:"category_id"`
+	Name             string              `json:"name"`
+	Description      string              `json:"description"`
+	TotalAmountCents int64               `json:"total_amount_cents"`
+	InstallmentCount int                 `json:"installment_count"`
+	PurchaseDate     string              `json:"purchase_date"` // YYYY-MM-DD
+	SplitMode        string              `json:"split_mode"` // padrão: percentage
+	Splits           []ExpenseSplitInput `json:"splits"`
+}
+
+// CreatePurchase registra uma compra e lança cada parcela como despesa no mês da sua fatura.
+// O resto da divisão em centavos fica na primeira parcela.
+func (s *CreditCardService) CreatePurchase(familyID, cardID uint, input PurchaseInput) (*models.InstallmentPurchase, error) {
+	card, err := s.GetCard(familyID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.InstallmentCount == 0 {
+		input.InstallmentCount = 1
+	}
+
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateRequiredString(input.Name, "name"))
+	validator.Add(utils.ValidatePositiveAmount(input.TotalAmountCents, "total_amount_cents"))
+	validator.Add(utils.ValidateRange(input.InstallmentCount, 1, 48, "installment_count"))
+
+	purchaseDate, dateErr := time.Parse("2006-01-02", input.PurchaseDate)
+	if dateErr != nil {
+		validator.AddError(utils.ValidationError{
+			Field:   "purchase_date",
+			Message: "deve estar no formato YYYY-MM-DD",
+		})
+	}
+
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+
+	if _, err := s.categoryRepo.GetByIDForFamily(input.CategoryID, familyID); err != nil {
+		return nil, errors.New("categoria não encontrada")
+	}
+
+	firstMonth, firstYear := card.StatementForPurchase(purchaseDate)
+
+	// A divisão é calculada sobre o total (com as rendas do mês da primeira fatura)
+	// e cada parcela é repartida na mesma proporção
+	splitMode := models.SplitMode(input.SplitMode)
+	if splitMode == "" {
+		splitMode = models.SplitModePercentage
+	}
+	purchaseSplits, err := s.expenseService.ResolveSplits(familyID, splitMode, input.TotalAmountCents, firstMonth, firstYear, input.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase := &models.InstallmentPurchase{
+		FamilyAccountID:  familyID,
+		CreditCardID:     card.ID,
+		CategoryID:       input.CategoryID,
+		Name:             input.Name,
+		Description:      input.Description,
+		TotalAmountCents: input.TotalAmountCents,
+		InstallmentCount: input.InstallmentCount,
+		PurchaseDate:     purchaseDate,
+		IsActive:         true,
+	}
+
+	installmentCents := input.TotalAmountCents / int64(input.InstallmentCount)
+	remainder := input.TotalAmountCents % int64(input.InstallmentCount)
+
+	for i := 1; i <= input.InstallmentCount; i++ {
+		amountCents := installmentCents
+		if i == 1 {
+			amountCents += remainder
+		}
+
+		statement := time.Date(firstYear, time.Month(firstMonth), 1, 0, 0, 0, 0, time.UTC).AddDate(0, i-1, 0)
+
+		purchase.Installments = append(purchase.Installments, models.Expense{
+			FamilyAccountID:   familyID,
+			CategoryID:        input.CategoryID,
+			Name:              input.Name,
+			Description:       input.Description,
+			AmountCents:       amountCents,
+			Frequency:         models.FrequencyOneTime,
+			ExpenseType:       models.ExpenseTypeExpense,
+			DueDay:            card.DueDay,
+			IsFixed:           true, // parcela já comprometida
+			IsActive:          true,
+			ReferenceMonth:    int(statement.Month()),
+			ReferenceYear:     statement.Year(),
+			RecurrenceRule:    models.RecurrenceNone,
+			CreditCardID:      &card.ID,
+			InstallmentNumber: i,
+			InstallmentCount:  input.InstallmentCount,
+			SplitMode:         splitMode,
+			Splits:            prorateSplits(purchaseSplits, amountCents),
+		})
+	}
+
+	if err := s.creditCardRepo.CreatePurchase(purchase); err != nil {
+		return nil, err
+	}
+
+	return purchase, nil
+}
+
+// GetPurchases lista as compras ativas do cartão
+func (s *CreditCardService) GetPurchases(familyID, cardID uint) ([]models.InstallmentPurchase, error) {
+	if _, err := s.GetCard(familyID, cardID); err != nil {
+		return nil, err
+	}
+	return s.creditCardRepo.GetPurchasesByCardID(cardID)
+}
+
+// DeletePurchase cancela a compra e todas as suas parcelas
+func (s *CreditCardService) DeletePurchase(familyID, cardID, purchaseID uint) error {
+	purchase, err := s.creditCardRepo.GetPurchaseByID(purchaseID)
+	if err != nil || purchase.FamilyAccountID != familyID || purchase.CreditCardID != cardID {
+		return errors.New("compra não encontrada")
+	}
+	return s.creditCardRepo.DeletePurchase(purchaseID)
+}
+
+// StatementSummary resumo de uma fatura
+type StatementSummary struct {
+	Month       int       `json:"month"`
+	Year        int       `json:"year"`
+	ClosingDate time.Time `json:"closing_date"`
+	DueDate     time.Time `json:"due_date"`
+	Status      string    `json:"status"`
+	Total       float64   `json:"total"`
+	ItemCount   int       `json:"item_count"`
+}
+
+// StatementItem lançamento de uma fatura
+type StatementItem struct {
+	ExpenseID         uint    `json:"expense_id"`
+	PurchaseID        *uint   `json:"purchase_id,omitempty"`
+	Name              string  `json:"name"`
+	CategoryName      string  `json:"category_name"`
+	Amount            float64 `json:"amount"`
+	InstallmentNumber int     `json:"installment_number,omitempty"`
+	InstallmentCount  int     `json:"installment_count,omitempty"`
+}
+
+// CreditCardLimit uso do limite do cartão
+type CreditCardLimit struct {
+	Limit     float64 `json:"limit"`
+	Committed float64 `json:"committed"` // faturas ainda não vencidas
+	Remaining float64 `json:"remaining"`
+}
+
+// CreditCardStatements visão geral das faturas do cartão
+type CreditCardStatements struct {
+	Card       models.CreditCard  `json:"card"`
+	Limit      CreditCardLimit    `json:"limit"`
+	Statements []StatementSummary `json:"statements"`
+}
+
+// CreditCardStatementDetail fatura de um mês com seus lançamentos
+type CreditCardStatementDetail struct {
+	Card               models.CreditCard  `json:"card"`
+	Statement          StatementSummary   `json:"statement"`
+	Items              []StatementItem    `json:"items"`
+	Limit              CreditCardLimit    `json:"limit"`
+	FutureInstallments []StatementSummary `json:"future_installments"` // faturas seguintes já comprometidas
+}
+
+// GetStatements lista todas as faturas do cartão com o uso do limite
+func (s *CreditCardService) GetStatements(familyID, cardID uint) (*CreditCardStatements, error) {
+	card, err := s.GetCard(familyID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, limit, err := s.statementSummaries(card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreditCardStatements{
+		Card:       *card,
+		Limit:      limit,
+		Statements: statements,
+	}, nil
+}
+
+// GetStatement retorna a fatura de um mês (mês de vencimento)
+func (s *CreditCardService) GetStatement(familyID, cardID uint, month, year int) (*CreditCardStatementDetail, error) {
+	card, err := s.GetCard(familyID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.creditCardRepo.GetStatementItems(card.ID, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []StatementItem{}
+	totalCents := int64(0)
+	for _, expense := range expenses {
+		items = append(items, StatementItem{
+			ExpenseID:         expense.ID,
+			PurchaseID:        expense.InstallmentPurchaseID,
+			Name:              expense.Name,
+			CategoryName:      expense.Category.Name,
+			Amount:            utils.CentsToFloat(expense.AmountCents),
+			InstallmentNumber: expense.InstallmentNumber,
+			InstallmentCount:  expense.InstallmentCount,
+		})
+		totalCents += expense.AmountCents
+	}
+
+	statements, limit, err := s.statementSummaries(card)
+	if err != nil {
+		return nil, err
+	}
+
+	future := []StatementSummary{}
+	for _, statement := range statements {
+		if statement.Year*12+statement.Month > year*12+month {
+			future = append(future, statement)
+		}
+	}
+
+	return &CreditCardStatementDetail{
+		Card:               *card,
+		Statement:          newStatementSummary(card, month, year, totalCents, len(items)),
+		Items:              items,
+		Limit:              limit,
+		FutureInstallments: future,
+	}, nil
+}
+
+// statementSummaries monta o resumo de cada fatura e calcula o limite comprometido
+// (considera pagas as faturas cujo vencimento já passou)
+func (s *CreditCardService) statementSummaries(card *models.CreditCard) ([]StatementSummary, CreditCardLimit, error) {
+	totals, err := s.creditCardRepo.GetStatementTotals(card.ID)
+	if err != nil {
+		return nil, CreditCardLimit{}, err
+	}
+
+	statements := []StatementSummary{}
+	committedCents := int64(0)
+	for _, total := range totals {
+		summary := newStatementSummary(card, total.Month, total.Year, total.TotalCents, total.Count)
+		if summary.Status != StatementPast {
+			committedCents += total.TotalCents
+		}
+		statements = append(statements, summary)
+	}
+
+	limit := CreditCardLimit{
+		Limit:     utils.CentsToFloat(card.LimitCents),
+		Committed: utils.CentsToFloat(committedCents),
+		Remaining: utils.CentsToFloat(card.LimitCents - committedCents),
+	}
+
+	return statements, limit, nil
+}
+
+func newStatementSummary(card *models.CreditCard, month, year int, totalCents int64, count int) StatementSummary {
+	closingDate := card.ClosingDate(month, year)
+	dueDate := card.DueDate(month, year)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	status := StatementOpen
+	if today.After(dueDate) {
+		status = StatementPast
+	} else if !today.Before(closingDate) {
+		status = StatementClosed
+	}
+
+	return StatementSummary{
+		Month:       month,
+		Year:        year,
+		ClosingDate: closingDate,
+		DueDate:     dueDate,
+		Status:      status,
+		Total:       utils.CentsToFloat(totalCents),
+		ItemCount:   count,
+	}
+}
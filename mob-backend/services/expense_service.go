@@ -4,80 +4,101 @@ import (
 	"errors"
 	"finance-backend/models"
 	"finance-backend/repositories"
+	"finance-backend/services/calculation"
 	"finance-backend/utils"
+	"fmt"
+	"math"
+	"sort"
+	"time"
 )
 
 type ExpenseService struct {
 	expenseRepo  *repositories.ExpenseRepository
 	familyRepo   *repositories.FamilyRepository
 	categoryRepo *repositories.ExpenseCategoryRepository
+	ruleRepo     *repositories.CategorizationRuleRepository
+	incomeRepo   *repositories.IncomeRepository
 }
 
 func NewExpenseService(
 	expenseRepo *repositories.ExpenseRepository,
 	familyRepo *repositories.FamilyRepository,
 	categoryRepo *repositories.ExpenseCategoryRepository,
+	ruleRepo *repositories.CategorizationRuleRepository,
+	incomeRepo *repositories.IncomeRepository,
 ) *ExpenseService {
 	return &ExpenseService{
 		expenseRepo:  expenseRepo,
 		familyRepo:   familyRepo,
 		categoryRepo: categoryRepo,
+		ruleRepo:     ruleRepo,
+		incomeRepo:   incomeRepo,
 	}
 }
 
-// CreateExpense cria uma nova despesa com divisão entre membros
+// CreateExpense cria uma nova despesa com divisão entre membros.
+// Sem categoria informada, aplica a primeira regra de categorização da família que casar.
 func (s *ExpenseService) CreateExpense(expense *models.Expense, splits []ExpenseSplitInput) error {
+	if expense.CategoryID == 0 {
+		rule, err := s.MatchRule(expense.FamilyAccountID, expense.Name, expense.Description, expense.AmountCents)
+		if err != nil {
+			return err
+		}
+		if rule != nil {
+			expense.CategoryID = rule.CategoryID
+			if rule.IsFixed != nil {
+				expense.IsFixed = *rule.IsFixed
+			}
+			if len(splits) == 0 {
+				splits = ruleSplits(rule)
+			}
+		}
+	}
+	
 	// Validações
 	validator := utils.NewValidator()
 	
 	validator.Add(utils.ValidateRequiredString(expense.Name, "name"))
 	validator.Add(utils.ValidatePositiveAmount(expense.AmountCents, "amount_cents"))
 	validator.Add(utils.ValidateDueDay(expense.DueDay))
-	
-	if len(splits) == 0 {
+	validator.Add(utils.ValidateRecurrence(string(expense.RecurrenceRule), expense.RecurrenceInterval))
+	if expense.RecurrenceParentID != nil && expense.RecurrenceRule != "" && expense.RecurrenceRule != models.RecurrenceNone {
 		validator.AddError(utils.ValidationError{
-			Field:   "splits",
-			Message: "pelo menos um membro deve ser incluído",
+			Field:   "recurrence_rule",
+			Message: "ocorrências geradas não podem ter regra própria; edite a despesa original",
 		})
 	}
-	
-	// Validar splits
-	splitsForValidation := make([]struct {
-		FamilyMemberID uint
-		Percentage     float64
-	}, len(splits))
-	
-	for i, split := range splits {
-		splitsForValidation[i] = struct {
-			FamilyMemberID uint
-			Percentage     float64
-		}{
-			FamilyMemberID: split.FamilyMemberID,
-			Percentage:     split.Percentage,
-		}
+	if expense.CategoryID == 0 {
+		validator.AddError(utils.ValidationError{
+			Field:   "category_id",
+			Message: "obrigatória quando nenhuma regra de categorização se aplica",
+		})
 	}
 	
-	validator.Add(utils.ValidateExpenseSplits(splitsForValidation))
-	
 	if validator.HasErrors() {
 		return validator.GetErrors()
 	}
 	
-	// Verificar se categoria existe
-	_, err := s.categoryRepo.GetByID(expense.CategoryID)
+	// Verificar se categoria existe (padrão ou da própria família)
+	_, err := s.categoryRepo.GetByIDForFamily(expense.CategoryID, expense.FamilyAccountID)
 	if err != nil {
 		return errors.New("categoria não encontrada")
 	}
 	
-	// Validar que todos os membros pertencem à mesma família
-	for _, split := range splits {
-		belongs, err := s.familyRepo.MemberBelongsToFamily(split.FamilyMemberID, expense.FamilyAccountID)
-		if err != nil {
-			return err
-		}
-		if !belongs {
-			return errors.New("membro não pertence a esta família")
-		}
+	if err := s.validatePayer(expense); err != nil {
+		return err
+	}
+	if err := s.validateIRPFMetadata(expense); err != nil {
+		return err
+	}
+	
+	// Calcular a divisão conforme o modo (valida os membros)
+	if expense.SplitMode == "" {
+		expense.SplitMode = models.SplitModePercentage
+	}
+	resolvedSplits, err := s.ResolveSplits(expense.FamilyAccountID, expense.SplitMode, expense.AmountCents, expense.ReferenceMonth, expense.ReferenceYear, splits)
+	if err != nil {
+		return err
 	}
 	
 	// Criar despesa
@@ -87,7 +108,38 @@ func (s *ExpenseService) CreateExpense(expense *models.Expense, splits []Expense
 	}
 	
 	// Criar splits
-	return s.createExpenseSplits(expense.ID, expense.AmountCents, splits)
+	return s.saveSplits(s.expenseRepo, expense, resolvedSplits)
+}
+
+// MatchRule retorna a regra ativa de maior prioridade da família que casa com a despesa (nil se nenhuma)
+func (s *ExpenseService) MatchRule(familyID uint, name, description string, amountCents int64) (*models.CategorizationRule, error) {
+	rules, err := s.ruleRepo.GetActiveByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+	return matchCategorizationRule(rules, name, description, amountCents), nil
+}
+
+// matchCategorizationRule retorna a primeira regra (já ordenada por prioridade) que casa
+func matchCategorizationRule(rules []models.CategorizationRule, name, description string, amountCents int64) *models.CategorizationRule {
+	for i := range rules {
+		if rules[i].Matches(name, description, amountCents) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// ruleSplits converte o modelo de divisão da regra em splits de despesa
+func ruleSplits(rule *models.CategorizationRule) []ExpenseSplitInput {
+	splits := make([]ExpenseSplitInput, 0, len(rule.Splits))
+	for _, split := range rule.Splits {
+		splits = append(splits, ExpenseSplitInput{
+			FamilyMemberID: split.FamilyMemberID,
+			Percentage:     split.Percentage,
+		})
+	}
+	return splits
 }
 
 // UpdateExpense atualiza uma despesa e recalcula splits
@@ -98,38 +150,37 @@ func (s *ExpenseService) UpdateExpense(expense *models.Expense, splits []Expense
 	validator.Add(utils.ValidateRequiredString(expense.Name, "name"))
 	validator.Add(utils.ValidatePositiveAmount(expense.AmountCents, "amount_cents"))
 	validator.Add(utils.ValidateDueDay(expense.DueDay))
-	
-	// Validar splits
-	splitsForValidation := make([]struct {
-		FamilyMemberID uint
-		Percentage     float64
-	}, len(splits))
-	
-	for i, split := range splits {
-		splitsForValidation[i] = struct {
-			FamilyMemberID uint
-			Percentage     float64
-		}{
-			FamilyMemberID: split.FamilyMemberID,
-			Percentage:     split.Percentage,
-		}
+	validator.Add(utils.ValidateRecurrence(string(expense.RecurrenceRule), expense.RecurrenceInterval))
+	if expense.RecurrenceParentID != nil && expense.RecurrenceRule != "" && expense.RecurrenceRule != models.RecurrenceNone {
+		validator.AddError(utils.ValidationError{
+			Field:   "recurrence_rule",
+			Message: "ocorrências geradas não podem ter regra própria; edite a despesa original",
+		})
 	}
 	
-	validator.Add(utils.ValidateExpenseSplits(splitsForValidation))
-	
 	if validator.HasErrors() {
 		return validator.GetErrors()
 	}
 	
-	// Validar que todos os membros pertencem à mesma família
-	for _, split := range splits {
-		belongs, err := s.familyRepo.MemberBelongsToFamily(split.FamilyMemberID, expense.FamilyAccountID)
-		if err != nil {
-			return err
-		}
-		if !belongs {
-			return errors.New("membro não pertence a esta família")
-		}
+	// Verificar se categoria existe (padrão ou da própria família)
+	if _, err := s.categoryRepo.GetByIDForFamily(expense.CategoryID, expense.FamilyAccountID); err != nil {
+		return errors.New("categoria não encontrada")
+	}
+	
+	if err := s.validatePayer(expense); err != nil {
+		return err
+	}
+	if err := s.validateIRPFMetadata(expense); err != nil {
+		return err
+	}
+	
+	// Calcular a divisão conforme o modo (valida os membros)
+	if expense.SplitMode == "" {
+		expense.SplitMode = models.SplitModePercentage
+	}
+	resolvedSplits, err := s.ResolveSplits(expense.FamilyAccountID, expense.SplitMode, expense.AmountCents, expense.ReferenceMonth, expense.ReferenceYear, splits)
+	if err != nil {
+		return err
 	}
 	
 	// Usar transação para garantir atomicidade
@@ -146,58 +197,284 @@ func (s *ExpenseService) UpdateExpense(expense *models.Expense, splits []Expense
 			return err
 		}
 		
-		return s.createExpenseSplitsInTx(repo, expense.ID, expense.AmountCents, splits)
+		return s.saveSplits(repo, expense, resolvedSplits)
 	})
 }
 
-// createExpenseSplits cria as divisões de uma despesa
-func (s *ExpenseService) createExpenseSplits(expenseID uint, totalAmountCents int64, splits []ExpenseSplitInput) error {
+// validatePayer verifica se o membro pagador (quando informado) pertence à família
+func (s *ExpenseService) validatePayer(expense *models.Expense) error {
+	if expense.PaidByMemberID == nil {
+		return nil
+	}
+	
+	belongs, err := s.familyRepo.MemberBelongsToFamily(*expense.PaidByMemberID, expense.FamilyAccountID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return utils.ValidationErrors{{Field: "paid_by_member_id", Message: "membro não pertence a esta família"}}
+	}
+	return nil
+}
+
+// validateIRPFMetadata valida a dedução do IRPF, o CPF/CNPJ do prestador (gravado sem pontuação) e se o
+// beneficiário (quando informado) pertence à família
+func (s *ExpenseService) validateIRPFMetadata(expense *models.Expense) error {
+	expense.ProviderDocument = utils.NormalizeDocument(expense.ProviderDocument)
+	
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateExpenseIRPFDeduction(string(expense.IRPFDeduction)))
+	if expense.ProviderDocument != "" {
+		validator.Add(utils.ValidateCPFOrCNPJ(expense.ProviderDocument, "provider_document"))
+	}
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	
+	if expense.IRPFBeneficiaryMemberID == nil {
+		return nil
+	}
+	belongs, err := s.familyRepo.MemberBelongsToFamily(*expense.IRPFBeneficiaryMemberID, expense.FamilyAccountID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return utils.ValidationErrors{{Field: "irpf_beneficiary_member_id", Message: "membro não pertence a esta família"}}
+	}
+	return nil
+}
+
+// saveSplits grava as divisões já calculadas de uma despesa
+func (s *ExpenseService) saveSplits(repo *repositories.ExpenseRepository, expense *models.Expense, splits []models.ExpenseSplit) error {
+	expense.Splits = nil
 	for _, split := range splits {
-		// Calcular valor baseado na porcentagem
-		amountCents := utils.CalculatePercentage(totalAmountCents, split.Percentage)
-		
-		expenseSplit := &models.ExpenseSplit{
-			ExpenseID:      expenseID,
-			FamilyMemberID: split.FamilyMemberID,
-			Percentage:     split.Percentage,
-			AmountCents:    amountCents,
+		split.ExpenseID = expense.ID
+		if err := repo.CreateSplit(&split); err != nil {
+			return err
 		}
-		
-		err := s.expenseRepo.CreateSplit(expenseSplit)
+		expense.Splits = append(expense.Splits, split)
+	}
+	
+	return nil
+}
+
+// ExpenseSplitInput representa a entrada de divisão de despesa
+type ExpenseSplitInput struct {
+	FamilyMemberID uint    `json:"family_member_id"`
+	Percentage     float64 `json:"percentage"`   // modo percentage
+	AmountCents    int64   `json:"amount_cents"` // modo exact
+	Shares         float64 `json:"shares"`       // modo shares
+}
+
+// ValidateSplits valida as porcentagens dos splits e se os membros pertencem à família
+func (s *ExpenseService) ValidateSplits(familyID uint, splits []ExpenseSplitInput) error {
+	splitsForValidation := make([]struct {
+		FamilyMemberID uint
+		Percentage     float64
+	}, len(splits))
+	
+	for i, split := range splits {
+		splitsForValidation[i].FamilyMemberID = split.FamilyMemberID
+		splitsForValidation[i].Percentage = split.Percentage
+	}
+	
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateExpenseSplits(splitsForValidation))
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	
+	for _, split := range splits {
+		belongs, err := s.familyRepo.MemberBelongsToFamily(split.FamilyMemberID, familyID)
 		if err != nil {
 			return err
 		}
+		if !belongs {
+			return errors.New("membro não pertence a esta família")
+		}
 	}
 	
 	return nil
 }
 
-// createExpenseSplitsInTx cria splits dentro de uma transação
-func (s *ExpenseService) createExpenseSplitsInTx(repo *repositories.ExpenseRepository, expenseID uint, totalAmountCents int64, splits []ExpenseSplitInput) error {
-	for _, split := range splits {
-		// Calcular valor baseado na porcentagem
-		amountCents := utils.CalculatePercentage(totalAmountCents, split.Percentage)
+// ResolveSplits valida a divisão conforme o modo e calcula o valor de cada membro.
+// Os centavos de arredondamento vão para as maiores sobras (empate: menor ID de membro),
+// então a soma dos splits é sempre exatamente totalCents.
+// Nos modos equal e income, sem membros informados divide entre todos os membros ativos.
+// No modo income os pesos são as rendas vigentes no mês/ano da despesa (zero = mês atual).
+func (s *ExpenseService) ResolveSplits(familyID uint, mode models.SplitMode, totalCents int64, month, year int, splits []ExpenseSplitInput) ([]models.ExpenseSplit, error) {
+	if len(splits) == 0 && (mode == models.SplitModeEqual || mode == models.SplitModeIncome) {
+		members, err := s.familyRepo.GetMembers(familyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if member.IsActive {
+				splits = append(splits, ExpenseSplitInput{FamilyMemberID: member.ID})
+			}
+		}
+	}
+	
+	sorted := make([]ExpenseSplitInput, len(splits))
+	copy(sorted, splits)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].FamilyMemberID < sorted[j].FamilyMemberID })
+	
+	validator := utils.NewValidator()
+	if len(sorted) == 0 {
+		validator.AddError(utils.ValidationError{Field: "splits", Message: "pelo menos um membro deve ser incluído"})
+	}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].FamilyMemberID == sorted[i-1].FamilyMemberID {
+			validator.AddError(utils.ValidationError{
+				Field:   "splits",
+				Message: fmt.Sprintf("membro %d aparece mais de uma vez", sorted[i].FamilyMemberID),
+			})
+		}
+	}
+	
+	weights := make([]int64, len(sorted))
+	switch mode {
+	case models.SplitModePercentage:
+		splitsForValidation := make([]struct {
+			FamilyMemberID uint
+			Percentage     float64
+		}, len(sorted))
+		for i, split := range sorted {
+			splitsForValidation[i].FamilyMemberID = split.FamilyMemberID
+			splitsForValidation[i].Percentage = split.Percentage
+			weights[i] = int64(math.Round(split.Percentage * 100))
+		}
+		if len(sorted) > 0 {
+			validator.Add(utils.ValidateExpenseSplits(splitsForValidation))
+		}
+	case models.SplitModeExact:
+		sum := int64(0)
+		for i, split := range sorted {
+			validator.Add(utils.ValidateNonNegativeAmount(split.AmountCents, fmt.Sprintf("splits[%d].amount_cents", i)))
+			weights[i] = split.AmountCents
+			sum += split.AmountCents
+		}
+		if sum != totalCents {
+			validator.AddError(utils.ValidationError{
+				Field:   "splits",
+				Message: fmt.Sprintf("a soma dos valores deve ser igual ao total da despesa (%s de %s)", utils.FormatMoney(sum), utils.FormatMoney(totalCents)),
+			})
+		}
+	case models.SplitModeShares:
+		for i, split := range sorted {
+			if split.Shares <= 0 {
+				validator.AddError(utils.ValidationError{Field: fmt.Sprintf("splits[%d].shares", i), Message: "deve ser maior que zero"})
+			}
+			weights[i] = int64(math.Round(split.Shares * 10000))
+		}
+	case models.SplitModeEqual:
+		for i := range sorted {
+			weights[i] = 1
+		}
+	case models.SplitModeIncome:
+		// pesos = renda líquida ativa, buscada depois de validar os membros
+	default:
+		validator.AddError(utils.ValidationError{Field: "split_mode", Message: "deve ser percentage, exact, shares, equal ou income"})
+	}
+	
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+	
+	// Validar que todos os membros pertencem à mesma família
+	for _, split := range sorted {
+		belongs, err := s.familyRepo.MemberBelongsToFamily(split.FamilyMemberID, familyID)
+		if err != nil {
+			return nil, err
+		}
+		if !belongs {
+			return nil, errors.New("membro não pertence a esta família")
+		}
+	}
+	
+	if mode == models.SplitModeIncome {
+		if month == 0 || year == 0 {
+			now := time.Now()
+			month, year = int(now.Month()), now.Year()
+		}
+		incomes, err := s.incomeRepo.GetByFamilyIDAndMonth(familyID, month, year)
+		if err != nil {
+			return nil, err
+		}
+		netByMember := make(map[uint]int64)
+		for _, income := range incomes {
+			netByMember[income.FamilyMemberID] += income.NetMonthlyCents
+		}
+		totalNet := int64(0)
+		for i, split := range sorted {
+			weights[i] = netByMember[split.FamilyMemberID]
+			totalNet += weights[i]
+		}
+		if totalNet <= 0 {
+			return nil, utils.ValidationErrors{{Field: "splits", Message: "nenhum dos membros tem renda líquida ativa cadastrada"}}
+		}
+	}
+	
+	amounts := calculation.AllocateCents(totalCents, weights)
+	result := make([]models.ExpenseSplit, 0, len(sorted))
+	for i, split := range sorted {
+		// Membro sem renda não participa da divisão proporcional
+		if mode == models.SplitModeIncome && weights[i] <= 0 {
+			continue
+		}
 		
-		expenseSplit := &models.ExpenseSplit{
-			ExpenseID:      expenseID,
+		expenseSplit := models.ExpenseSplit{
 			FamilyMemberID: split.FamilyMemberID,
 			Percentage:     split.Percentage,
-			AmountCents:    amountCents,
+			AmountCents:    amounts[i],
 		}
-		
-		err := repo.CreateSplit(expenseSplit)
-		if err != nil {
-			return err
+		if mode != models.SplitModePercentage {
+			expenseSplit.Percentage = math.Round(utils.CalculatePercentageOf(amounts[i], totalCents)*100) / 100
+		}
+		if mode == models.SplitModeShares {
+			expenseSplit.Shares = split.Shares
 		}
+		result = append(result, expenseSplit)
 	}
-	
-	return nil
+	return result, nil
 }
 
-// ExpenseSplitInput representa a entrada de divisão de despesa
-type ExpenseSplitInput struct {
-	FamilyMemberID uint    `json:"family_member_id"`
-	Percentage     float64 `json:"percentage"`
+// buildSplits monta os splits de um valor a partir das porcentagens (sem persistir).
+// Usa a mesma distribuição de centavos de ResolveSplits: a soma é exatamente totalAmountCents.
+func buildSplits(totalAmountCents int64, splits []ExpenseSplitInput) []models.ExpenseSplit {
+	template := make([]models.ExpenseSplit, 0, len(splits))
+	for _, split := range splits {
+		template = append(template, models.ExpenseSplit{
+			FamilyMemberID: split.FamilyMemberID,
+			Percentage:     split.Percentage,
+			AmountCents:    int64(math.Round(split.Percentage * 100)),
+		})
+	}
+	return prorateSplits(template, totalAmountCents)
+}
+
+// prorateSplits reparte outro valor na mesma proporção de splits já calculados (ex: parcelas de uma compra)
+func prorateSplits(template []models.ExpenseSplit, totalAmountCents int64) []models.ExpenseSplit {
+	sorted := make([]models.ExpenseSplit, len(template))
+	copy(sorted, template)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].FamilyMemberID < sorted[j].FamilyMemberID })
+	
+	weights := make([]int64, len(sorted))
+	for i, split := range sorted {
+		weights[i] = split.AmountCents
+	}
+	
+	amounts := calculation.AllocateCents(totalAmountCents, weights)
+	result := make([]models.ExpenseSplit, 0, len(sorted))
+	for i, split := range sorted {
+		result = append(result, models.ExpenseSplit{
+			FamilyMemberID: split.FamilyMemberID,
+			Percentage:     split.Percentage,
+			AmountCents:    amounts[i],
+			Shares:         split.Shares,
+		})
+	}
+	return result
 }
 
 // GetExpenseByID busca despesa por ID
@@ -237,30 +514,69 @@ func (s *ExpenseService) GetExpensesByCategory(familyID uint, month, year int) (
 		return nil, err
 	}
 	
+	// Subcategorias são somadas na categoria pai
+	visible, err := s.categoryRepo.GetByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+	categoryByID := make(map[uint]models.ExpenseCategory, len(visible))
+	for _, category := range visible {
+		categoryByID[category.ID] = category
+	}
+	
 	// Agrupar por categoria manualmente
 	categoryMap := make(map[uint]*CategoryExpense)
+	subcategoryIndex := make(map[uint]int) // subcategoria -> posição em Subcategories do pai
+	order := []uint{}
 	totalCents := int64(0)
 	
 	for _, expense := range expenses {
 		totalCents += expense.AmountCents
+		amount := utils.CentsToFloat(expense.AmountCents)
 		
 		catID := expense.CategoryID
+		catName := expense.Category.Name
+		subcategoryID := uint(0)
+		if category, ok := categoryByID[catID]; ok && category.ParentID != nil {
+			if parent, ok := categoryByID[*category.ParentID]; ok {
+				subcategoryID = category.ID
+				catID = parent.ID
+				catName = parent.Name
+			}
+		}
+		
 		if _, exists := categoryMap[catID]; !exists {
 			categoryMap[catID] = &CategoryExpense{
 				CategoryID:   catID,
-				CategoryName: expense.Category.Name,
+				CategoryName: catName,
 				Total:        0,
 				Count:        0,
 			}
+			order = append(order, catID)
 		}
 		
-		categoryMap[catID].Total += utils.CentsToFloat(expense.AmountCents)
-		categoryMap[catID].Count++
+		cat := categoryMap[catID]
+		cat.Total += amount
+		cat.Count++
+		
+		if subcategoryID != 0 {
+			index, exists := subcategoryIndex[subcategoryID]
+			if !exists {
+				cat.Subcategories = append(cat.Subcategories, CategoryExpense{
+					CategoryID:   subcategoryID,
+					CategoryName: expense.Category.Name,
+				})
+				index = len(cat.Subcategories) - 1
+				subcategoryIndex[subcategoryID] = index
+			}
+			cat.Subcategories[index].Total += amount
+			cat.Subcategories[index].Count++
+		}
 	}
 	
 	categories := []CategoryExpense{}
-	for _, cat := range categoryMap {
-		categories = append(categories, *cat)
+	for _, catID := range order {
+		categories = append(categories, *categoryMap[catID])
 	}
 	
 	return &ExpenseByCategoryResponse{
@@ -275,10 +591,11 @@ type ExpenseByCategoryResponse struct {
 }
 
 type CategoryExpense struct {
-	CategoryID   uint    `json:"category_id"`
-	CategoryName string  `json:"category_name"`
-	Total        float64 `json:"total"`
-	Count        int     `json:"count"`
+	CategoryID    uint              `json:"category_id"`
+	CategoryName  string            `json:"category_name"`
+	Total         float64           `json:"total"`
+	Count         int               `json:"count"`
+	Subcategories []CategoryExpense `json:"subcategories,omitempty"` // parte do total lançada em subcategorias
 }
 
 // GetMemberExpenses retorna despesas de um membro específico
@@ -391,11 +708,6 @@ type ExpensesSummary struct {
 	ByCategory    []CategoryExpense `json:"by_category"`
 }
 
-// GetAllCategories retorna todas as categorias
-func (s *ExpenseService) GetAllCategories() ([]models.ExpenseCategory, error) {
-	return s.categoryRepo.GetAll()
-}
-
 // GetDefaultCategories retorna categorias padrão
 func (s *ExpenseService) GetDefaultCategories() ([]models.ExpenseCategory, error) {
 	return s.categoryRepo.GetDefaults()
@@ -1,17 +1,30 @@
 package services
 
 import (
+	"errors"
 	"finance-backend/models"
 	"finance-backend/repositories"
 	"finance-backend/utils"
+
+	"gorm.io/gorm"
 )
 
 type FamilyService struct {
 	familyRepo *repositories.FamilyRepository
+	userRepo   *repositories.UserRepository
+}
+
+func NewFamilyService(familyRepo *repositories.FamilyRepository, userRepo *repositories.UserRepository) *FamilyService {
+	return &FamilyService{
+		familyRepo: familyRepo,
+		userRepo:   userRepo,
+	}
 }
 
-func NewFamilyService(familyRepo *repositories.FamilyRepository) *FamilyService {
-	return &FamilyService{familyRepo: familyRepo}
+// FamilyWithRole família acompanhada do papel do usuário nela
+type FamilyWithRole struct {
+	models.FamilyAccount
+	Role models.MemberRole `json:"role"`
 }
 
 // CreateFamily cria uma nova conta familiar
@@ -36,6 +49,105 @@ func (s *FamilyService) GetFamiliesByOwner(ownerUserID uint) ([]models.FamilyAcc
 	return s.familyRepo.GetByOwnerID(ownerUserID)
 }
 
+// GetAccessibleFamilies busca todas as famílias que o usuário acessa (como dono ou membro), com seu papel
+func (s *FamilyService) GetAccessibleFamilies(userID uint) ([]FamilyWithRole, error) {
+	families, err := s.familyRepo.GetAccessibleByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := make([]FamilyWithRole, 0, len(families))
+	for _, family := range families {
+		var member *models.FamilyMember
+		for i := range family.Members {
+			if family.Members[i].UserID != nil && *family.Members[i].UserID == userID && family.Members[i].IsActive {
+				member = &family.Members[i]
+				break
+			}
+		}
+		
+		result = append(result, FamilyWithRole{
+			FamilyAccount: family,
+			Role:          models.ResolveMemberRole(family.OwnerUserID, userID, member),
+		})
+	}
+	
+	return result, nil
+}
+
+// TransferOwnership transfere a propriedade da família para outro membro vinculado a um usuário.
+// O antigo dono continua na família como member.
+func (s *FamilyService) TransferOwnership(familyID, currentOwnerID, memberID uint) (*models.FamilyAccount, error) {
+	var family *models.FamilyAccount
+	
+	err := s.familyRepo.UpdateWithTransaction(func(txRepo *repositories.FamilyRepository) error {
+		var err error
+		family, err = txRepo.GetByIDForUpdate(familyID)
+		if err != nil {
+			return err
+		}
+		if family.OwnerUserID != currentOwnerID {
+			return errors.New("apenas o dono pode transferir a família")
+		}
+		
+		target, err := txRepo.GetMemberByID(memberID)
+		if err != nil || target.FamilyAccountID != familyID || !target.IsActive {
+			return errors.New("membro não pertence a esta família")
+		}
+		if target.UserID == nil {
+			return errors.New("o membro precisa estar vinculado a um usuário para receber a família")
+		}
+		if *target.UserID == currentOwnerID {
+			return errors.New("você já é o dono desta família")
+		}
+		
+		// Antigo dono passa a ser member (cria o registro se ele não tiver um)
+		previous, err := txRepo.GetActiveMemberByUserID(familyID, currentOwnerID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			user, err := s.userRepo.GetByID(currentOwnerID)
+			if err != nil {
+				return err
+			}
+			name := user.Name
+			if name == "" {
+				name = user.Username
+			}
+			previous = &models.FamilyMember{
+				FamilyAccountID: familyID,
+				UserID:          &currentOwnerID,
+				Name:            name,
+				IsActive:        true,
+			}
+		}
+		previous.Role = models.RoleMember
+		previous.Incomes = nil
+		if err := txRepo.UpdateMember(previous); err != nil {
+			return err
+		}
+		
+		target.Role = models.RoleOwner
+		target.Incomes = nil
+		if err := txRepo.UpdateMember(target); err != nil {
+			return err
+		}
+		
+		if err := txRepo.UpdateOwner(familyID, *target.UserID); err != nil {
+			return err
+		}
+		family.OwnerUserID = *target.UserID
+		return nil
+	})
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return family, nil
+}
+
 // UpdateFamily atualiza uma família
 func (s *FamilyService) UpdateFamily(family *models.FamilyAccount) error {
 	validator := utils.NewValidator()
@@ -62,10 +174,16 @@ func (s *FamilyService) GetMembers(familyID uint) ([]models.FamilyMember, error)
 func (s *FamilyService) AddMember(member *models.FamilyMember) error {
 	validator := utils.NewValidator()
 	validator.Add(utils.ValidateRequiredString(member.Name, "name"))
+	if member.Role != "" {
+		validator.Add(utils.ValidateMemberRole(string(member.Role)))
+	}
 	
 	if validator.HasErrors() {
 		return validator.GetErrors()
 	}
+	if err := s.validateIRPFDeclarant(member); err != nil {
+		return err
+	}
 	
 	return s.familyRepo.AddMember(member)
 }
@@ -74,16 +192,54 @@ func (s *FamilyService) AddMember(member *models.FamilyMember) error {
 func (s *FamilyService) UpdateMember(member *models.FamilyMember) error {
 	validator := utils.NewValidator()
 	validator.Add(utils.ValidateRequiredString(member.Name, "name"))
+	if member.Role != "" {
+		validator.Add(utils.ValidateMemberRole(string(member.Role)))
+	}
 	
 	if validator.HasErrors() {
 		return validator.GetErrors()
 	}
 	
+	// Só dependentes têm declarante
+	if member.Role != models.RoleDependent {
+		member.IRPFDeclarantMemberID = nil
+	}
+	if err := s.validateIRPFDeclarant(member); err != nil {
+		return err
+	}
+	
 	return s.familyRepo.UpdateMember(member)
 }
 
-// RemoveMember remove um membro
-func (s *FamilyService) RemoveMember(memberID uint) error {
+// validateIRPFDeclarant valida o declarante do dependente: um membro ativo da mesma família que não é dependente
+func (s *FamilyService) validateIRPFDeclarant(member *models.FamilyMember) error {
+	if member.IRPFDeclarantMemberID == nil {
+		return nil
+	}
+	if member.Role != models.RoleDependent {
+		return utils.ValidationError{Field: "irpf_declarant_member_id", Message: "só dependentes têm declarante"}
+	}
+	
+	declarant, err := s.familyRepo.GetMemberByID(*member.IRPFDeclarantMemberID)
+	if err != nil || declarant.FamilyAccountID != member.FamilyAccountID || !declarant.IsActive {
+		return utils.ValidationError{Field: "irpf_declarant_member_id", Message: "membro não encontrado nesta família"}
+	}
+	if declarant.Role == models.RoleDependent || declarant.ID == member.ID {
+		return utils.ValidationError{Field: "irpf_declarant_member_id", Message: "o declarante não pode ser um dependente"}
+	}
+	return nil
+}
+
+// RemoveMember remove um membro da família
+func (s *FamilyService) RemoveMember(familyID, memberID uint) error {
+	belongs, err := s.familyRepo.MemberBelongsToFamily(memberID, familyID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return errors.New("membro não pertence a esta família")
+	}
+	
 	return s.familyRepo.RemoveMember(memberID)
 }
 
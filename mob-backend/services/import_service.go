@@ -0,0 +1,627 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/services/importer"
+	"finance-backend/utils"
+)
+
+// Limite de lançamentos por arquivo importado
+const maxImportRows = 5000
+
+var (
+	ErrImportNotFound          = errors.New("importação não encontrada")
+	ErrImportNotStaged         = errors.New("importação já foi confirmada ou desfeita")
+	ErrImportNotCommitted      = errors.New("apenas importações confirmadas podem ser desfeitas")
+	ErrImportNothingSelected   = errors.New("nenhuma linha selecionada para importar")
+	ErrUnsupportedImportFormat = errors.New("formato não suportado; use ofx ou csv")
+	ErrUnknownImportLayout     = errors.New("layout CSV desconhecido")
+	ErrImportNoMembers         = errors.New("a família não possui membros ativos para dividir as despesas")
+)
+
+type ImportService struct {
+	importRepo     *repositories.ImportRepository
+	expenseRepo    *repositories.ExpenseRepository
+	familyRepo     *repositories.FamilyRepository
+	categoryRepo   *repositories.ExpenseCategoryRepository
+	ruleRepo       *repositories.CategorizationRuleRepository
+	expenseService *ExpenseService
+}
+
+func NewImportService(
+	importRepo *repositories.ImportRepository,
+	expenseRepo *repositories.ExpenseRepository,
+	familyRepo *repositories.FamilyRepository,
+	categoryRepo *repositories.ExpenseCategoryRepository,
+	ruleRepo *repositories.CategorizationRuleRepository,
+	expenseService *ExpenseService,
+) *ImportService {
+	return &ImportService{
+		importRepo:     importRepo,
+		expenseRepo:    expenseRepo,
+		familyRepo:     familyRepo,
+		categoryRepo:   categoryRepo,
+		ruleRepo:       ruleRepo,
+		expenseService: expenseService,
+	}
+}
+
+// ImportFileInput arquivo enviado para importação
+type ImportFileInput struct {
+	FileName     string
+	Content      []byte
+	Format       string              // "ofx" ou "csv"; vazio = deduzido pela extensão
+	Layout       string              // layout CSV conhecido (ver importer.Layouts) ou "custom"
+	CustomLayout *importer.CSVLayout // obrigatório quando Layout = "custom"
+}
+
+// StageImport lê o arquivo, marca as duplicatas, sugere categorias pelas regras da família
+// e grava o lote para revisão. Saídas que não são duplicatas já vêm selecionadas; entradas vêm desmarcadas
+// e só viram renda quando selecionadas na revisão.
+func (s *ImportService) StageImport(familyID, userID uint, input ImportFileInput) (*models.ImportBatch, error) {
+	format := models.ImportFormat(strings.ToLower(input.Format))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(input.FileName)) {
+		case ".ofx", ".qfx":
+			format = models.ImportFormatOFX
+		case ".csv":
+			format = models.ImportFormatCSV
+		}
+	}
+
+	var transactions []importer.Transaction
+	var err error
+	layoutName := ""
+
+	switch format {
+	case models.ImportFormatOFX:
+		transactions, err = importer.ParseOFX(input.Content)
+	case models.ImportFormatCSV:
+		layout, layoutErr := resolveCSVLayout(input.Layout, input.CustomLayout)
+		if layoutErr != nil {
+			return nil, layoutErr
+		}
+		layoutName = layout.Name
+		transactions, err = importer.ParseCSV(input.Content, layout)
+	default:
+		return nil, ErrUnsupportedImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) > maxImportRows {
+		return nil, fmt.Errorf("o arquivo excede o limite de %d lançamentos", maxImportRows)
+	}
+
+	duplicates, err := s.findDuplicates(familyID, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.GetActiveByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &models.ImportBatch{
+		FamilyAccountID: familyID,
+		FileName:        input.FileName,
+		Format:          format,
+		Layout:          layoutName,
+		Status:          models.ImportStatusStaged,
+		RowCount:        len(transactions),
+	}
+	if userID != 0 {
+		batch.CreatedByUserID = &userID
+	}
+
+	for i, transaction := range transactions {
+		row := models.ImportRow{
+			LineNumber:         transaction.Line,
+			TransactionDate:    transaction.Date,
+			Description:        transaction.Description,
+			AmountCents:        transaction.AmountCents,
+			ExternalID:         transaction.ExternalID,
+			DuplicateExpenseID: duplicates[i],
+		}
+		row.Selected = row.IsDebit() && !row.IsDuplicate()
+		if row.IsDuplicate() {
+			batch.DuplicateCount++
+		}
+		if row.IsDebit() {
+			if rule := matchCategorizationRule(rules, row.Description, "", -row.AmountCents); rule != nil {
+				row.CategoryID = &rule.CategoryID
+				row.CategorizationRuleID = &rule.ID
+			}
+		}
+		batch.Rows = append(batch.Rows, row)
+	}
+
+	if err := s.importRepo.Create(batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// resolveCSVLayout retorna o layout conhecido pelo nome ou o layout personalizado
+func resolveCSVLayout(name string, custom *importer.CSVLayout) (importer.CSVLayout, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "custom" || (name == "" && custom != nil) {
+		if custom == nil {
+			return importer.CSVLayout{}, ErrUnknownImportLayout
+		}
+		layout := *custom
+		layout.Name = "custom"
+		return layout, nil
+	}
+
+	layout, ok := importer.Layouts[name]
+	if !ok {
+		return importer.CSVLayout{}, ErrUnknownImportLayout
+	}
+	return layout, nil
+}
+
+// duplicateKey identifica uma despesa por data, valor e nome
+type duplicateKey struct {
+	year, month, day int
+	amountCents      int64
+	name             string
+}
+
+// findDuplicates procura, para cada saída, uma despesa ativa da família com a mesma data
+// (mês de referência + dia de vencimento), o mesmo valor e o mesmo nome (sem diferenciar maiúsculas)
+func (s *ImportService) findDuplicates(familyID uint, transactions []importer.Transaction) ([]*uint, error) {
+	result := make([]*uint, len(transactions))
+	if len(transactions) == 0 {
+		return result, nil
+	}
+
+	first, last := transactions[0].Date, transactions[0].Date
+	for _, transaction := range transactions {
+		if transaction.Date.Before(first) {
+			first = transaction.Date
+		}
+		if transaction.Date.After(last) {
+			last = transaction.Date
+		}
+	}
+
+	expenses, err := s.expenseRepo.GetByFamilyIDBetweenMonths(familyID,
+		int(first.Month()), first.Year(), int(last.Month()), last.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[duplicateKey]uint, len(expenses))
+	for _, expense := range expenses {
+		key := duplicateKey{
+			year:        expense.ReferenceYear,
+			month:       expense.ReferenceMonth,
+			day:         expense.DueDay,
+			amountCents: expense.AmountCents,
+			name:        strings.ToLower(strings.TrimSpace(expense.Name)),
+		}
+		if _, ok := existing[key]; !ok {
+			existing[key] = expense.ID
+		}
+	}
+
+	for i, transaction := range transactions {
+		if transaction.AmountCents >= 0 {
+			continue
+		}
+		key := duplicateKey{
+			year:        transaction.Date.Year(),
+			month:       int(transaction.Date.Month()),
+			day:         transaction.Date.Day(),
+			amountCents: -transaction.AmountCents,
+			name:        strings.ToLower(transaction.Description),
+		}
+		if id, ok := existing[key]; ok {
+			expenseID := id
+			result[i] = &expenseID
+		}
+	}
+
+	return result, nil
+}
+
+// GetImports lista os lotes de importação da família
+func (s *ImportService) GetImports(familyID uint) ([]models.ImportBatch, error) {
+	return s.importRepo.GetByFamilyID(familyID)
+}
+
+// GetImport busca um lote da família com suas linhas
+func (s *ImportService) GetImport(familyID, importID uint) (*models.ImportBatch, error) {
+	batch, err := s.importRepo.GetByID(importID)
+	if err != nil || batch.FamilyAccountID != familyID {
+		return nil, ErrImportNotFound
+	}
+	return batch, nil
+}
+
+// ImportRowInput alteração de uma linha durante a revisão
+type ImportRowInput struct {
+	ID             uint  `json:"id"`
+	Selected       *bool `json:"selected"`
+	CategoryID     *uint `json:"category_id"`
+	FamilyMemberID *uint `json:"family_member_id"` // membro que recebeu a entrada
+}
+
+// UpdateRows altera a seleção, a categoria e o membro das linhas de um lote em revisão
+func (s *ImportService) UpdateRows(familyID, importID uint, rows []ImportRowInput) (*models.ImportBatch, error) {
+	batch, err := s.GetImport(familyID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status != models.ImportStatusStaged {
+		return nil, ErrImportNotStaged
+	}
+
+	byID := make(map[uint]*models.ImportRow, len(batch.Rows))
+	for i := range batch.Rows {
+		byID[batch.Rows[i].ID] = &batch.Rows[i]
+	}
+
+	validator := utils.NewValidator()
+	for _, input := range rows {
+		row, ok := byID[input.ID]
+		if !ok {
+			validator.AddError(utils.ValidationError{
+				Field:   "rows",
+				Message: fmt.Sprintf("linha %d não pertence a esta importação", input.ID),
+			})
+			continue
+		}
+		if input.FamilyMemberID != nil {
+			if row.IsDebit() {
+				validator.AddError(utils.ValidationError{
+					Field:   "family_member_id",
+					Message: fmt.Sprintf("linha %d é uma saída; o membro só vale para entradas", input.ID),
+				})
+			} else if err := s.validateIncomeMember(familyID, *input.FamilyMemberID, "family_member_id"); err != nil {
+				if _, ok := err.(utils.ValidationError); !ok {
+					return nil, err
+				}
+				validator.Add(err)
+			}
+		}
+		if input.CategoryID != nil {
+			if _, err := s.categoryRepo.GetByIDForFamily(*input.CategoryID, familyID); err != nil {
+				validator.AddError(utils.ValidationError{
+					Field:   "category_id",
+					Message: fmt.Sprintf("categoria %d não encontrada", *input.CategoryID),
+				})
+			}
+		}
+	}
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+
+	for _, input := range rows {
+		row := byID[input.ID]
+		if input.Selected != nil {
+			row.Selected = *input.Selected
+		}
+		if input.CategoryID != nil {
+			// Categoria escolhida na revisão substitui a sugestão da regra
+			row.CategoryID = input.CategoryID
+			row.CategorizationRuleID = nil
+		}
+		if input.FamilyMemberID != nil {
+			row.FamilyMemberID = input.FamilyMemberID
+		}
+		if err := s.importRepo.UpdateRow(row); err != nil {
+			return nil, err
+		}
+	}
+
+	return batch, nil
+}
+
+// CommitImportInput opções da confirmação do lote
+type CommitImportInput struct {
+	CategoryID     uint                `json:"category_id"`      // categoria das saídas sem categoria própria
+	Splits         []ExpenseSplitInput `json:"splits"`           // vazio = divisão igual entre os membros ativos
+	IncomeMemberID uint                `json:"income_member_id"` // membro das entradas sem membro próprio
+	IncomeType     string              `json:"income_type"`      // tipo das rendas criadas (padrão: freelance)
+}
+
+// CommitImport cria, na mesma transação, uma despesa para cada saída selecionada e uma renda para cada
+// entrada selecionada. A renda vale só no mês da entrada e guarda o valor recebido como bruto e líquido,
+// sem cálculo de impostos.
+func (s *ImportService) CommitImport(familyID, importID uint, input CommitImportInput) (*models.ImportBatch, error) {
+	if _, err := s.GetImport(familyID, importID); err != nil {
+		return nil, err
+	}
+
+	if input.CategoryID != 0 {
+		if _, err := s.categoryRepo.GetByIDForFamily(input.CategoryID, familyID); err != nil {
+			return nil, errors.New("categoria não encontrada")
+		}
+	}
+
+	incomeType := models.IncomeFreelance
+	if input.IncomeType != "" {
+		incomeType = models.IncomeType(input.IncomeType)
+		if err := utils.ValidateIncomeType(input.IncomeType); err != nil {
+			validationErr := err.(utils.ValidationError)
+			validationErr.Field = "income_type"
+			return nil, utils.ValidationErrors{validationErr}
+		}
+		if incomeType.IsBusiness() {
+			return nil, utils.ValidationErrors{{Field: "income_type", Message: "entradas não podem ser importadas como renda de empresa"}}
+		}
+	}
+	if input.IncomeMemberID != 0 {
+		if err := s.validateIncomeMember(familyID, input.IncomeMemberID, "income_member_id"); err != nil {
+			if validationErr, ok := err.(utils.ValidationError); ok {
+				return nil, utils.ValidationErrors{validationErr}
+			}
+			return nil, err
+		}
+	}
+
+	splits := input.Splits
+	if len(splits) == 0 {
+		defaultSplits, err := s.defaultSplits(familyID)
+		if err != nil {
+			return nil, err
+		}
+		splits = defaultSplits
+	} else if err := s.expenseService.ValidateSplits(familyID, splits); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.GetActiveByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+	rulesByID := make(map[uint]*models.CategorizationRule, len(rules))
+	for i := range rules {
+		rulesByID[rules[i].ID] = &rules[i]
+	}
+
+	var committed *models.ImportBatch
+	err = s.importRepo.UpdateWithTransaction(func(repo *repositories.ImportRepository) error {
+		batch, err := repo.GetByIDForUpdate(importID)
+		if err != nil {
+			return ErrImportNotFound
+		}
+		if batch.Status != models.ImportStatusStaged {
+			return ErrImportNotStaged
+		}
+
+		imported := 0
+		for i := range batch.Rows {
+			row := &batch.Rows[i]
+			if !row.Selected {
+				continue
+			}
+			if !row.IsDebit() {
+				if err := createImportedIncome(repo, row, input.IncomeMemberID, incomeType); err != nil {
+					return err
+				}
+				imported++
+				continue
+			}
+
+			categoryID := input.CategoryID
+			if row.CategoryID != nil {
+				categoryID = *row.CategoryID
+			}
+			if categoryID == 0 {
+				return utils.ValidationErrors{{
+					Field:   "category_id",
+					Message: fmt.Sprintf("informe a categoria padrão ou a categoria da linha %d", row.LineNumber),
+				}}
+			}
+
+			// Regra que sugeriu a categoria também define is_fixed e o modelo de divisão
+			rowSplits := splits
+			isFixed := false
+			if row.CategorizationRuleID != nil {
+				if rule, ok := rulesByID[*row.CategorizationRuleID]; ok {
+					if rule.IsFixed != nil {
+						isFixed = *rule.IsFixed
+					}
+					if len(rule.Splits) > 0 {
+						rowSplits = ruleSplits(rule)
+					}
+				}
+			}
+
+			amountCents := -row.AmountCents
+			expense := &models.Expense{
+				FamilyAccountID: familyID,
+				CategoryID:      categoryID,
+				Name:            row.Description,
+				AmountCents:     amountCents,
+				Frequency:       models.FrequencyOneTime,
+				ExpenseType:     models.ExpenseTypeExpense,
+				DueDay:          row.TransactionDate.Day(),
+				IsFixed:         isFixed,
+				IsActive:        true,
+				ReferenceMonth:  int(row.TransactionDate.Month()),
+				ReferenceYear:   row.TransactionDate.Year(),
+				RecurrenceRule:  models.RecurrenceNone,
+				Splits:          buildSplits(amountCents, rowSplits),
+			}
+			if err := repo.CreateExpense(expense); err != nil {
+				return err
+			}
+
+			row.ExpenseID = &expense.ID
+			if err := repo.UpdateRow(row); err != nil {
+				return err
+			}
+			imported++
+		}
+
+		if imported == 0 {
+			return ErrImportNothingSelected
+		}
+
+		now := time.Now()
+		batch.Status = models.ImportStatusCommitted
+		batch.ImportedCount = imported
+		batch.CommittedAt = &now
+		if err := repo.UpdateBatch(batch); err != nil {
+			return err
+		}
+
+		committed = batch
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return committed, nil
+}
+
+// defaultSplits divide igualmente entre os membros ativos da família;
+// os centésimos de porcentagem que sobram ficam com os primeiros membros
+func (s *ImportService) defaultSplits(familyID uint) ([]ExpenseSplitInput, error) {
+	members, err := s.familyRepo.GetMembers(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []models.FamilyMember{}
+	for _, member := range members {
+		if member.IsActive {
+			active = append(active, member)
+		}
+	}
+	if len(active) == 0 {
+		return nil, ErrImportNoMembers
+	}
+
+	// Trabalha em centésimos de ponto percentual (10000 = 100%)
+	base := 10000 / len(active)
+	remainder := 10000 % len(active)
+
+	splits := make([]ExpenseSplitInput, 0, len(active))
+	for i, member := range active {
+		hundredths := base
+		if i < remainder {
+			hundredths++
+		}
+		splits = append(splits, ExpenseSplitInput{
+			FamilyMemberID: member.ID,
+			Percentage:     float64(hundredths) / 100,
+		})
+	}
+	return splits, nil
+}
+
+// createImportedIncome cria a renda de uma entrada selecionada: vale só no mês da entrada, no nome do
+// membro da linha ou, sem ele, do membro padrão da confirmação
+func createImportedIncome(repo *repositories.ImportRepository, row *models.ImportRow, defaultMemberID uint, incomeType models.IncomeType) error {
+	memberID := defaultMemberID
+	if row.FamilyMemberID != nil {
+		memberID = *row.FamilyMemberID
+	}
+	if memberID == 0 {
+		return utils.ValidationErrors{{
+			Field:   "income_member_id",
+			Message: fmt.Sprintf("informe o membro padrão ou o membro da linha %d", row.LineNumber),
+		}}
+	}
+
+	month, year := int(row.TransactionDate.Month()), row.TransactionDate.Year()
+	income := &models.Income{
+		FamilyMemberID:    memberID,
+		Type:              incomeType,
+		GrossMonthlyCents: row.AmountCents,
+		NetMonthlyCents:   row.AmountCents,
+		IsActive:          true,
+		ReferenceMonth:    month,
+		ReferenceYear:     year,
+		SourceName:        row.Description,
+		EndMonth:          &month,
+		EndYear:           &year,
+	}
+	if err := repo.CreateIncome(income); err != nil {
+		return err
+	}
+
+	row.IncomeID = &income.ID
+	return repo.UpdateRow(row)
+}
+
+// validateIncomeMember verifica se o membro que recebe as entradas pertence à família
+func (s *ImportService) validateIncomeMember(familyID, memberID uint, field string) error {
+	belongs, err := s.familyRepo.MemberBelongsToFamily(memberID, familyID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return utils.ValidationError{Field: field, Message: fmt.Sprintf("membro %d não pertence a esta família", memberID)}
+	}
+	return nil
+}
+
+// UndoImport desativa as despesas e rendas criadas pelo lote e o marca como desfeito
+func (s *ImportService) UndoImport(familyID, importID uint) (*models.ImportBatch, error) {
+	if _, err := s.GetImport(familyID, importID); err != nil {
+		return nil, err
+	}
+
+	var undone *models.ImportBatch
+	err := s.importRepo.UpdateWithTransaction(func(repo *repositories.ImportRepository) error {
+		batch, err := repo.GetByIDForUpdate(importID)
+		if err != nil {
+			return ErrImportNotFound
+		}
+		if batch.Status != models.ImportStatusCommitted {
+			return ErrImportNotCommitted
+		}
+
+		if _, err := repo.DeactivateExpenses(batch.ID); err != nil {
+			return err
+		}
+		if _, err := repo.DeactivateIncomes(batch.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		batch.Status = models.ImportStatusUndone
+		batch.UndoneAt = &now
+		if err := repo.UpdateBatch(batch); err != nil {
+			return err
+		}
+
+		undone = batch
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return undone, nil
+}
+
+// DiscardImport exclui um lote que ainda está em revisão
+func (s *ImportService) DiscardImport(familyID, importID uint) error {
+	batch, err := s.GetImport(familyID, importID)
+	if err != nil {
+		return err
+	}
+	if batch.Status != models.ImportStatusStaged {
+		return ErrImportNotStaged
+	}
+	return s.importRepo.Delete(batch.ID)
+}
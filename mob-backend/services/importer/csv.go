@@ -0,0 +1,188 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"finance-backend/utils"
+)
+
+// CSVLayout descreve as colunas de um CSV de extrato.
+// As colunas podem ser indicadas pelo nome do cabeçalho ou pelo índice (0, 1, 2...).
+type CSVLayout struct {
+	Name              string `json:"name"`
+	Delimiter         string `json:"delimiter"`
+	HasHeader         bool   `json:"has_header"`
+	DateColumn        string `json:"date_column"`
+	DescriptionColumn string `json:"description_column"`
+	AmountColumn      string `json:"amount_column"`
+	DateFormat        string `json:"date_format"`       // layout Go, ex: "02/01/2006"
+	DecimalSeparator  string `json:"decimal_separator"` // "," ou "."
+	InvertSign        bool   `json:"invert_sign"`       // valores positivos são saídas (ex: fatura de cartão)
+}
+
+// Layouts CSV conhecidos
+var Layouts = map[string]CSVLayout{
+	// Fatura do cartão Nubank: date,title,amount (compras positivas)
+	"nubank": {
+		Name:              "nubank",
+		Delimiter:         ",",
+		HasHeader:         true,
+		DateColumn:        "date",
+		DescriptionColumn: "title",
+		AmountColumn:      "amount",
+		DateFormat:        "2006-01-02",
+		DecimalSeparator:  ".",
+		InvertSign:        true,
+	},
+	// Extrato da conta Nubank: Data,Valor,Identificador,Descrição
+	"nubank_conta": {
+		Name:              "nubank_conta",
+		Delimiter:         ",",
+		HasHeader:         true,
+		DateColumn:        "Data",
+		DescriptionColumn: "Descrição",
+		AmountColumn:      "Valor",
+		DateFormat:        "02/01/2006",
+		DecimalSeparator:  ".",
+	},
+	// Extrato Itaú: data;lançamento;valor (sem cabeçalho, vírgula decimal)
+	"itau": {
+		Name:              "itau",
+		Delimiter:         ";",
+		HasHeader:         false,
+		DateColumn:        "0",
+		DescriptionColumn: "1",
+		AmountColumn:      "2",
+		DateFormat:        "02/01/2006",
+		DecimalSeparator:  ",",
+	},
+}
+
+// Validate verifica se o layout tem as informações mínimas
+func (l CSVLayout) Validate() error {
+	validator := utils.NewValidator()
+	if len([]rune(l.Delimiter)) != 1 {
+		validator.AddError(utils.ValidationError{Field: "delimiter", Message: "deve ter exatamente um caractere"})
+	}
+	validator.Add(utils.ValidateRequiredString(l.DateColumn, "date_column"))
+	validator.Add(utils.ValidateRequiredString(l.DescriptionColumn, "description_column"))
+	validator.Add(utils.ValidateRequiredString(l.AmountColumn, "amount_column"))
+	validator.Add(utils.ValidateRequiredString(l.DateFormat, "date_format"))
+	if l.DecimalSeparator != "," && l.DecimalSeparator != "." {
+		validator.AddError(utils.ValidationError{Field: "decimal_separator", Message: "deve ser ',' ou '.'"})
+	}
+
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	return nil
+}
+
+// ParseCSV lê os lançamentos de um CSV conforme o layout
+func ParseCSV(content []byte, layout CSVLayout) ([]Transaction, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(toUTF8(content)))
+	reader.Comma = []rune(layout.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler CSV: %w", err)
+	}
+
+	var header []string
+	firstLine := 0
+	if layout.HasHeader {
+		if len(records) == 0 {
+			return nil, errors.New("arquivo CSV vazio")
+		}
+		header = records[0]
+		firstLine = 1
+	}
+
+	dateIndex, err := columnIndex(header, layout.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	descriptionIndex, err := columnIndex(header, layout.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIndex, err := columnIndex(header, layout.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := []Transaction{}
+	for i := firstLine; i < len(records); i++ {
+		record := records[i]
+		line := i + 1
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if dateIndex >= len(record) || descriptionIndex >= len(record) || amountIndex >= len(record) {
+			return nil, fmt.Errorf("linha %d: quantidade de colunas insuficiente", line)
+		}
+
+		date, err := time.Parse(layout.DateFormat, strings.TrimSpace(record[dateIndex]))
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: data inválida (%s)", line, record[dateIndex])
+		}
+
+		amount, err := parseAmount(record[amountIndex], layout.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: valor inválido (%s)", line, record[amountIndex])
+		}
+		if layout.InvertSign {
+			amount = -amount
+		}
+
+		transactions = append(transactions, Transaction{
+			Line:        line,
+			Date:        dateOnly(date),
+			Description: normalizeDescription(record[descriptionIndex]),
+			AmountCents: amount,
+		})
+	}
+
+	if len(transactions) == 0 {
+		return nil, errors.New("nenhum lançamento encontrado no arquivo CSV")
+	}
+
+	return transactions, nil
+}
+
+// columnIndex resolve a coluna pelo índice numérico ou pelo nome no cabeçalho (sem diferenciar maiúsculas)
+func columnIndex(header []string, column string) (int, error) {
+	if index, err := strconv.Atoi(column); err == nil && index >= 0 {
+		return index, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("coluna '%s' não encontrada no cabeçalho", column)
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxTagPattern         = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+// ParseOFX lê os lançamentos de um extrato OFX (1.x SGML ou 2.x XML)
+func ParseOFX(content []byte) ([]Transaction, error) {
+	text := toUTF8(content)
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, errors.New("arquivo OFX inválido")
+	}
+
+	transactions := []Transaction{}
+	for i, match := range ofxTransactionPattern.FindAllStringSubmatch(text, -1) {
+		fields := map[string]string{}
+		for _, tag := range ofxTagPattern.FindAllStringSubmatch(match[1], -1) {
+			fields[strings.ToUpper(tag[1])] = strings.TrimSpace(tag[2])
+		}
+
+		position := i + 1
+
+		date, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, fmt.Errorf("lançamento %d: data inválida (%s)", position, fields["DTPOSTED"])
+		}
+
+		// Alguns bancos usam vírgula como separador decimal no TRNAMT
+		separator := "."
+		if strings.Contains(fields["TRNAMT"], ",") && !strings.Contains(fields["TRNAMT"], ".") {
+			separator = ","
+		}
+		amount, err := parseAmount(fields["TRNAMT"], separator)
+		if err != nil {
+			return nil, fmt.Errorf("lançamento %d: valor inválido (%s)", position, fields["TRNAMT"])
+		}
+
+		description := fields["MEMO"]
+		if description == "" {
+			description = fields["NAME"]
+		}
+
+		transactions = append(transactions, Transaction{
+			Line:        position,
+			Date:        date,
+			Description: normalizeDescription(description),
+			AmountCents: amount,
+			ExternalID:  fields["FITID"],
+		})
+	}
+
+	if len(transactions) == 0 {
+		return nil, errors.New("nenhum lançamento encontrado no arquivo OFX")
+	}
+
+	return transactions, nil
+}
+
+// parseOFXDate interpreta datas OFX (YYYYMMDD[HHMMSS[.XXX]][[-3:BRT]]), ignorando o horário
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("data OFX inválida")
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateOnly(date), nil
+}
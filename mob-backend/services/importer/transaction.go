@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"finance-backend/utils"
+)
+
+// Transaction lançamento lido de um extrato
+type Transaction struct {
+	Line        int       // linha (CSV) ou posição (OFX) no arquivo
+	Date        time.Time // data do lançamento (UTC, sem horário)
+	Description string
+	AmountCents int64  // negativo = saída, positivo = entrada
+	ExternalID  string // identificador do banco (FITID do OFX), quando existir
+}
+
+// parseAmount converte o valor usando utils.ParseMoneyString.
+// Com separador decimal "." (ex: "1,234.56") o valor é convertido para o formato brasileiro antes.
+func parseAmount(raw, decimalSeparator string) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	if decimalSeparator == "." {
+		raw = strings.ReplaceAll(raw, ",", "")
+		raw = strings.Replace(raw, ".", ",", 1)
+	}
+	return utils.ParseMoneyString(raw)
+}
+
+// toUTF8 converte conteúdo em Latin-1/Windows-1252 (comum em extratos de bancos brasileiros)
+func toUTF8(content []byte) string {
+	if utf8.Valid(content) {
+		return strings.TrimPrefix(string(content), "\uFEFF")
+	}
+
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// dateOnly normaliza a data para meia-noite UTC
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// normalizeDescription remove espaços repetidos da descrição
+func normalizeDescription(description string) string {
+	return strings.Join(strings.Fields(description), " ")
+}
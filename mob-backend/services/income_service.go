@@ -6,132 +6,419 @@ import (
 	"finance-backend/repositories"
 	"finance-backend/services/calculation"
 	"finance-backend/utils"
+	"fmt"
 	"time"
 )
 
 type IncomeService struct {
-	incomeRepo *repositories.IncomeRepository
-	familyRepo *repositories.FamilyRepository
+	incomeRepo  *repositories.IncomeRepository
+	familyRepo  *repositories.FamilyRepository
+	taxRepo     *repositories.TaxRepository
+	revenueRepo *repositories.PJRevenueRepository
 }
 
-func NewIncomeService(incomeRepo *repositories.IncomeRepository, familyRepo *repositories.FamilyRepository) *IncomeService {
+func NewIncomeService(
+	incomeRepo *repositories.IncomeRepository,
+	familyRepo *repositories.FamilyRepository,
+	taxRepo *repositories.TaxRepository,
+	revenueRepo *repositories.PJRevenueRepository,
+) *IncomeService {
 	return &IncomeService{
-		incomeRepo: incomeRepo,
-		familyRepo: familyRepo,
+		incomeRepo:  incomeRepo,
+		familyRepo:  familyRepo,
+		taxRepo:     taxRepo,
+		revenueRepo: revenueRepo,
 	}
 }
 
-// CreateIncome cria uma nova renda usando o valor líquido informado
+// CreateIncome cria uma fonte de renda. Com SourceID, a renda é uma nova versão daquela fonte e a substitui
+// a partir do seu mês de início; as demais fontes do membro seguem valendo.
+// Informando só o bruto, o líquido é calculado (ver CalculateNetIncome)
 func (s *IncomeService) CreateIncome(income *models.Income) error {
-	// Validações
-	validator := utils.NewValidator()
+	// Vigência: sem reference_month/reference_year informados, vale a partir do mês atual
+	if income.ReferenceMonth == 0 {
+		now := time.Now()
+		income.ReferenceMonth = int(now.Month())
+		income.ReferenceYear = now.Year()
+	}
 	
-	validator.Add(utils.ValidateIncomeType(string(income.Type)))
-	validator.Add(utils.ValidatePositiveAmount(income.NetMonthlyCents, "net_monthly_cents"))
+	income.NetCalculated = income.NetMonthlyCents == 0
+	setBusinessActivity(income)
+	validator := validateIncome(income)
 	
-	if income.Type == models.IncomePJ {
-		validator.Add(utils.ValidatePercentage(income.SimplesNacionalRate, "simples_nacional_rate"))
+	if income.SourceID != nil {
+		source, err := s.incomeRepo.GetByID(*income.SourceID)
+		if err != nil || !source.IsActive || source.FamilyMemberID != income.FamilyMemberID {
+			validator.AddError(utils.ValidationError{Field: "source_id", Message: "fonte de renda não encontrada para este membro"})
+		} else {
+			sourceKey := source.SourceKey()
+			income.SourceID = &sourceKey
+		}
 	}
 	
 	if validator.HasErrors() {
 		return validator.GetErrors()
 	}
 	
-	// Se gross_monthly_cents não foi informado, usar o net_monthly_cents como base
-	if income.GrossMonthlyCents == 0 {
-		income.GrossMonthlyCents = income.NetMonthlyCents
+	if err := s.CalculateNetIncome(income); err != nil {
+		return err
 	}
 	
-	// Se reference_month e reference_year não foram informados, usar mês/ano atual
-	if income.ReferenceMonth == 0 {
-		now := time.Now()
-		income.ReferenceMonth = int(now.Month())
-		income.ReferenceYear = now.Year()
+	return s.incomeRepo.Create(income)
+}
+
+// UpdateIncome atualiza uma renda; com NetCalculated (ou NetMonthlyCents zerado) o líquido é recalculado
+// a partir do bruto
+func (s *IncomeService) UpdateIncome(income *models.Income) error {
+	if income.NetMonthlyCents == 0 {
+		income.NetCalculated = true
+	}
+	setBusinessActivity(income)
+	validator := validateIncome(income)
+	if validator.HasErrors() {
+		return validator.GetErrors()
 	}
 	
-	// Usar transação para garantir atomicidade
-	return s.incomeRepo.CreateWithTransaction(func(repo *repositories.IncomeRepository) error {
-		// Desativar outras rendas do mesmo membro (apenas uma ativa por vez)
-		if income.IsActive {
-			err := repo.DeactivateOtherIncomes(income.FamilyMemberID, 0)
-			if err != nil {
-				return err
-			}
-		}
-		
-		// Criar nova renda
-		return repo.Create(income)
-	})
+	if err := s.CalculateNetIncome(income); err != nil {
+		return err
+	}
+	
+	return s.incomeRepo.Update(income)
 }
 
-// UpdateIncome atualiza uma renda usando o valor líquido informado
-func (s *IncomeService) UpdateIncome(income *models.Income) error {
-	// Validações
+// validateIncome valida os campos comuns da criação e da edição de renda
+func validateIncome(income *models.Income) *utils.Validator {
 	validator := utils.NewValidator()
 	
 	validator.Add(utils.ValidateIncomeType(string(income.Type)))
-	validator.Add(utils.ValidatePositiveAmount(income.NetMonthlyCents, "net_monthly_cents"))
+	if income.NetCalculated {
+		// Só o bruto informado: o líquido é calculado
+		validator.Add(utils.ValidatePositiveAmount(income.GrossMonthlyCents, "gross_monthly_cents"))
+	} else {
+		validator.Add(utils.ValidatePositiveAmount(income.NetMonthlyCents, "net_monthly_cents"))
+	}
+	validator.Add(validateIncomeEnd(income))
 	
 	if income.Type == models.IncomePJ {
 		validator.Add(utils.ValidatePercentage(income.SimplesNacionalRate, "simples_nacional_rate"))
 	}
+	switch income.Type {
+	case models.IncomePJ, models.IncomeLucroPresumido:
+		validator.Add(utils.ValidateNonNegativeAmount(income.ProLaboreCents, "pro_labore_cents"))
+		if income.GrossMonthlyCents > 0 && income.ProLaboreCents > income.GrossMonthlyCents {
+			validator.AddError(utils.ValidationError{Field: "pro_labore_cents", Message: "não pode ser maior que o faturamento"})
+		}
+	case models.IncomeMEI:
+		if income.ProLaboreCents != 0 {
+			validator.AddError(utils.ValidationError{Field: "pro_labore_cents", Message: "MEI não tem pró-labore"})
+		}
+	}
+	validator.Add(validateBusinessActivity(income))
+	if income.VacationMonth != nil {
+		validator.Add(utils.ValidateRange(*income.VacationMonth, 1, 12, "vacation_month"))
+		if income.Type != models.IncomeCLT {
+			validator.AddError(utils.ValidationError{Field: "vacation_month", Message: "somente para renda CLT"})
+		}
+	}
 	
-	if validator.HasErrors() {
-		return validator.GetErrors()
+	return validator
+}
+
+// setBusinessActivity usa serviços como atividade padrão do MEI e do Lucro Presumido e limpa a atividade
+// das demais rendas
+func setBusinessActivity(income *models.Income) {
+	switch income.Type {
+	case models.IncomeMEI, models.IncomeLucroPresumido:
+		if income.BusinessActivity == "" {
+			income.BusinessActivity = models.ActivityServices
+		}
+	default:
+		income.BusinessActivity = ""
+	}
+}
+
+// validateBusinessActivity valida a atividade da empresa: o MEI pode ter comércio e serviços juntos
+func validateBusinessActivity(income *models.Income) error {
+	switch income.Type {
+	case models.IncomeMEI:
+		switch income.BusinessActivity {
+		case models.ActivityServices, models.ActivityCommerce, models.ActivityCommerceServices:
+			return nil
+		}
+		return utils.ValidationError{Field: "business_activity", Message: "deve ser servicos, comercio ou comercio_servicos"}
+	case models.IncomeLucroPresumido:
+		switch income.BusinessActivity {
+		case models.ActivityServices, models.ActivityCommerce:
+			return nil
+		}
+		return utils.ValidationError{Field: "business_activity", Message: "deve ser servicos ou comercio"}
 	}
+	return nil
+}
+
+// validateIncomeEnd valida o último mês da fonte de renda (não pode ser anterior ao início)
+func validateIncomeEnd(income *models.Income) error {
+	if income.EndMonth == nil || income.EndYear == nil {
+		return nil
+	}
+	if *income.EndYear*12+*income.EndMonth < income.ReferenceYear*12+income.ReferenceMonth {
+		return utils.ValidationError{Field: "effective_until", Message: "não pode ser anterior a effective_from"}
+	}
+	return nil
+}
+
+// CalculateNetIncome calcula os impostos da renda com as tabelas do banco vigentes no ano de referência.
+// Fontes de pessoa física consideram as outras fontes do membro vigentes no mês de início (teto único do
+// INSS, carnê-leão dos aluguéis) e os dependentes da família; nas empresas (PJ, MEI, Lucro Presumido) os
+// tributos ficam com a empresa e INSS/IRPF são retidos sobre o pró-labore. Com NetCalculated, o líquido é
+// o bruto menos os impostos mais os benefícios; com bruto e líquido informados, calcula os impostos e mantém
+// o líquido. A renda informada só pelo líquido (NetOnly) não tem base para o cálculo.
+func (s *IncomeService) CalculateNetIncome(income *models.Income) error {
+	netOnly := income.NetOnly()
 	
-	// Se gross_monthly_cents não foi informado, usar o net_monthly_cents como base
-	if income.GrossMonthlyCents == 0 {
+	income.INSSCents, income.IRPFCents, income.FGTSCents, income.CompanyTaxCents = 0, 0, 0, 0
+	income.TaxTableYear, income.IRPFDependents = 0, 0
+	income.IRPFReductionCents, income.IRPFSimplified = 0, false
+	if netOnly {
 		income.GrossMonthlyCents = income.NetMonthlyCents
+		return nil
 	}
 	
-	// Usar transação para garantir atomicidade
-	return s.incomeRepo.UpdateWithTransaction(func(repo *repositories.IncomeRepository) error {
-		// Se está ativando esta renda, desativar outras
-		if income.IsActive {
-			err := repo.DeactivateOtherIncomes(income.FamilyMemberID, income.ID)
-			if err != nil {
+	calculator := calculation.NewTaxCalculatorForReferenceYear(s.taxRepo, income.ReferenceYear)
+	totalBenefits := income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents
+	
+	others, err := s.otherEffectiveSources(income)
+	if err != nil {
+		return err
+	}
+	dependents, err := s.irpfDependents(income, others)
+	if err != nil {
+		return err
+	}
+	
+	var netCents int64
+	switch income.Type {
+	case models.IncomePJ, models.IncomeLucroPresumido:
+		// Os tributos são da empresa; INSS e IRPF são retidos do sócio sobre o pró-labore
+		var pj calculation.PJResult
+		if income.Type == models.IncomePJ {
+			if err := s.applySimplesNacional(income); err != nil {
 				return err
 			}
+			pj = calculator.CalculatePJNet(
+				income.GrossMonthlyCents,
+				income.SimplesNacionalRate,
+				income.ProLaboreCents,
+				totalBenefits,
+				dependents,
+			)
+		} else {
+			_, pj = calculator.CalculateLucroPresumidoNet(
+				income.GrossMonthlyCents,
+				income.BusinessActivity,
+				income.ProLaboreCents,
+				totalBenefits,
+				dependents,
+			)
 		}
-		
-		// Atualizar renda
-		return repo.Update(income)
-	})
+		income.CompanyTaxCents = pj.CompanyTaxCents
+		income.INSSCents = pj.ProLaboreINSSCents
+		income.IRPFCents = pj.ProLaboreIRPFCents
+		if income.ProLaboreCents > 0 {
+			income.IRPFDependents = dependents
+		}
+		netCents = pj.NetCents
+	case models.IncomeMEI:
+		// DAS fixo; o lucro do MEI é isento
+		income.CompanyTaxCents = calculation.CalculateMEIDAS(income.ReferenceYear, income.BusinessActivity).TotalCents
+		netCents = income.GrossMonthlyCents - income.CompanyTaxCents + totalBenefits
+	default:
+		taxes := calculator.CalculateSourceTaxes(income.Type, income.GrossMonthlyCents, others, dependents)
+		income.INSSCents = taxes.INSSCents
+		income.IRPFCents = taxes.IRPFCents
+		income.FGTSCents = taxes.FGTSCents
+		income.IRPFReductionCents = taxes.IRPFReductionCents
+		income.IRPFSimplified = taxes.IRPFSimplified
+		if !taxes.IRPFSimplified {
+			// No desconto simplificado os dependentes não são deduzidos e ficam para as outras fontes
+			income.IRPFDependents = dependents
+		}
+		netCents = income.GrossMonthlyCents - taxes.INSSCents - taxes.IRPFCents + totalBenefits
+	}
+	income.TaxTableYear = calculator.Year()
+	
+	if income.NetCalculated {
+		if netCents <= 0 {
+			return utils.ValidationErrors{{Field: "gross_monthly_cents", Message: "o líquido calculado não é positivo"}}
+		}
+		income.NetMonthlyCents = netCents
+	}
+	return nil
 }
 
-// CalculateNetIncome calcula o valor líquido baseado no tipo de renda
-func (s *IncomeService) CalculateNetIncome(income *models.Income) {
-	totalBenefits := income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents
+// applySimplesNacional calcula a alíquota do Simples da renda PJ com SimplesAutoRate: RBT12 e Fator R do
+// faturamento da fonte nos 12 meses anteriores ao mês de início da renda (o mês usa o bruto e o pró-labore)
+func (s *IncomeService) applySimplesNacional(income *models.Income) error {
+	income.SimplesAnnex, income.RBT12Cents, income.FatorR = "", 0, 0
+	if !income.SimplesAutoRate {
+		return nil
+	}
 	
-	if income.Type == models.IncomeCLT {
-		// Cálculo CLT
-		netCents, inssCents, fgtsCents, irpfCents := calculation.CalculateCLTNet(
-			income.GrossMonthlyCents,
-			totalBenefits,
-			0, // dependentes (pode adicionar depois)
-		)
-		
-		income.INSSCents = inssCents
-		income.FGTSCents = fgtsCents
-		income.IRPFCents = irpfCents
-		income.NetMonthlyCents = netCents
-		
-	} else if income.Type == models.IncomePJ {
-		// Cálculo PJ
-		netCents, simplesTaxCents := calculation.CalculatePJNet(
-			income.GrossMonthlyCents,
-			income.SimplesNacionalRate,
-			income.ProLaboreCents,
-			totalBenefits,
-		)
-		
-		// Para PJ, guardar o imposto no campo IRPF (reutilizando)
-		income.IRPFCents = simplesTaxCents
-		income.INSSCents = 0
-		income.FGTSCents = 0
-		income.NetMonthlyCents = netCents
+	var history []calculation.SimplesRevenue
+	if income.ID != 0 || income.SourceID != nil {
+		monthIndex := income.ReferenceYear*12 + income.ReferenceMonth - 1
+		revenues, err := s.revenueRepo.GetBySourceIDBetween(income.SourceKey(), monthIndex-12, monthIndex-1)
+		if err != nil {
+			return err
+		}
+		for _, revenue := range revenues {
+			history = append(history, calculation.SimplesRevenue{RevenueCents: revenue.RevenueCents, PayrollCents: revenue.PayrollCents})
+		}
+	}
+	
+	simples := calculation.CalculateSimplesNacional(income.GrossMonthlyCents, income.ProLaboreCents, history)
+	income.SimplesNacionalRate = simples.EffectiveRate
+	income.SimplesAnnex = simples.Annex
+	income.RBT12Cents = simples.RBT12Cents
+	income.FatorR = simples.FatorR
+	return nil
+}
+
+// GetRevenues retorna o histórico de faturamento da fonte de uma renda PJ ou MEI (mais recente primeiro)
+func (s *IncomeService) GetRevenues(income *models.Income) ([]models.PJMonthlyRevenue, error) {
+	return s.revenueRepo.GetBySourceID(income.SourceKey())
+}
+
+// SetRevenue grava o faturamento e a folha da fonte PJ ou MEI no mês. Com alíquota automática, a alíquota e o
+// líquido da renda são recalculados
+func (s *IncomeService) SetRevenue(income *models.Income, month, year int, revenueCents, payrollCents int64) (*models.PJMonthlyRevenue, error) {
+	validator := utils.NewValidator()
+	if income.Type != models.IncomePJ && income.Type != models.IncomeMEI {
+		validator.AddError(utils.ValidationError{Field: "income_id", Message: "o faturamento só pode ser informado em renda PJ ou MEI"})
+	}
+	validator.Add(utils.ValidateNonNegativeAmount(revenueCents, "revenue_cents"))
+	validator.Add(utils.ValidateNonNegativeAmount(payrollCents, "payroll_cents"))
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+	
+	revenue := &models.PJMonthlyRevenue{
+		IncomeSourceID: income.SourceKey(),
+		Year:           year,
+		Month:          month,
+		RevenueCents:   revenueCents,
+		PayrollCents:   payrollCents,
+	}
+	if err := s.revenueRepo.Upsert(revenue); err != nil {
+		return nil, err
+	}
+	
+	if err := s.recalculateSimples(income); err != nil {
+		return nil, err
+	}
+	return revenue, nil
+}
+
+// DeleteRevenue remove o faturamento da fonte PJ no mês e recalcula a alíquota automática da renda
+func (s *IncomeService) DeleteRevenue(income *models.Income, month, year int) (bool, error) {
+	deleted, err := s.revenueRepo.Delete(income.SourceKey(), month, year)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+	return true, s.recalculateSimples(income)
+}
+
+// meiCeiling soma o faturamento do MEI no ano atual (limitado à vigência da renda): meses com faturamento
+// informado usam o valor informado; os demais meses da vigência, o bruto da renda
+func (s *IncomeService) meiCeiling(income *models.Income) (calculation.MEICeiling, int, error) {
+	year := time.Now().Year()
+	if year < income.ReferenceYear {
+		year = income.ReferenceYear
+	}
+	if income.EndYear != nil && year > *income.EndYear {
+		year = *income.EndYear
+	}
+	
+	// Mês de abertura: início da primeira versão da fonte
+	start := income
+	if income.SourceID != nil {
+		source, err := s.incomeRepo.GetByID(*income.SourceID)
+		if err != nil {
+			return calculation.MEICeiling{}, 0, err
+		}
+		start = source
+	}
+	firstMonth, lastMonth := 1, 12
+	if start.ReferenceYear == year {
+		firstMonth = start.ReferenceMonth
+	}
+	if income.EndYear != nil && *income.EndYear == year {
+		lastMonth = *income.EndMonth
+	}
+	
+	var monthly [12]int64
+	for month := firstMonth; month <= lastMonth; month++ {
+		monthly[month-1] = income.GrossMonthlyCents
+	}
+	revenues, err := s.revenueRepo.GetBySourceIDBetween(income.SourceKey(), year*12, year*12+11)
+	if err != nil {
+		return calculation.MEICeiling{}, 0, err
+	}
+	for _, revenue := range revenues {
+		monthly[revenue.Month-1] = revenue.RevenueCents
+	}
+	
+	return calculation.CheckMEICeiling(monthly, firstMonth), year, nil
+}
+
+func (s *IncomeService) recalculateSimples(income *models.Income) error {
+	if !income.SimplesAutoRate {
+		return nil
+	}
+	
+	income.NetCalculated = true
+	if err := s.CalculateNetIncome(income); err != nil {
+		return err
+	}
+	return s.incomeRepo.Update(income)
+}
+
+// otherEffectiveSources busca as outras fontes do membro vigentes no mês de início da renda.
+// A própria fonte (versão anterior ou a renda sendo editada) não entra
+func (s *IncomeService) otherEffectiveSources(income *models.Income) ([]models.Income, error) {
+	effective, err := s.incomeRepo.GetEffectiveByMemberID(income.FamilyMemberID, income.ReferenceMonth, income.ReferenceYear)
+	if err != nil {
+		return nil, err
+	}
+	
+	others := make([]models.Income, 0, len(effective))
+	for _, other := range effective {
+		if other.SourceKey() == income.SourceKey() {
+			continue
+		}
+		others = append(others, other)
 	}
+	return others, nil
+}
+
+// irpfDependents conta os dependentes deduzidos no IRPF da fonte: os membros ativos da família com papel
+// dependent que têm o membro como declarante. São deduzidos uma única vez por membro (na fonte que já os
+// deduz) e um dependente com renda própria não deduz os demais
+func (s *IncomeService) irpfDependents(income *models.Income, others []models.Income) (int, error) {
+	for _, other := range others {
+		if other.IRPFDependents > 0 {
+			return 0, nil
+		}
+	}
+	
+	member, err := s.familyRepo.GetMemberByID(income.FamilyMemberID)
+	if err != nil {
+		return 0, err
+	}
+	if member.Role == models.RoleDependent {
+		return 0, nil
+	}
+	return s.familyRepo.CountDependentsDeclaredBy(member.FamilyAccountID, member.ID)
 }
 
 // GetIncomeByID busca renda por ID
@@ -139,17 +426,17 @@ func (s *IncomeService) GetIncomeByID(id uint) (*models.Income, error) {
 	return s.incomeRepo.GetByID(id)
 }
 
-// GetIncomesByMemberID busca rendas de um membro
+// GetIncomesByMemberID busca o histórico de rendas de um membro (mais recente primeiro)
 func (s *IncomeService) GetIncomesByMemberID(memberID uint) ([]models.Income, error) {
 	return s.incomeRepo.GetByMemberID(memberID)
 }
 
-// GetIncomesByFamilyID busca rendas de uma família
+// GetIncomesByFamilyID busca as rendas da família vigentes no mês corrente
 func (s *IncomeService) GetIncomesByFamilyID(familyID uint) ([]models.Income, error) {
 	return s.incomeRepo.GetByFamilyID(familyID)
 }
 
-// GetIncomesByFamilyIDAndMonth busca rendas de uma família filtradas por mês/ano
+// GetIncomesByFamilyIDAndMonth busca as rendas da família vigentes no mês/ano informado
 func (s *IncomeService) GetIncomesByFamilyIDAndMonth(familyID uint, month, year int) ([]models.Income, error) {
 	return s.incomeRepo.GetByFamilyIDAndMonth(familyID, month, year)
 }
@@ -175,22 +462,93 @@ func (s *IncomeService) GetIncomeBreakdown(id uint) (*IncomeBreakdown, error) {
 		Income:       income,
 		GrossAmount:  utils.CentsToFloat(income.GrossMonthlyCents),
 		NetAmount:    utils.CentsToFloat(income.NetMonthlyCents),
-		TotalTax:     utils.CentsToFloat(income.INSSCents + income.IRPFCents),
+		TotalTax:     utils.CentsToFloat(income.TaxCents()),
 		Benefits:     utils.CentsToFloat(income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents),
+		TaxYear:      income.TaxTableYear,
+		Dependents:   income.IRPFDependents,
+	}
+	if !income.Type.IsBusiness() && income.TaxTableYear > 0 {
+		breakdown.IRPFReduction = utils.CentsToFloat(income.IRPFReductionCents)
+		breakdown.IRPFDeduction = "legal"
+		if income.IRPFSimplified {
+			breakdown.IRPFDeduction = "simplificado"
+		}
 	}
 	
-	if income.Type == models.IncomeCLT {
+	switch income.Type {
+	case models.IncomeCLT:
 		breakdown.Taxes = map[string]float64{
 			"INSS": utils.CentsToFloat(income.INSSCents),
 			"IRPF": utils.CentsToFloat(income.IRPFCents),
 			"FGTS": utils.CentsToFloat(income.FGTSCents),
 		}
-	} else {
+	case models.IncomeFreelance:
 		breakdown.Taxes = map[string]float64{
-			"Simples Nacional": utils.CentsToFloat(income.IRPFCents),
+			"INSS": utils.CentsToFloat(income.INSSCents),
+			"IRPF": utils.CentsToFloat(income.IRPFCents),
 		}
-		if income.ProLaboreCents > 0 {
-			breakdown.Taxes["Pró-labore"] = utils.CentsToFloat(income.ProLaboreCents)
+	case models.IncomeAluguel:
+		breakdown.Taxes = map[string]float64{
+			"IRPF (carnê-leão)": utils.CentsToFloat(income.IRPFCents),
+		}
+	case models.IncomeAposentadoria, models.IncomePensao:
+		breakdown.Taxes = map[string]float64{
+			"IRPF": utils.CentsToFloat(income.IRPFCents),
+		}
+	case models.IncomeMEI:
+		das := calculation.CalculateMEIDAS(income.ReferenceYear, income.BusinessActivity)
+		breakdown.Taxes = map[string]float64{
+			"DAS-MEI": utils.CentsToFloat(income.CompanyTaxCents),
+		}
+		ceiling, year, err := s.meiCeiling(income)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.MEI = &MEIBreakdown{
+			Activity:      income.BusinessActivity,
+			DASINSS:       utils.CentsToFloat(das.INSSCents),
+			DASICMS:       utils.CentsToFloat(das.ICMSCents),
+			DASISS:        utils.CentsToFloat(das.ISSCents),
+			Year:          year,
+			AnnualRevenue: utils.CentsToFloat(ceiling.RevenueCents),
+			AnnualCeiling: utils.CentsToFloat(ceiling.CeilingCents),
+		}
+		switch {
+		case ceiling.ToleranceExceeded:
+			breakdown.Warnings = append(breakdown.Warnings, fmt.Sprintf(
+				"Faturamento de %s em %d passa o limite do MEI (%s) em mais de 20%%: o desenquadramento retroage ao início do ano",
+				utils.FormatMoney(ceiling.RevenueCents), year, utils.FormatMoney(ceiling.CeilingCents)))
+		case ceiling.Exceeded:
+			breakdown.Warnings = append(breakdown.Warnings, fmt.Sprintf(
+				"Faturamento de %s em %d passa o limite do MEI (%s): o excesso paga DAS complementar e a empresa deixa o MEI no ano seguinte",
+				utils.FormatMoney(ceiling.RevenueCents), year, utils.FormatMoney(ceiling.CeilingCents)))
+		}
+	case models.IncomeLucroPresumido:
+		taxes := calculation.CalculateLucroPresumido(income.GrossMonthlyCents, income.BusinessActivity, income.ProLaboreCents)
+		breakdown.Taxes = map[string]float64{
+			"PIS":                        utils.CentsToFloat(taxes.PISCents),
+			"COFINS":                     utils.CentsToFloat(taxes.COFINSCents),
+			"IRPJ":                       utils.CentsToFloat(taxes.IRPJCents),
+			"CSLL":                       utils.CentsToFloat(taxes.CSLLCents),
+			"INSS patronal (pró-labore)": utils.CentsToFloat(taxes.EmployerINSSCents),
+			"INSS (pró-labore)":          utils.CentsToFloat(income.INSSCents),
+			"IRPF (pró-labore)":          utils.CentsToFloat(income.IRPFCents),
+		}
+		breakdown.PJ = newPJBreakdown(income)
+	default:
+		breakdown.Taxes = map[string]float64{
+			"Simples Nacional (DAS)": utils.CentsToFloat(income.CompanyTaxCents),
+			"INSS (pró-labore)":      utils.CentsToFloat(income.INSSCents),
+			"IRPF (pró-labore)":      utils.CentsToFloat(income.IRPFCents),
+		}
+		breakdown.PJ = newPJBreakdown(income)
+		if income.SimplesAutoRate {
+			breakdown.Simples = &SimplesBreakdown{
+				Annex:         income.SimplesAnnex,
+				RBT12:         utils.CentsToFloat(income.RBT12Cents),
+				FatorR:        income.FatorR,
+				EffectiveRate: income.SimplesNacionalRate,
+			}
 		}
 	}
 	
@@ -205,10 +563,89 @@ type IncomeBreakdown struct {
 	TotalTax    float64            `json:"total_tax"`
 	Benefits    float64            `json:"benefits"`
 	Taxes       map[string]float64 `json:"taxes"`
+	TaxYear     int                `json:"tax_year,omitempty"`   // ano da tabela de impostos usada
+	Dependents  int                `json:"dependents,omitempty"` // dependentes deduzidos no IRPF
+	
+	// IRPF de pessoa física: redução mensal aplicada e dedução usada (legal ou simplificado)
+	IRPFReduction float64 `json:"irpf_reduction,omitempty"`
+	IRPFDeduction string  `json:"irpf_deduction,omitempty"`
+	
+	// PJ e Lucro Presumido: empresa e sócio separados, e a alíquota automática do Simples Nacional
+	PJ      *PJBreakdown      `json:"pj,omitempty"`
+	Simples *SimplesBreakdown `json:"simples,omitempty"`
+	
+	// MEI: composição do DAS e faturamento do ano contra o limite
+	MEI *MEIBreakdown `json:"mei,omitempty"`
+	
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MEIBreakdown DAS do MEI e faturamento do ano (informado ou projetado) contra o limite
+type MEIBreakdown struct {
+	Activity      string  `json:"activity"`
+	DASINSS       float64 `json:"das_inss"`
+	DASICMS       float64 `json:"das_icms"`
+	DASISS        float64 `json:"das_iss"`
+	Year          int     `json:"year"`
+	AnnualRevenue float64 `json:"annual_revenue"`
+	AnnualCeiling float64 `json:"annual_ceiling"`
+}
+
+// PJBreakdown renda da empresa (PJ ou Lucro Presumido) no mês vista pela empresa e pelo sócio
+type PJBreakdown struct {
+	Company PJCompanyBreakdown `json:"company"`
+	Person  PJPersonBreakdown  `json:"person"`
 }
 
-// GetFamilyIncomeSummary retorna resumo de todas as rendas da família
-// Se month e year forem fornecidos (> 0), filtra por mês específico
+// PJCompanyBreakdown faturamento da empresa e o que ela paga: tributos (DAS ou Lucro Presumido),
+// pró-labore (com INSS e IRPF retidos) e lucros distribuídos
+type PJCompanyBreakdown struct {
+	Revenue            float64 `json:"revenue"`
+	CompanyTax         float64 `json:"company_tax"`
+	ProLabore          float64 `json:"pro_labore"`
+	INSSRetained       float64 `json:"inss_retained"`
+	IRPFRetained       float64 `json:"irpf_retained"`
+	ProfitDistribution float64 `json:"profit_distribution"`
+}
+
+// PJPersonBreakdown o que o sócio recebe: pró-labore líquido e lucros distribuídos (isentos)
+type PJPersonBreakdown struct {
+	ProLaboreNet       float64 `json:"pro_labore_net"`
+	ProfitDistribution float64 `json:"profit_distribution"`
+	Benefits           float64 `json:"benefits"`
+	Net                float64 `json:"net"`
+}
+
+func newPJBreakdown(income *models.Income) *PJBreakdown {
+	profitDistribution := utils.CentsToFloat(income.GrossMonthlyCents - income.CompanyTaxCents - income.ProLaboreCents)
+	return &PJBreakdown{
+		Company: PJCompanyBreakdown{
+			Revenue:            utils.CentsToFloat(income.GrossMonthlyCents),
+			CompanyTax:         utils.CentsToFloat(income.CompanyTaxCents),
+			ProLabore:          utils.CentsToFloat(income.ProLaboreCents),
+			INSSRetained:       utils.CentsToFloat(income.INSSCents),
+			IRPFRetained:       utils.CentsToFloat(income.IRPFCents),
+			ProfitDistribution: profitDistribution,
+		},
+		Person: PJPersonBreakdown{
+			ProLaboreNet:       utils.CentsToFloat(income.ProLaboreCents - income.INSSCents - income.IRPFCents),
+			ProfitDistribution: profitDistribution,
+			Benefits:           utils.CentsToFloat(income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents),
+			Net:                utils.CentsToFloat(income.NetMonthlyCents),
+		},
+	}
+}
+
+// SimplesBreakdown alíquota do Simples Nacional calculada pelo RBT12 e pelo Fator R
+type SimplesBreakdown struct {
+	Annex         string  `json:"annex"` // III ou V
+	RBT12         float64 `json:"rbt12"`
+	FatorR        float64 `json:"fator_r"`
+	EffectiveRate float64 `json:"effective_rate"` // em %
+}
+
+// GetFamilyIncomeSummary retorna resumo das rendas da família vigentes no mês
+// Se month e year forem fornecidos (> 0), usa o mês específico; senão, o mês corrente
 func (s *IncomeService) GetFamilyIncomeSummary(familyID uint, month, year int) (*FamilyIncomeSummary, error) {
 	var incomes []models.Income
 	var err error
@@ -217,7 +654,7 @@ func (s *IncomeService) GetFamilyIncomeSummary(familyID uint, month, year int) (
 	if month > 0 && year > 0 {
 		incomes, err = s.incomeRepo.GetByFamilyIDAndMonth(familyID, month, year)
 	} else {
-		// Caso contrário, buscar todos
+		// Caso contrário, rendas vigentes no mês corrente
 		incomes, err = s.incomeRepo.GetByFamilyID(familyID)
 	}
 	
@@ -234,24 +671,52 @@ func (s *IncomeService) GetFamilyIncomeSummary(familyID uint, month, year int) (
 		}, nil
 	}
 	
+	// Fontes agrupadas por membro; as rendas vêm ordenadas por membro e bruto (a primeira é a principal)
+	type memberTotals struct {
+		gross, net, tax int64
+	}
 	var totalGross, totalNet, totalTax int64
 	memberIncomes := []MemberIncome{}
+	totals := []memberTotals{}
 	
 	for _, income := range incomes {
+		tax := income.TaxCents()
 		totalGross += income.GrossMonthlyCents
 		totalNet += income.NetMonthlyCents
-		totalTax += income.INSSCents + income.IRPFCents
+		totalTax += tax
+		
+		last := len(memberIncomes) - 1
+		if last < 0 || memberIncomes[last].MemberID != income.FamilyMemberID {
+			memberIncomes = append(memberIncomes, MemberIncome{
+				MemberID:   income.FamilyMemberID,
+				MemberName: income.FamilyMember.Name,
+				Type:       string(income.Type),
+				Sources:    []MemberIncomeSource{},
+			})
+			totals = append(totals, memberTotals{})
+			last++
+		}
 		
-		memberIncomes = append(memberIncomes, MemberIncome{
-			MemberID:   income.FamilyMemberID,
-			MemberName: income.FamilyMember.Name,
+		totals[last].gross += income.GrossMonthlyCents
+		totals[last].net += income.NetMonthlyCents
+		totals[last].tax += tax
+		memberIncomes[last].Sources = append(memberIncomes[last].Sources, MemberIncomeSource{
+			IncomeID:   income.ID,
+			SourceID:   income.SourceKey(),
+			SourceName: income.SourceName,
 			Type:       string(income.Type),
 			Gross:      utils.CentsToFloat(income.GrossMonthlyCents),
 			Net:        utils.CentsToFloat(income.NetMonthlyCents),
-			Tax:        utils.CentsToFloat(income.INSSCents + income.IRPFCents),
+			Tax:        utils.CentsToFloat(tax),
 		})
 	}
 	
+	for i := range memberIncomes {
+		memberIncomes[i].Gross = utils.CentsToFloat(totals[i].gross)
+		memberIncomes[i].Net = utils.CentsToFloat(totals[i].net)
+		memberIncomes[i].Tax = utils.CentsToFloat(totals[i].tax)
+	}
+	
 	return &FamilyIncomeSummary{
 		TotalGross: utils.CentsToFloat(totalGross),
 		TotalNet:   utils.CentsToFloat(totalNet),
@@ -267,9 +732,22 @@ type FamilyIncomeSummary struct {
 	Members    []MemberIncome `json:"members"`
 }
 
+// MemberIncome totais do membro somando todas as fontes de renda vigentes
 type MemberIncome struct {
-	MemberID   uint    `json:"member_id"`
-	MemberName string  `json:"member_name"`
+	MemberID   uint                 `json:"member_id"`
+	MemberName string               `json:"member_name"`
+	Type       string               `json:"type"` // tipo da principal fonte (maior bruto)
+	Gross      float64              `json:"gross"`
+	Net        float64              `json:"net"`
+	Tax        float64              `json:"tax"`
+	Sources    []MemberIncomeSource `json:"sources"`
+}
+
+// MemberIncomeSource uma fonte de renda vigente do membro
+type MemberIncomeSource struct {
+	IncomeID   uint    `json:"income_id"`
+	SourceID   uint    `json:"source_id"`
+	SourceName string  `json:"source_name"`
 	Type       string  `json:"type"`
 	Gross      float64 `json:"gross"`
 	Net        float64 `json:"net"`
@@ -303,3 +781,284 @@ func (s *IncomeService) ValidateMemberBelongsToFamily(memberID, familyID uint) e
 	
 	return nil
 }
+
+// GetAnnualIncomeProjection projeta a renda da família mês a mês no ano, com as versões das fontes vigentes
+// em cada mês. Rendas CLT incluem o 1/3 de férias no mês de férias e as parcelas do 13º com os próprios
+// descontos (ver calculation.CalculateCLTAnnual); as demais fontes repetem o valor mensal vigente
+func (s *IncomeService) GetAnnualIncomeProjection(familyID uint, year int) (*AnnualIncomeProjection, error) {
+	sources, err := s.sourcesInYear(familyID, year)
+	if err != nil {
+		return nil, err
+	}
+	
+	calculator := calculation.NewTaxCalculatorForReferenceYear(s.taxRepo, year)
+	projection := &AnnualIncomeProjection{
+		Year:    year,
+		Months:  make([]AnnualIncomeMonth, 12),
+		Sources: []AnnualIncomeSource{},
+	}
+	var totalGross, totalNet int64
+	var monthGross, monthNet, monthThirteenth, monthVacationBonus [12]int64
+	
+	for _, source := range sources {
+		// Meses CLT passam pelo calculador anual; os demais usam os valores mensais da versão vigente
+		input := source.cltAnnualInput()
+		annual := calculator.CalculateCLTAnnual(input)
+		
+		item := AnnualIncomeSource{
+			SourceID:      source.key,
+			SourceName:    source.latest.SourceName,
+			MemberID:      source.latest.FamilyMemberID,
+			MemberName:    source.latest.FamilyMember.Name,
+			Type:          string(source.latest.Type),
+			VacationMonth: input.VacationMonth,
+			Thirteenth:    utils.CentsToFloat(annual.ThirteenthCents),
+			VacationBonus: utils.CentsToFloat(annual.VacationBonusCents),
+			Months:        make([]AnnualIncomeSourceMonth, 0, 12),
+		}
+		var sourceGross, sourceTax, sourceNet int64
+		for i, income := range source.incomes {
+			month := annual.Months[i]
+			var companyTax int64
+			if income != nil && income.Type != models.IncomeCLT {
+				companyTax = income.CompanyTaxCents
+				month = calculation.CLTAnnualMonth{
+					Month:         i + 1,
+					SalaryCents:   income.GrossMonthlyCents,
+					BenefitsCents: income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents,
+					INSSCents:     income.INSSCents,
+					IRPFCents:     income.IRPFCents,
+					FGTSCents:     income.FGTSCents,
+					NetCents:      income.NetMonthlyCents,
+				}
+			}
+			
+			gross := month.SalaryCents + month.VacationBonusCents + month.ThirteenthCents
+			sourceGross += gross
+			sourceTax += month.INSSCents + month.IRPFCents + companyTax
+			sourceNet += month.NetCents
+			monthGross[i] += gross
+			monthNet[i] += month.NetCents
+			monthThirteenth[i] += month.ThirteenthCents
+			monthVacationBonus[i] += month.VacationBonusCents
+			
+			item.Months = append(item.Months, AnnualIncomeSourceMonth{
+				Month:         i + 1,
+				Salary:        utils.CentsToFloat(month.SalaryCents),
+				VacationBonus: utils.CentsToFloat(month.VacationBonusCents),
+				Thirteenth:    utils.CentsToFloat(month.ThirteenthCents),
+				Benefits:      utils.CentsToFloat(month.BenefitsCents),
+				INSS:          utils.CentsToFloat(month.INSSCents),
+				IRPF:          utils.CentsToFloat(month.IRPFCents),
+				CompanyTax:    utils.CentsToFloat(companyTax),
+				FGTS:          utils.CentsToFloat(month.FGTSCents),
+				Net:           utils.CentsToFloat(month.NetCents),
+			})
+		}
+		item.TotalGross = utils.CentsToFloat(sourceGross)
+		item.TotalTax = utils.CentsToFloat(sourceTax)
+		item.TotalNet = utils.CentsToFloat(sourceNet)
+		totalGross += sourceGross
+		totalNet += sourceNet
+		projection.Sources = append(projection.Sources, item)
+	}
+	
+	for i := range projection.Months {
+		projection.Months[i] = AnnualIncomeMonth{
+			Month:         i + 1,
+			Gross:         utils.CentsToFloat(monthGross[i]),
+			Net:           utils.CentsToFloat(monthNet[i]),
+			Thirteenth:    utils.CentsToFloat(monthThirteenth[i]),
+			VacationBonus: utils.CentsToFloat(monthVacationBonus[i]),
+		}
+	}
+	projection.TotalGross = utils.CentsToFloat(totalGross)
+	projection.TotalNet = utils.CentsToFloat(totalNet)
+	
+	return projection, nil
+}
+
+// sourceYear versões de uma fonte de renda vigentes em cada mês de um ano
+type sourceYear struct {
+	key     uint
+	latest  models.Income // versão vigente no último mês do ano em que a fonte aparece
+	incomes [12]*models.Income
+}
+
+// sourcesInYear agrupa por fonte as rendas da família vigentes em cada mês do ano
+func (s *IncomeService) sourcesInYear(familyID uint, year int) ([]*sourceYear, error) {
+	byKey := map[uint]*sourceYear{}
+	sources := []*sourceYear{}
+	
+	months, err := s.incomeRepo.GetByFamilyIDForYear(familyID, year)
+	if err != nil {
+		return nil, err
+	}
+	for month := 1; month <= 12; month++ {
+		incomes := months[month-1]
+		for i := range incomes {
+			key := incomes[i].SourceKey()
+			source, ok := byKey[key]
+			if !ok {
+				source = &sourceYear{key: key}
+				byKey[key] = source
+				sources = append(sources, source)
+			}
+			source.incomes[month-1] = &incomes[i]
+			source.latest = incomes[i]
+		}
+	}
+	return sources, nil
+}
+
+// cltAnnualInput meses CLT da fonte para o calculador anual (13º e férias)
+func (source *sourceYear) cltAnnualInput() calculation.CLTAnnualInput {
+	input := calculation.CLTAnnualInput{Dependents: source.latest.IRPFDependents}
+	if source.latest.VacationMonth != nil {
+		input.VacationMonth = *source.latest.VacationMonth
+	}
+	for i, income := range source.incomes {
+		if income != nil && income.Type == models.IncomeCLT {
+			input.MonthlyGrossCents[i] = income.GrossMonthlyCents
+			input.MonthlyBenefitsCents[i] = income.FoodVoucherCents + income.TransportVoucherCents + income.BonusCents
+		}
+	}
+	return input
+}
+
+// GetMemberAnnualTaxes soma os rendimentos e impostos do membro no ano como entram na declaração de ajuste:
+//
+//   - CLT: salários e 1/3 de férias são tributáveis; o 13º (e seus INSS e IRPF) é de tributação exclusiva
+//   - PJ e Lucro Presumido: o pró-labore é tributável e os lucros distribuídos são isentos
+//   - MEI: o lucro (faturamento - DAS) entra como isento
+//   - freelance, aluguel, aposentadoria e pensão: o bruto é tributável
+func (s *IncomeService) GetMemberAnnualTaxes(familyID, memberID uint, year int) (*MemberAnnualTaxes, error) {
+	sources, err := s.sourcesInYear(familyID, year)
+	if err != nil {
+		return nil, err
+	}
+	
+	calculator := calculation.NewTaxCalculatorForReferenceYear(s.taxRepo, year)
+	result := &MemberAnnualTaxes{Sources: []MemberAnnualSource{}}
+	for _, source := range sources {
+		if source.latest.FamilyMemberID != memberID {
+			continue
+		}
+		
+		annual := calculator.CalculateCLTAnnual(source.cltAnnualInput())
+		item := MemberAnnualSource{
+			SourceID:   source.key,
+			SourceName: source.latest.SourceName,
+			Type:       source.latest.Type,
+		}
+		for i, income := range source.incomes {
+			if income == nil {
+				continue
+			}
+			switch income.Type {
+			case models.IncomeCLT:
+				month := annual.Months[i]
+				item.TaxableCents += month.SalaryCents + month.VacationBonusCents
+				item.INSSCents += month.INSSCents
+				item.WithheldIRPFCents += month.IRPFCents
+			case models.IncomePJ, models.IncomeLucroPresumido:
+				item.TaxableCents += income.ProLaboreCents
+				item.INSSCents += income.INSSCents
+				item.WithheldIRPFCents += income.IRPFCents
+				if profit := income.GrossMonthlyCents - income.CompanyTaxCents - income.ProLaboreCents; profit > 0 {
+					item.ExemptCents += profit
+				}
+			case models.IncomeMEI:
+				item.ExemptCents += income.GrossMonthlyCents - income.CompanyTaxCents
+			default:
+				item.TaxableCents += income.GrossMonthlyCents
+				item.INSSCents += income.INSSCents
+				item.WithheldIRPFCents += income.IRPFCents
+			}
+		}
+		
+		// O 13º entra na parcela final com os próprios descontos: sai do ajuste anual
+		item.ExclusiveCents = annual.ThirteenthCents
+		item.INSSCents -= annual.ThirteenthINSSCents
+		item.WithheldIRPFCents -= annual.ThirteenthIRPFCents
+		
+		result.TaxableCents += item.TaxableCents
+		result.ExemptCents += item.ExemptCents
+		result.ExclusiveCents += item.ExclusiveCents
+		result.INSSCents += item.INSSCents
+		result.WithheldIRPFCents += item.WithheldIRPFCents
+		result.Sources = append(result.Sources, item)
+	}
+	
+	return result, nil
+}
+
+// MemberAnnualTaxes rendimentos e impostos de um membro no ano (em centavos)
+type MemberAnnualTaxes struct {
+	TaxableCents      int64
+	ExemptCents       int64 // lucros distribuídos e lucro do MEI
+	ExclusiveCents    int64 // 13º salário
+	INSSCents         int64
+	WithheldIRPFCents int64
+	Sources           []MemberAnnualSource
+}
+
+// MemberAnnualSource totais de uma fonte do membro no ano (em centavos)
+type MemberAnnualSource struct {
+	SourceID          uint
+	SourceName        string
+	Type              models.IncomeType
+	TaxableCents      int64
+	ExemptCents       int64
+	ExclusiveCents    int64
+	INSSCents         int64
+	WithheldIRPFCents int64
+}
+
+// AnnualIncomeProjection renda da família mês a mês em um ano
+type AnnualIncomeProjection struct {
+	Year       int                  `json:"year"`
+	TotalGross float64              `json:"total_gross"`
+	TotalNet   float64              `json:"total_net"`
+	Months     []AnnualIncomeMonth  `json:"months"`
+	Sources    []AnnualIncomeSource `json:"sources"`
+}
+
+// AnnualIncomeMonth totais da família em um mês da projeção
+type AnnualIncomeMonth struct {
+	Month         int     `json:"month"`
+	Gross         float64 `json:"gross"`
+	Net           float64 `json:"net"`
+	Thirteenth    float64 `json:"thirteenth"`     // parcelas do 13º pagas no mês
+	VacationBonus float64 `json:"vacation_bonus"` // 1/3 de férias pago no mês
+}
+
+// AnnualIncomeSource projeção anual de uma fonte de renda
+type AnnualIncomeSource struct {
+	SourceID      uint                      `json:"source_id"`
+	SourceName    string                    `json:"source_name"`
+	MemberID      uint                      `json:"member_id"`
+	MemberName    string                    `json:"member_name"`
+	Type          string                    `json:"type"`
+	VacationMonth int                       `json:"vacation_month,omitempty"`
+	Thirteenth    float64                   `json:"thirteenth"` // 13º bruto do ano (CLT)
+	VacationBonus float64                   `json:"vacation_bonus"`
+	TotalGross    float64                   `json:"total_gross"`
+	TotalTax      float64                   `json:"total_tax"`
+	TotalNet      float64                   `json:"total_net"`
+	Months        []AnnualIncomeSourceMonth `json:"months"`
+}
+
+// AnnualIncomeSourceMonth valores de uma fonte em um mês da projeção
+type AnnualIncomeSourceMonth struct {
+	Month         int     `json:"month"`
+	Salary        float64 `json:"salary"` // bruto mensal (salário ou remuneração das férias)
+	VacationBonus float64 `json:"vacation_bonus"`
+	Thirteenth    float64 `json:"thirteenth"`
+	Benefits      float64 `json:"benefits"`
+	INSS          float64 `json:"inss"`
+	IRPF          float64 `json:"irpf"` // na parcela final do 13º inclui o IRPF exclusivo do 13º
+	CompanyTax    float64 `json:"company_tax,omitempty"` // tributos da empresa (PJ, MEI, Lucro Presumido)
+	FGTS          float64 `json:"fgts"`
+	Net           float64 `json:"net"`
+}
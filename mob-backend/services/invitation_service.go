@@ -0,0 +1,207 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+// Validade dos convites
+const (
+	defaultInvitationTTLHours = 168 // 7 dias
+	maxInvitationTTLHours     = 720 // 30 dias
+)
+
+var (
+	ErrInvitationNotFound      = errors.New("convite não encontrado")
+	ErrInvitationNotPending    = errors.New("convite já foi aceito, revogado ou expirou")
+	ErrInvitationEmailMismatch = errors.New("este convite foi enviado para outro email")
+	ErrAlreadyFamilyMember     = errors.New("você já faz parte desta família")
+)
+
+type InvitationService struct {
+	invitationRepo *repositories.InvitationRepository
+	familyRepo     *repositories.FamilyRepository
+	userRepo       *repositories.UserRepository
+}
+
+func NewInvitationService(
+	invitationRepo *repositories.InvitationRepository,
+	familyRepo *repositories.FamilyRepository,
+	userRepo *repositories.UserRepository,
+) *InvitationService {
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		familyRepo:     familyRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateInvitationInput dados para criação de convite
+type CreateInvitationInput struct {
+	Role           models.MemberRole
+	Email          string
+	FamilyMemberID *uint
+	ExpiresInHours int
+}
+
+// CreateInvitation cria um convite e retorna o token (exibido apenas uma vez)
+func (s *InvitationService) CreateInvitation(familyID, userID uint, input CreateInvitationInput) (*models.FamilyInvitation, string, error) {
+	if input.Role == "" {
+		input.Role = models.RoleMember
+	}
+	if input.ExpiresInHours == 0 {
+		input.ExpiresInHours = defaultInvitationTTLHours
+	}
+	input.Email = strings.ToLower(strings.TrimSpace(input.Email))
+
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidateMemberRole(string(input.Role)))
+	validator.Add(utils.ValidateRange(input.ExpiresInHours, 1, maxInvitationTTLHours, "expires_in_hours"))
+	if input.Email != "" {
+		validator.Add(utils.ValidateEmail(input.Email))
+	}
+
+	if validator.HasErrors() {
+		return nil, "", validator.GetErrors()
+	}
+
+	// Convite para um membro já cadastrado (ex: criado só pelo nome)
+	if input.FamilyMemberID != nil {
+		member, err := s.familyRepo.GetMemberByID(*input.FamilyMemberID)
+		if err != nil || member.FamilyAccountID != familyID || !member.IsActive {
+			return nil, "", errors.New("membro não pertence a esta família")
+		}
+		if member.UserID != nil {
+			return nil, "", errors.New("membro já está vinculado a um usuário")
+		}
+
+		pending, err := s.invitationRepo.HasPendingForMember(member.ID)
+		if err != nil {
+			return nil, "", err
+		}
+		if pending {
+			return nil, "", errors.New("já existe um convite pendente para este membro")
+		}
+	}
+
+	token, tokenHash, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	invitation := &models.FamilyInvitation{
+		FamilyAccountID: familyID,
+		FamilyMemberID:  input.FamilyMemberID,
+		InvitedByUserID: userID,
+		TokenHash:       tokenHash,
+		Role:            input.Role,
+		ExpiresAt:       time.Now().Add(time.Duration(input.ExpiresInHours) * time.Hour),
+	}
+	if input.Email != "" {
+		invitation.Email = &input.Email
+	}
+
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, "", err
+	}
+
+	return invitation, token, nil
+}
+
+// GetPendingInvitations lista os convites pendentes da família
+func (s *InvitationService) GetPendingInvitations(familyID uint) ([]models.FamilyInvitation, error) {
+	return s.invitationRepo.GetPendingByFamilyID(familyID)
+}
+
+// RevokeInvitation revoga um convite pendente da família
+func (s *InvitationService) RevokeInvitation(familyID, invitationID uint) error {
+	invitation, err := s.invitationRepo.GetByID(invitationID)
+	if err != nil || invitation.FamilyAccountID != familyID {
+		return ErrInvitationNotFound
+	}
+	if invitation.Status() != models.InvitationPending {
+		return ErrInvitationNotPending
+	}
+
+	return s.invitationRepo.Revoke(invitationID)
+}
+
+// AcceptInvitation vincula o usuário logado à família do convite,
+// associando-o ao membro indicado no convite ou criando um novo membro
+func (s *InvitationService) AcceptInvitation(token string, userID uint) (*models.FamilyMember, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *models.FamilyMember
+
+	err = s.invitationRepo.AcceptWithTransaction(func(txRepo *repositories.InvitationRepository) error {
+		invitation, err := txRepo.GetByTokenHash(utils.HashToken(strings.TrimSpace(token)))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvitationNotFound
+			}
+			return err
+		}
+
+		if invitation.Status() != models.InvitationPending {
+			return ErrInvitationNotPending
+		}
+		if invitation.Email != nil && !strings.EqualFold(*invitation.Email, user.Email) {
+			return ErrInvitationEmailMismatch
+		}
+
+		hasAccess, err := s.familyRepo.UserHasAccess(userID, invitation.FamilyAccountID)
+		if err != nil {
+			return err
+		}
+		if hasAccess {
+			return ErrAlreadyFamilyMember
+		}
+
+		if invitation.FamilyMemberID != nil {
+			member, err = txRepo.GetMemberByID(*invitation.FamilyMemberID)
+			if err != nil {
+				return err
+			}
+			if member.UserID != nil && *member.UserID != userID {
+				return errors.New("membro já está vinculado a outro usuário")
+			}
+			member.UserID = &userID
+			member.Role = invitation.Role
+			member.IsActive = true
+		} else {
+			name := user.Name
+			if name == "" {
+				name = user.Username
+			}
+			member = &models.FamilyMember{
+				FamilyAccountID: invitation.FamilyAccountID,
+				UserID:          &userID,
+				Name:            name,
+				Role:            invitation.Role,
+				IsActive:        true,
+			}
+		}
+
+		if err := txRepo.SaveMember(member); err != nil {
+			return err
+		}
+
+		return txRepo.MarkAccepted(invitation.ID, userID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,487 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/services/calculation"
+	"finance-backend/utils"
+)
+
+var ErrMemberNotInFamily = errors.New("membro não pertence a esta família")
+
+type IRPFDeclarationService struct {
+	incomeService *IncomeService
+	expenseRepo   *repositories.ExpenseRepository
+	categoryRepo  *repositories.ExpenseCategoryRepository
+	familyRepo    *repositories.FamilyRepository
+	taxRepo       *repositories.TaxRepository
+}
+
+func NewIRPFDeclarationService(
+	incomeService *IncomeService,
+	expenseRepo *repositories.ExpenseRepository,
+	categoryRepo *repositories.ExpenseCategoryRepository,
+	familyRepo *repositories.FamilyRepository,
+	taxRepo *repositories.TaxRepository,
+) *IRPFDeclarationService {
+	return &IRPFDeclarationService{
+		incomeService: incomeService,
+		expenseRepo:   expenseRepo,
+		categoryRepo:  categoryRepo,
+		familyRepo:    familyRepo,
+		taxRepo:       taxRepo,
+	}
+}
+
+// IRPFDeclaration simulação da declaração de ajuste anual de um membro
+type IRPFDeclaration struct {
+	Year            int     `json:"year"`     // ano-calendário
+	TaxYear         int     `json:"tax_year"` // exercício (ano da entrega)
+	MemberID        uint    `json:"member_id"`
+	MemberName      string  `json:"member_name"`
+	Dependents      int     `json:"dependents"`
+	TaxableIncome   float64 `json:"taxable_income"`
+	ExemptIncome    float64 `json:"exempt_income"`    // lucros distribuídos e lucro do MEI
+	ExclusiveIncome float64 `json:"exclusive_income"` // 13º salário
+	INSS            float64 `json:"inss"`
+	WithheldIRPF    float64 `json:"withheld_irpf"`
+
+	Deductions IRPFDeclarationDeductions `json:"deductions"`
+	Complete   IRPFDeclarationModel      `json:"complete"`
+	Simplified IRPFDeclarationModel      `json:"simplified"`
+	BestModel  string                    `json:"best_model"` // completa ou simplificada
+	Refund     float64                   `json:"refund"`     // a restituir no modelo mais vantajoso
+	TaxDue     float64                   `json:"tax_due"`    // a pagar no modelo mais vantajoso
+
+	Sources  []IRPFDeclarationSource `json:"sources"`
+	Expenses []DeductibleExpense     `json:"expenses"` // despesas dedutíveis consideradas
+	Warnings []string                `json:"warnings,omitempty"`
+}
+
+// IRPFDeclarationDeductions deduções do modelo completo (informadas e aceitas após os limites)
+type IRPFDeclarationDeductions struct {
+	INSS              float64 `json:"inss"`
+	Dependents        float64 `json:"dependents"`
+	Health            float64 `json:"health"`
+	Education         float64 `json:"education"`
+	EducationDeclared float64 `json:"education_declared"`
+	PGBL              float64 `json:"pgbl"`
+	PGBLDeclared      float64 `json:"pgbl_declared"`
+	Alimony           float64 `json:"alimony"`
+	Total             float64 `json:"total"`
+}
+
+// IRPFDeclarationModel apuração em um modelo de declaração
+type IRPFDeclarationModel struct {
+	Deductions  float64 `json:"deductions"` // no simplificado, o desconto padrão
+	TaxableBase float64 `json:"taxable_base"`
+	Reduction   float64 `json:"reduction"` // redução anual do ano
+	Tax         float64 `json:"tax"`
+	Result      float64 `json:"result"` // positivo = a pagar, negativo = a restituir
+}
+
+// IRPFDeclarationSource rendimentos e retenções de uma fonte no ano
+type IRPFDeclarationSource struct {
+	SourceID     uint    `json:"source_id"`
+	SourceName   string  `json:"source_name"`
+	Type         string  `json:"type"`
+	Taxable      float64 `json:"taxable"`
+	Exempt       float64 `json:"exempt"`
+	Exclusive    float64 `json:"exclusive"`
+	INSS         float64 `json:"inss"`
+	WithheldIRPF float64 `json:"withheld_irpf"`
+}
+
+// DeductibleExpense parte do membro em uma despesa dedutível
+type DeductibleExpense struct {
+	ExpenseID           uint                 `json:"expense_id"`
+	Name                string               `json:"name"`
+	Month               int                  `json:"month"`
+	CategoryID          uint                 `json:"category_id"`
+	CategoryName        string               `json:"category_name"`
+	Deduction           models.IRPFDeduction `json:"deduction"`
+	BeneficiaryMemberID *uint                `json:"beneficiary_member_id,omitempty"`
+	ProviderName        string               `json:"provider_name,omitempty"`
+	ProviderDocument    string               `json:"provider_document,omitempty"`
+	Amount              float64              `json:"amount"`
+	amountCents         int64
+}
+
+// SimulateDeclaration simula a declaração de ajuste do membro no ano: soma rendimentos, INSS e IRPF retido
+// das rendas do membro, a parte dele nas despesas de categorias dedutíveis e compara os modelos completo e
+// simplificado. dependents < 0 usa os dependentes que têm o membro como declarante
+func (s *IRPFDeclarationService) SimulateDeclaration(familyID, memberID uint, year, dependents int) (*IRPFDeclaration, error) {
+	member, err := s.familyRepo.GetMemberByID(memberID)
+	if err != nil || member.FamilyAccountID != familyID {
+		return nil, ErrMemberNotInFamily
+	}
+
+	taxes, err := s.incomeService.GetMemberAnnualTaxes(familyID, memberID, year)
+	if err != nil {
+		return nil, err
+	}
+	if dependents < 0 {
+		dependents, err = s.familyRepo.CountDependentsDeclaredBy(familyID, memberID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expenses, err := s.memberDeductibleExpenses(familyID, memberID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	input := calculation.IRPFAnnualInput{
+		TaxableIncomeCents: taxes.TaxableCents,
+		WithheldIRPFCents:  taxes.WithheldIRPFCents,
+		INSSCents:          taxes.INSSCents,
+		Dependents:         dependents,
+	}
+
+	// O limite de instrução é por pessoa: os gastos são somados por beneficiário (sem beneficiário, o titular)
+	educationByPerson := map[uint]int64{}
+	educationOrder := []uint{}
+	var educationCents int64
+	for _, expense := range expenses {
+		switch expense.Deduction {
+		case models.IRPFDeductionHealth:
+			input.HealthCents += expense.amountCents
+		case models.IRPFDeductionEducation:
+			beneficiaryID := memberID
+			if expense.BeneficiaryMemberID != nil {
+				beneficiaryID = *expense.BeneficiaryMemberID
+			}
+			if _, ok := educationByPerson[beneficiaryID]; !ok {
+				educationOrder = append(educationOrder, beneficiaryID)
+			}
+			educationByPerson[beneficiaryID] += expense.amountCents
+			educationCents += expense.amountCents
+		case models.IRPFDeductionPGBL:
+			input.PGBLCents += expense.amountCents
+		case models.IRPFDeductionAlimony:
+			input.AlimonyCents += expense.amountCents
+		}
+	}
+	for _, beneficiaryID := range educationOrder {
+		input.EducationCents = append(input.EducationCents, educationByPerson[beneficiaryID])
+	}
+
+	calculator := calculation.NewTaxCalculatorForReferenceYear(s.taxRepo, year)
+	result := calculator.CalculateIRPFAnnual(input)
+
+	declaration := &IRPFDeclaration{
+		Year:            year,
+		TaxYear:         year + 1,
+		MemberID:        member.ID,
+		MemberName:      member.Name,
+		Dependents:      dependents,
+		TaxableIncome:   utils.CentsToFloat(taxes.TaxableCents),
+		ExemptIncome:    utils.CentsToFloat(taxes.ExemptCents),
+		ExclusiveIncome: utils.CentsToFloat(taxes.ExclusiveCents),
+		INSS:            utils.CentsToFloat(taxes.INSSCents),
+		WithheldIRPF:    utils.CentsToFloat(taxes.WithheldIRPFCents),
+		Deductions: IRPFDeclarationDeductions{
+			INSS:              utils.CentsToFloat(result.Deductions.INSSCents),
+			Dependents:        utils.CentsToFloat(result.Deductions.DependentsCents),
+			Health:            utils.CentsToFloat(result.Deductions.HealthCents),
+			Education:         utils.CentsToFloat(result.Deductions.EducationCents),
+			EducationDeclared: utils.CentsToFloat(educationCents),
+			PGBL:              utils.CentsToFloat(result.Deductions.PGBLCents),
+			PGBLDeclared:      utils.CentsToFloat(input.PGBLCents),
+			Alimony:           utils.CentsToFloat(result.Deductions.AlimonyCents),
+			Total:             utils.CentsToFloat(result.Deductions.TotalCents),
+		},
+		Complete:   newIRPFDeclarationModel(result.Complete),
+		Simplified: newIRPFDeclarationModel(result.Simplified),
+		BestModel:  result.BestModel,
+		Sources:    make([]IRPFDeclarationSource, 0, len(taxes.Sources)),
+		Expenses:   expenses,
+	}
+
+	if result.MonthlyTableApproximation {
+		declaration.Warnings = append(declaration.Warnings, fmt.Sprintf(
+			"tabela anual do IRPF de %d não cadastrada: o imposto usa a tabela mensal × 12, que difere da anual se a tabela mudou durante o ano",
+			calculator.Year()))
+	}
+
+	if best := result.Best(); best.ResultCents < 0 {
+		declaration.Refund = utils.CentsToFloat(-best.ResultCents)
+	} else {
+		declaration.TaxDue = utils.CentsToFloat(best.ResultCents)
+	}
+
+	for _, source := range taxes.Sources {
+		declaration.Sources = append(declaration.Sources, IRPFDeclarationSource{
+			SourceID:     source.SourceID,
+			SourceName:   source.SourceName,
+			Type:         string(source.Type),
+			Taxable:      utils.CentsToFloat(source.TaxableCents),
+			Exempt:       utils.CentsToFloat(source.ExemptCents),
+			Exclusive:    utils.CentsToFloat(source.ExclusiveCents),
+			INSS:         utils.CentsToFloat(source.INSSCents),
+			WithheldIRPF: utils.CentsToFloat(source.WithheldIRPFCents),
+		})
+	}
+
+	return declaration, nil
+}
+
+func newIRPFDeclarationModel(model calculation.IRPFAnnualModel) IRPFDeclarationModel {
+	return IRPFDeclarationModel{
+		Deductions:  utils.CentsToFloat(model.DeductionsCents),
+		TaxableBase: utils.CentsToFloat(model.TaxableBaseCents),
+		Reduction:   utils.CentsToFloat(model.ReductionCents),
+		Tax:         utils.CentsToFloat(model.TaxCents),
+		Result:      utils.CentsToFloat(model.ResultCents),
+	}
+}
+
+// memberDeductibleExpenses despesas dedutíveis do ano: a dedução da própria despesa ou a da categoria (a
+// subcategoria sem dedução herda a da categoria pai). A parte do membro é o valor do split dele; sem splits,
+// o valor cheio se ele pagou
+func (s *IRPFDeclarationService) memberDeductibleExpenses(familyID, memberID uint, year int) ([]DeductibleExpense, error) {
+	categories, err := s.categoryRepo.GetByFamilyID(familyID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.ExpenseCategory, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	deductionOf := func(categoryID uint) models.IRPFDeduction {
+		category := byID[categoryID]
+		if category.IRPFDeduction == "" && category.ParentID != nil {
+			return byID[*category.ParentID].IRPFDeduction
+		}
+		return category.IRPFDeduction
+	}
+
+	expenses, err := s.expenseRepo.GetWithSplitsBetweenMonths(familyID, 1, year, 12, year)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []DeductibleExpense{}
+	for _, expense := range expenses {
+		deduction := expense.ResolveIRPFDeduction(deductionOf(expense.CategoryID))
+		if deduction == "" {
+			continue
+		}
+
+		var share int64
+		if len(expense.Splits) == 0 {
+			if expense.PaidByMemberID != nil && *expense.PaidByMemberID == memberID {
+				share = expense.AmountCents
+			}
+		}
+		for _, split := range expense.Splits {
+			if split.FamilyMemberID == memberID {
+				share += split.AmountCents
+			}
+		}
+		if share <= 0 {
+			continue
+		}
+
+		result = append(result, DeductibleExpense{
+			ExpenseID:           expense.ID,
+			Name:                expense.Name,
+			Month:               expense.ReferenceMonth,
+			CategoryID:          expense.CategoryID,
+			CategoryName:        byID[expense.CategoryID].Name,
+			Deduction:           deduction,
+			BeneficiaryMemberID: expense.IRPFBeneficiaryMemberID,
+			ProviderName:        expense.ProviderName,
+			ProviderDocument:    expense.ProviderDocument,
+			Amount:              utils.CentsToFloat(share),
+			amountCents:         share,
+		})
+	}
+	return result, nil
+}
+
+// Tipo do beneficiário na ficha "Pagamentos Efetuados"
+const (
+	BeneficiaryHolder     = "titular"
+	BeneficiaryDependent  = "dependente"
+	BeneficiaryAlimentand = "alimentando"
+)
+
+// IRPFPaymentsReport pagamentos dedutíveis do membro no ano, como são lançados na ficha "Pagamentos Efetuados"
+type IRPFPaymentsReport struct {
+	Year       int           `json:"year"`     // ano-calendário
+	TaxYear    int           `json:"tax_year"` // exercício (ano da entrega)
+	MemberID   uint          `json:"member_id"`
+	MemberName string        `json:"member_name"`
+	Payments   []IRPFPayment `json:"payments"`
+	Total      float64       `json:"total"`
+	Warnings   []string      `json:"warnings,omitempty"`
+}
+
+// IRPFPayment um lançamento da ficha: o total pago no ano a um prestador por beneficiário
+type IRPFPayment struct {
+	Code                string               `json:"code"` // código do pagamento na ficha (ex: 10 = médicos)
+	Description         string               `json:"description"`
+	Deduction           models.IRPFDeduction `json:"deduction"`
+	BeneficiaryType     string               `json:"beneficiary_type"` // titular, dependente ou alimentando
+	BeneficiaryMemberID uint                 `json:"beneficiary_member_id,omitempty"`
+	BeneficiaryName     string               `json:"beneficiary_name"`
+	ProviderName        string               `json:"provider_name"`
+	ProviderDocument    string               `json:"provider_document"` // CPF ou CNPJ formatado
+	Amount              float64              `json:"amount"`
+	ExpenseIDs          []uint               `json:"expense_ids"`
+	amountCents         int64
+}
+
+// irpfPaymentCode código e descrição do pagamento na ficha. Saúde com CNPJ vai como hospital, clínica ou
+// laboratório (21) e com CPF como médico (10); na pensão alimentícia o prestador é o alimentando
+func irpfPaymentCode(deduction models.IRPFDeduction, providerDocument string) (string, string) {
+	switch deduction {
+	case models.IRPFDeductionEducation:
+		return "01", "Instrução no Brasil"
+	case models.IRPFDeductionHealth:
+		if len(providerDocument) == 14 {
+			return "21", "Hospitais, clínicas e laboratórios no Brasil"
+		}
+		return "10", "Médicos no Brasil"
+	case models.IRPFDeductionAlimony:
+		return "30", "Pensão alimentícia judicial paga no Brasil"
+	case models.IRPFDeductionPGBL:
+		return "36", "Previdência complementar"
+	}
+	return "", ""
+}
+
+// GetPaymentsReport agrupa as despesas dedutíveis do membro no ano por código, beneficiário e prestador,
+// avisando os lançamentos que a Receita não aceita como estão (sem CPF/CNPJ, beneficiário que não é dependente)
+func (s *IRPFDeclarationService) GetPaymentsReport(familyID, memberID uint, year int) (*IRPFPaymentsReport, error) {
+	member, err := s.familyRepo.GetMemberByID(memberID)
+	if err != nil || member.FamilyAccountID != familyID {
+		return nil, ErrMemberNotInFamily
+	}
+
+	members, err := s.familyRepo.GetMembers(familyID)
+	if err != nil {
+		return nil, err
+	}
+	membersByID := make(map[uint]models.FamilyMember, len(members))
+	for _, familyMember := range members {
+		membersByID[familyMember.ID] = familyMember
+	}
+
+	expenses, err := s.memberDeductibleExpenses(familyID, memberID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &IRPFPaymentsReport{
+		Year:       year,
+		TaxYear:    year + 1,
+		MemberID:   member.ID,
+		MemberName: member.Name,
+		Payments:   []IRPFPayment{},
+	}
+
+	index := map[string]int{}
+	var totalCents int64
+	for _, expense := range expenses {
+		code, description := irpfPaymentCode(expense.Deduction, expense.ProviderDocument)
+		payment := IRPFPayment{
+			Code:             code,
+			Description:      description,
+			Deduction:        expense.Deduction,
+			BeneficiaryType:  BeneficiaryHolder,
+			BeneficiaryName:  member.Name,
+			ProviderName:     expense.ProviderName,
+			ProviderDocument: utils.FormatCPFOrCNPJ(expense.ProviderDocument),
+		}
+		switch {
+		case expense.Deduction == models.IRPFDeductionAlimony:
+			payment.BeneficiaryType, payment.BeneficiaryName = BeneficiaryAlimentand, expense.ProviderName
+		case expense.Deduction == models.IRPFDeductionPGBL:
+			// a previdência é sempre do titular
+		case expense.BeneficiaryMemberID != nil && *expense.BeneficiaryMemberID != memberID:
+			beneficiary := membersByID[*expense.BeneficiaryMemberID]
+			payment.BeneficiaryType = BeneficiaryDependent
+			payment.BeneficiaryMemberID = *expense.BeneficiaryMemberID
+			payment.BeneficiaryName = beneficiary.Name
+		}
+
+		key := fmt.Sprintf("%s|%s|%d|%s|%s", payment.Code, payment.BeneficiaryType, payment.BeneficiaryMemberID,
+			expense.ProviderDocument, strings.ToLower(strings.TrimSpace(expense.ProviderName)))
+		i, ok := index[key]
+		if !ok {
+			i = len(report.Payments)
+			index[key] = i
+			report.Payments = append(report.Payments, payment)
+		}
+		report.Payments[i].amountCents += expense.amountCents
+		report.Payments[i].ExpenseIDs = append(report.Payments[i].ExpenseIDs, expense.ExpenseID)
+		totalCents += expense.amountCents
+	}
+
+	sort.SliceStable(report.Payments, func(i, j int) bool {
+		a, b := report.Payments[i], report.Payments[j]
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		if a.BeneficiaryName != b.BeneficiaryName {
+			return a.BeneficiaryName < b.BeneficiaryName
+		}
+		return a.ProviderName < b.ProviderName
+	})
+
+	for i := range report.Payments {
+		payment := &report.Payments[i]
+		payment.Amount = utils.CentsToFloat(payment.amountCents)
+
+		label := payment.ProviderName
+		if label == "" {
+			label = payment.Description
+		}
+		if payment.ProviderDocument == "" {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s): informe o CPF/CNPJ do prestador", label, utils.FormatMoney(payment.amountCents)))
+		}
+		if payment.BeneficiaryType == BeneficiaryDependent && membersByID[payment.BeneficiaryMemberID].Role != models.RoleDependent {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s): %s não é dependente na família e a despesa não é dedutível pelo titular",
+				label, utils.FormatMoney(payment.amountCents), payment.BeneficiaryName))
+		}
+	}
+	report.Total = utils.CentsToFloat(totalCents)
+
+	return report, nil
+}
+
+// CSV exporta os lançamentos no layout da ficha (separador ";" e vírgula decimal, como o Excel em português)
+func (r *IRPFPaymentsReport) CSV() ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = ';'
+
+	rows := [][]string{{"Código", "Descrição", "Tipo de beneficiário", "Nome do beneficiário", "CPF/CNPJ do prestador", "Nome do prestador", "Valor pago", "Parcela não dedutível"}}
+	for _, payment := range r.Payments {
+		rows = append(rows, []string{
+			payment.Code,
+			payment.Description,
+			payment.BeneficiaryType,
+			payment.BeneficiaryName,
+			payment.ProviderDocument,
+			payment.ProviderName,
+			strings.Replace(fmt.Sprintf("%.2f", payment.Amount), ".", ",", 1),
+			"0,00",
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
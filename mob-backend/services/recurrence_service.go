@@ -0,0 +1,234 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+)
+
+type RecurrenceService struct {
+	expenseRepo    *repositories.ExpenseRepository
+	expenseService *ExpenseService
+}
+
+func NewRecurrenceService(expenseRepo *repositories.ExpenseRepository, expenseService *ExpenseService) *RecurrenceService {
+	return &RecurrenceService{expenseRepo: expenseRepo, expenseService: expenseService}
+}
+
+// RolloverResult resultado da geração de despesas recorrentes de um mês
+type RolloverResult struct {
+	Month    int              `json:"month"`
+	Year     int              `json:"year"`
+	Created  int              `json:"created"`
+	Existing int              `json:"existing"` // ocorrências que já haviam sido geradas
+	Expenses []models.Expense `json:"expenses"` // ocorrências criadas nesta execução
+}
+
+// GenerateMonth materializa no mês informado as ocorrências das despesas recorrentes da família,
+// com os splits da despesa original (ver occurrenceSplits). É idempotente: ocorrências já existentes
+// (inclusive as excluídas pelo usuário) não são recriadas.
+func (s *RecurrenceService) GenerateMonth(familyID uint, month, year int) (*RolloverResult, error) {
+	result := &RolloverResult{Month: month, Year: year, Expenses: []models.Expense{}}
+
+	roots, err := s.expenseRepo.GetRecurringRoots(familyID, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.expenseRepo.CreateWithTransaction(func(repo *repositories.ExpenseRepository) error {
+		for _, root := range roots {
+			// O mês da própria despesa original já é a primeira ocorrência
+			if !root.OccursIn(year, month) || (root.ReferenceYear == year && root.ReferenceMonth == month) {
+				continue
+			}
+
+			occurrence := models.Expense{
+				FamilyAccountID:         root.FamilyAccountID,
+				CategoryID:              root.CategoryID,
+				Name:                    root.Name,
+				Description:             root.Description,
+				AmountCents:             root.AmountCents,
+				Frequency:               root.Frequency,
+				ExpenseType:             root.ExpenseType,
+				DueDay:                  root.DueDay,
+				IsFixed:                 root.IsFixed,
+				IsActive:                true,
+				SplitMode:               root.SplitMode,
+				ReferenceMonth:          month,
+				ReferenceYear:           year,
+				RecurrenceRule:          models.RecurrenceNone,
+				RecurrenceInterval:      1,
+				RecurrenceParentID:      &root.ID,
+				PaidByMemberID:          root.PaidByMemberID,
+				IRPFDeduction:           root.IRPFDeduction,
+				IRPFBeneficiaryMemberID: root.IRPFBeneficiaryMemberID,
+				ProviderName:            root.ProviderName,
+				ProviderDocument:        root.ProviderDocument,
+			}
+
+			created, err := repo.CreateOccurrence(&occurrence)
+			if err != nil {
+				return err
+			}
+			if !created {
+				result.Existing++
+				continue
+			}
+
+			splits, err := s.occurrenceSplits(root, month, year)
+			if err != nil {
+				return err
+			}
+			for _, split := range splits {
+				occurrenceSplit := split
+				occurrenceSplit.ID = 0
+				occurrenceSplit.ExpenseID = occurrence.ID
+				if err := repo.CreateSplit(&occurrenceSplit); err != nil {
+					return err
+				}
+				occurrence.Splits = append(occurrence.Splits, occurrenceSplit)
+			}
+
+			result.Created++
+			result.Expenses = append(result.Expenses, occurrence)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// occurrenceSplits divide a ocorrência entre os membros da despesa original. Nos modos income e
+// shares a divisão é refeita com ResolveSplits no mês da ocorrência, já que a renda dos membros muda
+// de um mês para outro; se nenhum deles tiver renda no mês, mantém os valores da original.
+// Nos demais modos os valores da original são copiados.
+func (s *RecurrenceService) occurrenceSplits(root models.Expense, month, year int) ([]models.ExpenseSplit, error) {
+	if root.SplitMode != models.SplitModeIncome && root.SplitMode != models.SplitModeShares {
+		return root.Splits, nil
+	}
+	
+	inputs := make([]ExpenseSplitInput, 0, len(root.Splits))
+	for _, split := range root.Splits {
+		inputs = append(inputs, ExpenseSplitInput{
+			FamilyMemberID: split.FamilyMemberID,
+			Percentage:     split.Percentage,
+			AmountCents:    split.AmountCents,
+			Shares:         split.Shares,
+		})
+	}
+	
+	splits, err := s.expenseService.ResolveSplits(root.FamilyAccountID, root.SplitMode, root.AmountCents, month, year, inputs)
+	if err != nil {
+		if _, ok := err.(utils.ValidationErrors); ok {
+			return root.Splits, nil
+		}
+		return nil, err
+	}
+	return splits, nil
+}
+
+// GenerateMonthForAllFamilies executa GenerateMonth para todas as famílias com despesas recorrentes
+func (s *RecurrenceService) GenerateMonthForAllFamilies(month, year int) (int, error) {
+	familyIDs, err := s.expenseRepo.GetFamilyIDsWithRecurringExpenses()
+	if err != nil {
+		return 0, err
+	}
+
+	log := utils.GetLogger()
+	created := 0
+	for _, familyID := range familyIDs {
+		result, err := s.GenerateMonth(familyID, month, year)
+		if err != nil {
+			// Uma família com erro não deve impedir as demais
+			log.Error("Erro ao gerar despesas recorrentes", map[string]interface{}{
+				"family_id": familyID,
+				"month":     month,
+				"year":      year,
+				"error":     err.Error(),
+			})
+			continue
+		}
+		created += result.Created
+	}
+
+	return created, nil
+}
+
+// RecurrenceScheduler gera periodicamente as despesas recorrentes do mês corrente
+type RecurrenceScheduler struct {
+	recurrenceService *RecurrenceService
+	interval          time.Duration
+	stop              chan struct{}
+}
+
+// NewRecurrenceScheduler cria o agendador; o intervalo vem de RECURRENCE_SCHEDULER_INTERVAL_MINUTES
+// (padrão: 60, 0 desativa)
+func NewRecurrenceScheduler(recurrenceService *RecurrenceService) *RecurrenceScheduler {
+	minutes := 60
+	if value, err := strconv.Atoi(os.Getenv("RECURRENCE_SCHEDULER_INTERVAL_MINUTES")); err == nil && value >= 0 {
+		minutes = value
+	}
+
+	return &RecurrenceScheduler{
+		recurrenceService: recurrenceService,
+		interval:          time.Duration(minutes) * time.Minute,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start executa uma geração imediata e depois a cada intervalo, em background
+func (s *RecurrenceScheduler) Start() {
+	if s.interval <= 0 {
+		utils.GetLogger().Info("Agendador de despesas recorrentes desativado")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run()
+		for {
+			select {
+			case <-ticker.C:
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop encerra o agendador
+func (s *RecurrenceScheduler) Stop() {
+	close(s.stop)
+}
+
+func (s *RecurrenceScheduler) run() {
+	now := time.Now()
+	log := utils.GetLogger()
+
+	created, err := s.recurrenceService.GenerateMonthForAllFamilies(int(now.Month()), now.Year())
+	if err != nil {
+		log.Error("Erro no agendador de despesas recorrentes", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if created > 0 {
+		log.Info("Despesas recorrentes geradas", map[string]interface{}{
+			"created": created,
+			"month":   int(now.Month()),
+			"year":    now.Year(),
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/services/calculation"
+	"finance-backend/utils"
+)
+
+var ErrSettlementNotFound = errors.New("acerto não encontrado")
+
+type SettlementService struct {
+	settlementRepo *repositories.SettlementRepository
+	expenseRepo    *repositories.ExpenseRepository
+	familyRepo     *repositories.FamilyRepository
+}
+
+func NewSettlementService(
+	settlementRepo *repositories.SettlementRepository,
+	expenseRepo *repositories.ExpenseRepository,
+	familyRepo *repositories.FamilyRepository,
+) *SettlementService {
+	return &SettlementService{
+		settlementRepo: settlementRepo,
+		expenseRepo:    expenseRepo,
+		familyRepo:     familyRepo,
+	}
+}
+
+// SettlementInput dados de um pagamento de acerto entre membros
+type SettlementInput struct {
+	FromMemberID uint   `json:"from_member_id"`
+	ToMemberID   uint   `json:"to_member_id"`
+	AmountCents  int64  `json:"amount_cents"`
+	Month        string `json:"month"`   // YYYY-MM do saldo acertado (padrão: mês de paid_at)
+	PaidAt       string `json:"paid_at"` // YYYY-MM-DD (padrão: hoje)
+	Notes        string `json:"notes"`
+}
+
+// MemberBalance saldo de um membro no período
+type MemberBalance struct {
+	MemberID            uint    `json:"member_id"`
+	MemberName          string  `json:"member_name"`
+	Paid                float64 `json:"paid"`                 // despesas pagas pelo membro
+	Share               float64 `json:"share"`                // parte do membro nas despesas pagas
+	SettlementsSent     float64 `json:"settlements_sent"`     // acertos pagos
+	SettlementsReceived float64 `json:"settlements_received"` // acertos recebidos
+	Net                 float64 `json:"net"`                  // positivo = tem a receber, negativo = deve
+}
+
+// SuggestedTransfer transferência sugerida para quitar os saldos
+type SuggestedTransfer struct {
+	FromMemberID   uint    `json:"from_member_id"`
+	FromMemberName string  `json:"from_member_name"`
+	ToMemberID     uint    `json:"to_member_id"`
+	ToMemberName   string  `json:"to_member_name"`
+	Amount         float64 `json:"amount"`
+}
+
+// BalanceReport quem deve a quem no período
+type BalanceReport struct {
+	Month            int                 `json:"month,omitempty"` // 0 = todo o histórico
+	Year             int                 `json:"year,omitempty"`
+	Members          []MemberBalance     `json:"members"`
+	Transfers        []SuggestedTransfer `json:"transfers"`
+	UnassignedCount  int                 `json:"unassigned_count"` // despesas sem pagador (fora do acerto)
+	UnassignedAmount float64             `json:"unassigned_amount"`
+}
+
+// GetBalances calcula o saldo de cada membro a partir de quem pagou, dos splits e dos acertos registrados,
+// e sugere o menor número de transferências para zerar. Com month e year = 0 considera todo o histórico.
+func (s *SettlementService) GetBalances(familyID uint, month, year int) (*BalanceReport, error) {
+	var expenses []models.Expense
+	var err error
+	if month > 0 && year > 0 {
+		expenses, err = s.expenseRepo.GetByFamilyIDAndMonth(familyID, month, year)
+	} else {
+		expenses, err = s.expenseRepo.GetByFamilyID(familyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.settlementRepo.GetByFamilyID(familyID, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.familyRepo.GetMembers(familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	type ledger struct {
+		paid, share, sent, received int64
+	}
+	ledgers := make(map[uint]*ledger)
+	entry := func(memberID uint) *ledger {
+		if ledgers[memberID] == nil {
+			ledgers[memberID] = &ledger{}
+		}
+		return ledgers[memberID]
+	}
+
+	report := &BalanceReport{Month: month, Year: year, Members: []MemberBalance{}, Transfers: []SuggestedTransfer{}}
+	unassignedCents := int64(0)
+	for _, expense := range expenses {
+		if expense.PaidByMemberID == nil {
+			report.UnassignedCount++
+			unassignedCents += expense.AmountCents
+			continue
+		}
+
+		// O pagador recebe a soma dos splits (e não o valor cheio) para que centavos de
+		// arredondamento fiquem com ele e os saldos fechem em zero
+		payer := entry(*expense.PaidByMemberID)
+		for _, split := range expense.Splits {
+			payer.paid += split.AmountCents
+			entry(split.FamilyMemberID).share += split.AmountCents
+		}
+	}
+	report.UnassignedAmount = utils.CentsToFloat(unassignedCents)
+
+	for _, settlement := range settlements {
+		entry(settlement.FromMemberID).sent += settlement.AmountCents
+		entry(settlement.ToMemberID).received += settlement.AmountCents
+	}
+
+	names := make(map[uint]string, len(members))
+	balances := []calculation.MemberNetBalance{}
+	for _, member := range members {
+		names[member.ID] = member.Name
+		l, ok := ledgers[member.ID]
+		if !ok && !member.IsActive {
+			continue
+		}
+		if !ok {
+			l = &ledger{}
+		}
+
+		net := l.paid - l.share + l.sent - l.received
+		report.Members = append(report.Members, MemberBalance{
+			MemberID:            member.ID,
+			MemberName:          member.Name,
+			Paid:                utils.CentsToFloat(l.paid),
+			Share:               utils.CentsToFloat(l.share),
+			SettlementsSent:     utils.CentsToFloat(l.sent),
+			SettlementsReceived: utils.CentsToFloat(l.received),
+			Net:                 utils.CentsToFloat(net),
+		})
+		balances = append(balances, calculation.MemberNetBalance{MemberID: member.ID, NetCents: net})
+	}
+
+	for _, transfer := range calculation.MinimizeTransfers(balances) {
+		report.Transfers = append(report.Transfers, SuggestedTransfer{
+			FromMemberID:   transfer.FromMemberID,
+			FromMemberName: names[transfer.FromMemberID],
+			ToMemberID:     transfer.ToMemberID,
+			ToMemberName:   names[transfer.ToMemberID],
+			Amount:         utils.CentsToFloat(transfer.AmountCents),
+		})
+	}
+
+	return report, nil
+}
+
+// CreateSettlement registra um pagamento de acerto entre dois membros da família
+func (s *SettlementService) CreateSettlement(familyID, userID uint, input SettlementInput) (*models.Settlement, error) {
+	validator := utils.NewValidator()
+	validator.Add(utils.ValidatePositiveAmount(input.AmountCents, "amount_cents"))
+	if input.FromMemberID == input.ToMemberID {
+		validator.AddError(utils.ValidationError{Field: "to_member_id", Message: "deve ser diferente de from_member_id"})
+	}
+
+	paidAt := time.Now()
+	if input.PaidAt != "" {
+		parsed, err := time.Parse("2006-01-02", input.PaidAt)
+		if err != nil {
+			validator.AddError(utils.ValidationError{Field: "paid_at", Message: "use o formato YYYY-MM-DD"})
+		}
+		paidAt = parsed
+	}
+
+	month, year := int(paidAt.Month()), paidAt.Year()
+	if input.Month != "" {
+		_, err := fmt.Sscanf(input.Month, "%d-%d", &year, &month)
+		if err != nil || month < 1 || month > 12 || year < 2000 {
+			validator.AddError(utils.ValidationError{Field: "month", Message: "use o formato YYYY-MM"})
+		}
+	}
+
+	if validator.HasErrors() {
+		return nil, validator.GetErrors()
+	}
+
+	for _, memberID := range []uint{input.FromMemberID, input.ToMemberID} {
+		belongs, err := s.familyRepo.MemberBelongsToFamily(memberID, familyID)
+		if err != nil {
+			return nil, err
+		}
+		if !belongs {
+			return nil, errors.New("membro não pertence a esta família")
+		}
+	}
+
+	settlement := &models.Settlement{
+		FamilyAccountID: familyID,
+		FromMemberID:    input.FromMemberID,
+		ToMemberID:      input.ToMemberID,
+		AmountCents:     input.AmountCents,
+		ReferenceMonth:  month,
+		ReferenceYear:   year,
+		PaidAt:          paidAt,
+		Notes:           input.Notes,
+		IsActive:        true,
+		CreatedByUserID: &userID,
+	}
+	if err := s.settlementRepo.Create(settlement); err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// GetSettlements lista os acertos da família; com month e year > 0 filtra pelo mês de referência
+func (s *SettlementService) GetSettlements(familyID uint, month, year int) ([]models.Settlement, error) {
+	return s.settlementRepo.GetByFamilyID(familyID, month, year)
+}
+
+// DeleteSettlement exclui um acerto registrado por engano
+func (s *SettlementService) DeleteSettlement(familyID, settlementID uint) error {
+	settlement, err := s.settlementRepo.GetByID(settlementID)
+	if err != nil || settlement.FamilyAccountID != familyID || !settlement.IsActive {
+		return ErrSettlementNotFound
+	}
+	return s.settlementRepo.Delete(settlementID)
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"time"
+
+	"finance-backend/repositories"
+	"finance-backend/services/calculation"
+	"finance-backend/utils"
+)
+
+// Regimes tributários aceitos para o PJ na simulação
+const (
+	PJRegimeSimples = "simples"
+)
+
+type SimulationService struct {
+	taxRepo *repositories.TaxRepository
+}
+
+func NewSimulationService(taxRepo *repositories.TaxRepository) *SimulationService {
+	return &SimulationService{taxRepo: taxRepo}
+}
+
+// CLTvsPJInput proposta CLT e proposta PJ a comparar (valores mensais em centavos)
+type CLTvsPJInput struct {
+	Year       int                `json:"year"`       // ano da tabela de impostos (padrão: ano atual)
+	Dependents int                `json:"dependents"` // dependentes deduzidos no IRPF
+	CLT        CLTSimulationInput `json:"clt"`
+	PJ         PJSimulationInput  `json:"pj"`
+}
+
+// CLTSimulationInput salário e benefícios do emprego CLT
+type CLTSimulationInput struct {
+	GrossMonthlyCents int64 `json:"gross_monthly_cents"`
+	BenefitsCents     int64 `json:"benefits_cents"` // vales, plano de saúde e outros benefícios mensais
+}
+
+// PJSimulationInput faturamento e custos do PJ
+type PJSimulationInput struct {
+	MonthlyInvoiceCents int64   `json:"monthly_invoice_cents"`
+	TaxRegime           string  `json:"tax_regime"`            // simples
+	SimplesNacionalRate float64 `json:"simples_nacional_rate"` // alíquota efetiva do DAS (ex: 6.5 = 6,5%); 0 = Anexo III/V pelo Fator R
+	ProLaboreCents      int64   `json:"pro_labore_cents"`
+	AccountantCents     int64   `json:"accountant_cents"`  // honorários mensais do contador
+	OtherCostsCents     int64   `json:"other_costs_cents"` // outros custos mensais (plano de saúde, certificado digital...)
+}
+
+// CLTvsPJSimulation comparação anual entre as propostas
+type CLTvsPJSimulation struct {
+	Year             int                 `json:"year"` // ano da tabela de impostos usada
+	CLT              CLTSimulationResult `json:"clt"`
+	PJ               PJSimulationResult  `json:"pj"`
+	Difference       float64             `json:"difference"`                   // PJ - CLT no ano (positivo = PJ rende mais)
+	BetterOption     string              `json:"better_option"`                // CLT, PJ ou empate
+	BreakEvenInvoice float64             `json:"break_even_invoice,omitempty"` // faturamento mensal do PJ que iguala o total CLT
+}
+
+// CLTSimulationResult valores anuais do CLT (12 salários, 13º e 1/3 de férias)
+type CLTSimulationResult struct {
+	MonthlyNet    float64 `json:"monthly_net"` // líquido de um mês comum, com benefícios
+	AnnualGross   float64 `json:"annual_gross"`
+	Thirteenth    float64 `json:"thirteenth"`
+	VacationBonus float64 `json:"vacation_bonus"`
+	Benefits      float64 `json:"benefits"`
+	INSS          float64 `json:"inss"`
+	IRPF          float64 `json:"irpf"`
+	FGTS          float64 `json:"fgts"`
+	AnnualNet     float64 `json:"annual_net"`
+	AnnualTotal   float64 `json:"annual_total"` // líquido + FGTS
+}
+
+// PJSimulationResult valores anuais do PJ (12 faturamentos, sem 13º nem férias)
+type PJSimulationResult struct {
+	MonthlyNet         float64 `json:"monthly_net"`
+	AnnualInvoice      float64 `json:"annual_invoice"`
+	SimplesTax         float64 `json:"simples_tax"`
+	SimplesRate        float64 `json:"simples_rate"`            // alíquota efetiva em %
+	SimplesAnnex       string  `json:"simples_annex,omitempty"` // III ou V quando calculado pelo Fator R
+	ProLaboreINSS      float64 `json:"pro_labore_inss"`
+	ProLaboreIRPF      float64 `json:"pro_labore_irpf"`
+	Accountant         float64 `json:"accountant"`
+	OtherCosts         float64 `json:"other_costs"`
+	ProfitDistribution float64 `json:"profit_distribution"` // lucros distribuídos (isentos)
+	AnnualNet          float64 `json:"annual_net"`          // pró-labore líquido + lucros distribuídos
+}
+
+// pjMonth valores mensais do PJ (em centavos) e a alíquota do Simples usada
+type pjMonth struct {
+	simplesTax, inss, irpf, costs, net int64
+	simplesRate                        float64
+	simplesAnnex                       string
+}
+
+// CompareCLTvsPJ compara as propostas no ano: o CLT soma líquido e FGTS de 12 salários, 13º e 1/3 de
+// férias; o PJ soma 12 faturamentos menos DAS, INSS e IRPF do pró-labore, contador e outros custos.
+// Também calcula o faturamento mensal a partir do qual o PJ iguala o total do CLT
+func (s *SimulationService) CompareCLTvsPJ(input CLTvsPJInput) (*CLTvsPJSimulation, error) {
+	if input.Year == 0 {
+		input.Year = time.Now().Year()
+	}
+	if err := validateCLTvsPJInput(input); err != nil {
+		return nil, err
+	}
+
+	calculator := calculation.NewTaxCalculatorForReferenceYear(s.taxRepo, input.Year)
+
+	// CLT: com salário constante o mês das férias não altera os totais anuais
+	cltInput := calculation.CLTAnnualInput{Dependents: input.Dependents, VacationMonth: 1}
+	for i := range cltInput.MonthlyGrossCents {
+		cltInput.MonthlyGrossCents[i] = input.CLT.GrossMonthlyCents
+		cltInput.MonthlyBenefitsCents[i] = input.CLT.BenefitsCents
+	}
+	clt := calculator.CalculateCLTAnnual(cltInput)
+	monthlyNet, _, _, _ := calculator.CalculateCLTNet(input.CLT.GrossMonthlyCents, input.CLT.BenefitsCents, input.Dependents)
+	cltTotal := clt.NetCents + clt.FGTSCents
+
+	pj := simulatePJMonth(calculator, input.PJ, input.PJ.MonthlyInvoiceCents, input.Dependents)
+	pjTotal := pj.net * 12
+	invoice := input.PJ.MonthlyInvoiceCents
+
+	simulation := &CLTvsPJSimulation{
+		Year: calculator.Year(),
+		CLT: CLTSimulationResult{
+			MonthlyNet:    utils.CentsToFloat(monthlyNet),
+			AnnualGross:   utils.CentsToFloat(clt.GrossCents),
+			Thirteenth:    utils.CentsToFloat(clt.ThirteenthCents),
+			VacationBonus: utils.CentsToFloat(clt.VacationBonusCents),
+			Benefits:      utils.CentsToFloat(input.CLT.BenefitsCents * 12),
+			INSS:          utils.CentsToFloat(clt.INSSCents),
+			IRPF:          utils.CentsToFloat(clt.IRPFCents),
+			FGTS:          utils.CentsToFloat(clt.FGTSCents),
+			AnnualNet:     utils.CentsToFloat(clt.NetCents),
+			AnnualTotal:   utils.CentsToFloat(cltTotal),
+		},
+		PJ: PJSimulationResult{
+			MonthlyNet:         utils.CentsToFloat(pj.net),
+			AnnualInvoice:      utils.CentsToFloat(invoice * 12),
+			SimplesTax:         utils.CentsToFloat(pj.simplesTax * 12),
+			SimplesRate:        pj.simplesRate,
+			SimplesAnnex:       pj.simplesAnnex,
+			ProLaboreINSS:      utils.CentsToFloat(pj.inss * 12),
+			ProLaboreIRPF:      utils.CentsToFloat(pj.irpf * 12),
+			Accountant:         utils.CentsToFloat(input.PJ.AccountantCents * 12),
+			OtherCosts:         utils.CentsToFloat(input.PJ.OtherCostsCents * 12),
+			ProfitDistribution: utils.CentsToFloat((invoice - pj.simplesTax - pj.costs - input.PJ.ProLaboreCents) * 12),
+			AnnualNet:          utils.CentsToFloat(pjTotal),
+		},
+		Difference: utils.CentsToFloat(pjTotal - cltTotal),
+	}
+
+	switch {
+	case pjTotal > cltTotal:
+		simulation.BetterOption = "PJ"
+	case pjTotal < cltTotal:
+		simulation.BetterOption = "CLT"
+	default:
+		simulation.BetterOption = "empate"
+	}
+
+	if breakEven, ok := pjBreakEvenInvoice(calculator, input.PJ, input.Dependents, cltTotal); ok {
+		simulation.BreakEvenInvoice = utils.CentsToFloat(breakEven)
+	}
+
+	return simulation, nil
+}
+
+// simulatePJMonth calcula um mês do PJ com o faturamento informado
+func simulatePJMonth(calculator *calculation.TaxCalculator, input PJSimulationInput, invoiceCents int64, dependents int) pjMonth {
+	var month pjMonth
+	month.simplesRate = input.SimplesNacionalRate
+	if input.SimplesNacionalRate == 0 {
+		// RBT12 do próprio faturamento e Fator R do pró-labore
+		simples := calculation.CalculateSimplesNacional(invoiceCents, input.ProLaboreCents, nil)
+		month.simplesTax, month.simplesRate, month.simplesAnnex = simples.TaxCents, simples.EffectiveRate, simples.Annex
+	} else {
+		month.simplesTax = calculator.CalculateSimplesTax(invoiceCents, input.SimplesNacionalRate)
+	}
+	month.inss, month.irpf = calculator.CalculateProLaboreTaxes(input.ProLaboreCents, dependents)
+	month.costs = input.AccountantCents + input.OtherCostsCents
+	month.net = invoiceCents - month.simplesTax - month.inss - month.irpf - month.costs
+	return month
+}
+
+// pjBreakEvenInvoice busca (por bisseção, em centavos) o faturamento mensal cujo líquido anual do PJ
+// alcança o total anual do CLT; false se nenhum faturamento alcança (ex: alíquota de 100%)
+func pjBreakEvenInvoice(calculator *calculation.TaxCalculator, input PJSimulationInput, dependents int, targetCents int64) (int64, bool) {
+	reaches := func(invoiceCents int64) bool {
+		return simulatePJMonth(calculator, input, invoiceCents, dependents).net*12 >= targetCents
+	}
+
+	low, high := int64(0), targetCents
+	for i := 0; !reaches(high); i++ {
+		if i == 20 {
+			return 0, false
+		}
+		low, high = high, high*2
+	}
+	for low < high {
+		middle := low + (high-low)/2
+		if reaches(middle) {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+	return high, true
+}
+
+func validateCLTvsPJInput(input CLTvsPJInput) error {
+	validator := utils.NewValidator()
+
+	validator.Add(utils.ValidateRange(input.Year, 2000, 2100, "year"))
+	if input.Dependents < 0 {
+		validator.AddError(utils.ValidationError{Field: "dependents", Message: "não pode ser negativo"})
+	}
+	validator.Add(utils.ValidatePositiveAmount(input.CLT.GrossMonthlyCents, "clt.gross_monthly_cents"))
+	validator.Add(utils.ValidateNonNegativeAmount(input.CLT.BenefitsCents, "clt.benefits_cents"))
+
+	validator.Add(utils.ValidatePositiveAmount(input.PJ.MonthlyInvoiceCents, "pj.monthly_invoice_cents"))
+	if input.PJ.TaxRegime != PJRegimeSimples {
+		validator.AddError(utils.ValidationError{Field: "pj.tax_regime", Message: "deve ser simples"})
+	}
+	validator.Add(utils.ValidatePercentage(input.PJ.SimplesNacionalRate, "pj.simples_nacional_rate"))
+	validator.Add(utils.ValidateNonNegativeAmount(input.PJ.ProLaboreCents, "pj.pro_labore_cents"))
+	if input.PJ.ProLaboreCents > input.PJ.MonthlyInvoiceCents {
+		validator.AddError(utils.ValidationError{Field: "pj.pro_labore_cents", Message: "não pode ser maior que o faturamento"})
+	}
+	validator.Add(utils.ValidateNonNegativeAmount(input.PJ.AccountantCents, "pj.accountant_cents"))
+	validator.Add(utils.ValidateNonNegativeAmount(input.PJ.OtherCostsCents, "pj.other_costs_cents"))
+
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	return nil
+}
@@ -0,0 +1,556 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"finance-backend/models"
+	"finance-backend/repositories"
+	"finance-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+var ErrTaxYearNotFound = errors.New("tabela de impostos do ano não encontrada")
+
+// Limite a partir do qual a faixa é considerada sem teto (as tabelas semeadas usam 999999999.99)
+const unlimitedBracketValue = 999999999
+
+// Status de uma faixa na comparação com o ano anterior
+const (
+	BracketAdded     = "added"
+	BracketRemoved   = "removed"
+	BracketChanged   = "changed"
+	BracketUnchanged = "unchanged"
+)
+
+type TaxTableService struct {
+	taxRepo *repositories.TaxRepository
+}
+
+func NewTaxTableService(taxRepo *repositories.TaxRepository) *TaxTableService {
+	return &TaxTableService{taxRepo: taxRepo}
+}
+
+// TaxTable tabela completa de impostos de um ano (também é o formato de importação em JSON)
+type TaxTable struct {
+	Year                      int          `json:"year"`
+	IsActive                  bool         `json:"is_active"`                    // ignorado na importação
+	INSSDeductionPerDependent float64      `json:"inss_deduction_per_dependent"` // dedução por dependente no IRPF
+	FGTSRate                  float64      `json:"fgts_rate"`
+	IRPFSimplifiedDiscount    float64      `json:"irpf_simplified_discount"` // desconto simplificado mensal (0 = não há)
+	INSSBrackets              []TaxBracket `json:"inss_brackets"`
+	IRPFBrackets              []TaxBracket `json:"irpf_brackets"`
+	IRPFReducers              []TaxReducer `json:"irpf_reducers"` // redução mensal do IRPF (opcional)
+
+	// Declaração de ajuste anual (opcionais): teto do desconto simplificado de 20%, limite de instrução por
+	// pessoa, tabela progressiva anual (sem ela, a mensal × 12) e redução anual do IRPF
+	IRPFAnnualSimplifiedCap float64      `json:"irpf_annual_simplified_cap"`
+	IRPFEducationCap        float64      `json:"irpf_education_cap"`
+	IRPFAnnualBrackets      []TaxBracket `json:"irpf_annual_brackets"`
+	IRPFAnnualReducers      []TaxReducer `json:"irpf_annual_reducers"`
+}
+
+// TaxBracket faixa de INSS ou IRPF em reais
+type TaxBracket struct {
+	MinValue  float64 `json:"min_value"`
+	MaxValue  float64 `json:"max_value"` // 0 = sem limite (só na última faixa do IRPF)
+	Rate      float64 `json:"rate"`      // ex: 0.075 = 7.5%
+	Deduction float64 `json:"deduction"` // parcela a deduzir (IRPF)
+}
+
+// TaxReducer faixa de redução mensal do IRPF: redução = fixed_amount - rate × rendimentos tributáveis,
+// limitada ao imposto calculado
+type TaxReducer struct {
+	MinValue    float64 `json:"min_value"`
+	MaxValue    float64 `json:"max_value"` // 0 = sem limite (só na última faixa)
+	FixedAmount float64 `json:"fixed_amount"`
+	Rate        float64 `json:"rate"`
+}
+
+// TaxYearSummary ano cadastrado e se está ativo nos cálculos
+type TaxYearSummary struct {
+	Year     int  `json:"year"`
+	IsActive bool `json:"is_active"`
+}
+
+// TaxValueChange alteração de um valor da configuração entre os anos
+type TaxValueChange struct {
+	Field    string  `json:"field"`
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+}
+
+// TaxBracketDiff comparação de uma faixa (pela ordem) com a do ano anterior
+type TaxBracketDiff struct {
+	Order    int         `json:"order"`
+	Status   string      `json:"status"` // added, removed, changed ou unchanged
+	Previous *TaxBracket `json:"previous,omitempty"`
+	Current  *TaxBracket `json:"current,omitempty"`
+}
+
+// TaxReducerDiff comparação de uma faixa de redução (pela ordem) com a do ano anterior
+type TaxReducerDiff struct {
+	Order    int         `json:"order"`
+	Status   string      `json:"status"` // added, removed, changed ou unchanged
+	Previous *TaxReducer `json:"previous,omitempty"`
+	Current  *TaxReducer `json:"current,omitempty"`
+}
+
+// TaxTableDiff diferenças da tabela de um ano em relação ao ano cadastrado anterior
+type TaxTableDiff struct {
+	Year          int              `json:"year"`
+	PreviousYear  int              `json:"previous_year,omitempty"` // 0 = não há ano anterior
+	Configuration []TaxValueChange `json:"configuration"`
+	INSSBrackets  []TaxBracketDiff `json:"inss_brackets"`
+	IRPFBrackets  []TaxBracketDiff `json:"irpf_brackets"`
+	IRPFReducers  []TaxReducerDiff `json:"irpf_reducers"`
+
+	IRPFAnnualBrackets []TaxBracketDiff `json:"irpf_annual_brackets"`
+	IRPFAnnualReducers []TaxReducerDiff `json:"irpf_annual_reducers"`
+}
+
+// TaxTableImportResult resultado da importação de uma tabela
+type TaxTableImportResult struct {
+	Table     *TaxTable     `json:"table"`
+	Diff      *TaxTableDiff `json:"diff"`
+	Saved     bool          `json:"saved"` // false em dry run
+	Activated bool          `json:"activated"`
+}
+
+// ListYears lista os anos com tabela cadastrada (mais recente primeiro)
+func (s *TaxTableService) ListYears() ([]TaxYearSummary, error) {
+	configs, err := s.taxRepo.GetConfigurations()
+	if err != nil {
+		return nil, err
+	}
+
+	years := make([]TaxYearSummary, 0, len(configs))
+	for _, config := range configs {
+		years = append(years, TaxYearSummary{Year: config.Year, IsActive: config.IsActive})
+	}
+	return years, nil
+}
+
+// GetTable retorna a tabela cadastrada de um ano, ativa ou não
+func (s *TaxTableService) GetTable(year int) (*TaxTable, error) {
+	config, inssBrackets, irpfBrackets, err := s.taxRepo.GetYearTable(year)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTaxYearNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	irpfReducers, err := s.taxRepo.GetYearIRPFReducers(year)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &TaxTable{
+		Year:                      config.Year,
+		IsActive:                  config.IsActive,
+		INSSDeductionPerDependent: config.INSSDeductionPerDependent,
+		FGTSRate:                  config.FGTSRate,
+		IRPFSimplifiedDiscount:    config.IRPFSimplifiedDiscount,
+		INSSBrackets:              []TaxBracket{},
+		IRPFBrackets:              []TaxBracket{},
+		IRPFReducers:              []TaxReducer{},
+		IRPFAnnualSimplifiedCap:   config.IRPFAnnualSimplifiedCap,
+		IRPFEducationCap:          config.IRPFEducationCap,
+		IRPFAnnualBrackets:        []TaxBracket{},
+		IRPFAnnualReducers:        []TaxReducer{},
+	}
+	for _, bracket := range inssBrackets {
+		table.INSSBrackets = append(table.INSSBrackets, TaxBracket{MinValue: bracket.MinValue, MaxValue: bracket.MaxValue, Rate: bracket.Rate})
+	}
+	for _, bracket := range irpfBrackets {
+		item := TaxBracket{MinValue: bracket.MinValue, MaxValue: bracket.MaxValue, Rate: bracket.Rate, Deduction: bracket.Deduction}
+		if bracket.Annual {
+			table.IRPFAnnualBrackets = append(table.IRPFAnnualBrackets, item)
+		} else {
+			table.IRPFBrackets = append(table.IRPFBrackets, item)
+		}
+	}
+	for _, reducer := range irpfReducers {
+		item := TaxReducer{MinValue: reducer.MinValue, MaxValue: reducer.MaxValue, FixedAmount: reducer.FixedAmount, Rate: reducer.Rate}
+		if reducer.Annual {
+			table.IRPFAnnualReducers = append(table.IRPFAnnualReducers, item)
+		} else {
+			table.IRPFReducers = append(table.IRPFReducers, item)
+		}
+	}
+	return table, nil
+}
+
+// ImportTable valida a tabela completa de um ano, compara com o ano anterior e grava substituindo as
+// faixas existentes do ano. Sem activate um ano novo fica inativo até ActivateYear (um ano já ativo continua
+// ativo); com dryRun nada é gravado
+func (s *TaxTableService) ImportTable(table *TaxTable, activate, dryRun bool) (*TaxTableImportResult, error) {
+	if err := ValidateTaxTable(table); err != nil {
+		return nil, err
+	}
+
+	diff, err := s.DiffTable(table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TaxTableImportResult{Table: table, Diff: diff}
+	if dryRun {
+		return result, nil
+	}
+
+	config := &models.TaxConfiguration{
+		Year:                      table.Year,
+		INSSDeductionPerDependent: table.INSSDeductionPerDependent,
+		FGTSRate:                  table.FGTSRate,
+		IRPFSimplifiedDiscount:    table.IRPFSimplifiedDiscount,
+		IRPFAnnualSimplifiedCap:   table.IRPFAnnualSimplifiedCap,
+		IRPFEducationCap:          table.IRPFEducationCap,
+	}
+	inssBrackets := make([]models.INSSBracket, 0, len(table.INSSBrackets))
+	for i, bracket := range table.INSSBrackets {
+		inssBrackets = append(inssBrackets, models.INSSBracket{
+			Year:     table.Year,
+			MinValue: bracket.MinValue,
+			MaxValue: bracket.MaxValue,
+			Rate:     bracket.Rate,
+			Order:    i + 1,
+		})
+	}
+	irpfBrackets := make([]models.IRPFBracket, 0, len(table.IRPFBrackets)+len(table.IRPFAnnualBrackets))
+	for i, bracket := range table.IRPFBrackets {
+		irpfBrackets = append(irpfBrackets, models.IRPFBracket{
+			Year:      table.Year,
+			MinValue:  bracket.MinValue,
+			MaxValue:  bracket.MaxValue,
+			Rate:      bracket.Rate,
+			Deduction: bracket.Deduction,
+			Order:     i + 1,
+		})
+	}
+	for i, bracket := range table.IRPFAnnualBrackets {
+		irpfBrackets = append(irpfBrackets, models.IRPFBracket{
+			Year:      table.Year,
+			MinValue:  bracket.MinValue,
+			MaxValue:  bracket.MaxValue,
+			Rate:      bracket.Rate,
+			Deduction: bracket.Deduction,
+			Order:     i + 1,
+			Annual:    true,
+		})
+	}
+
+	irpfReducers := make([]models.IRPFReducer, 0, len(table.IRPFReducers)+len(table.IRPFAnnualReducers))
+	for i, reducer := range table.IRPFReducers {
+		irpfReducers = append(irpfReducers, models.IRPFReducer{
+			Year:        table.Year,
+			MinValue:    reducer.MinValue,
+			MaxValue:    reducer.MaxValue,
+			FixedAmount: reducer.FixedAmount,
+			Rate:        reducer.Rate,
+			Order:       i + 1,
+		})
+	}
+	for i, reducer := range table.IRPFAnnualReducers {
+		irpfReducers = append(irpfReducers, models.IRPFReducer{
+			Year:        table.Year,
+			MinValue:    reducer.MinValue,
+			MaxValue:    reducer.MaxValue,
+			FixedAmount: reducer.FixedAmount,
+			Rate:        reducer.Rate,
+			Order:       i + 1,
+			Annual:      true,
+		})
+	}
+
+	active, err := s.taxRepo.ReplaceYearTable(config, inssBrackets, irpfBrackets, irpfReducers, activate)
+	if err != nil {
+		return nil, err
+	}
+
+	table.IsActive = active
+	result.Saved = true
+	result.Activated = activate
+	return result, nil
+}
+
+// ActivateYear ativa a tabela cadastrada de um ano para os cálculos, validando-a antes
+func (s *TaxTableService) ActivateYear(year int) (*TaxTable, error) {
+	table, err := s.GetTable(year)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTaxTable(table); err != nil {
+		return nil, err
+	}
+
+	if err := s.taxRepo.ActivateYearConfiguration(year); err != nil {
+		return nil, err
+	}
+	table.IsActive = true
+	return table, nil
+}
+
+// DiffYear compara a tabela cadastrada de um ano com a do ano anterior
+func (s *TaxTableService) DiffYear(year int) (*TaxTableDiff, error) {
+	table, err := s.GetTable(year)
+	if err != nil {
+		return nil, err
+	}
+	return s.DiffTable(table)
+}
+
+// DiffTable compara uma tabela com a do ano cadastrado anterior, faixa a faixa pela ordem
+func (s *TaxTableService) DiffTable(table *TaxTable) (*TaxTableDiff, error) {
+	diff := &TaxTableDiff{
+		Year:          table.Year,
+		Configuration: []TaxValueChange{},
+		INSSBrackets:  []TaxBracketDiff{},
+		IRPFBrackets:  []TaxBracketDiff{},
+		IRPFReducers:  []TaxReducerDiff{},
+
+		IRPFAnnualBrackets: []TaxBracketDiff{},
+		IRPFAnnualReducers: []TaxReducerDiff{},
+	}
+
+	previousYear, err := s.taxRepo.GetPreviousYear(table.Year)
+	if err != nil {
+		return nil, err
+	}
+	previous := &TaxTable{}
+	if previousYear > 0 {
+		previous, err = s.GetTable(previousYear)
+		if err != nil {
+			return nil, err
+		}
+		diff.PreviousYear = previousYear
+
+		if !sameTaxValue(previous.INSSDeductionPerDependent, table.INSSDeductionPerDependent) {
+			diff.Configuration = append(diff.Configuration, TaxValueChange{Field: "inss_deduction_per_dependent", Previous: previous.INSSDeductionPerDependent, Current: table.INSSDeductionPerDependent})
+		}
+		if !sameTaxValue(previous.FGTSRate, table.FGTSRate) {
+			diff.Configuration = append(diff.Configuration, TaxValueChange{Field: "fgts_rate", Previous: previous.FGTSRate, Current: table.FGTSRate})
+		}
+		if !sameTaxValue(previous.IRPFSimplifiedDiscount, table.IRPFSimplifiedDiscount) {
+			diff.Configuration = append(diff.Configuration, TaxValueChange{Field: "irpf_simplified_discount", Previous: previous.IRPFSimplifiedDiscount, Current: table.IRPFSimplifiedDiscount})
+		}
+		if !sameTaxValue(previous.IRPFAnnualSimplifiedCap, table.IRPFAnnualSimplifiedCap) {
+			diff.Configuration = append(diff.Configuration, TaxValueChange{Field: "irpf_annual_simplified_cap", Previous: previous.IRPFAnnualSimplifiedCap, Current: table.IRPFAnnualSimplifiedCap})
+		}
+		if !sameTaxValue(previous.IRPFEducationCap, table.IRPFEducationCap) {
+			diff.Configuration = append(diff.Configuration, TaxValueChange{Field: "irpf_education_cap", Previous: previous.IRPFEducationCap, Current: table.IRPFEducationCap})
+		}
+	}
+
+	diff.INSSBrackets = diffBrackets(previous.INSSBrackets, table.INSSBrackets)
+	diff.IRPFBrackets = diffBrackets(previous.IRPFBrackets, table.IRPFBrackets)
+	diff.IRPFReducers = diffReducers(previous.IRPFReducers, table.IRPFReducers)
+	diff.IRPFAnnualBrackets = diffBrackets(previous.IRPFAnnualBrackets, table.IRPFAnnualBrackets)
+	diff.IRPFAnnualReducers = diffReducers(previous.IRPFAnnualReducers, table.IRPFAnnualReducers)
+	return diff, nil
+}
+
+func diffBrackets(previous, current []TaxBracket) []TaxBracketDiff {
+	count := len(previous)
+	if len(current) > count {
+		count = len(current)
+	}
+
+	diffs := make([]TaxBracketDiff, 0, count)
+	for i := 0; i < count; i++ {
+		item := TaxBracketDiff{Order: i + 1}
+		if i < len(previous) {
+			item.Previous = &previous[i]
+		}
+		if i < len(current) {
+			item.Current = &current[i]
+		}
+
+		switch {
+		case item.Previous == nil:
+			item.Status = BracketAdded
+		case item.Current == nil:
+			item.Status = BracketRemoved
+		case sameBracket(*item.Previous, *item.Current):
+			item.Status = BracketUnchanged
+		default:
+			item.Status = BracketChanged
+		}
+		diffs = append(diffs, item)
+	}
+	return diffs
+}
+
+func diffReducers(previous, current []TaxReducer) []TaxReducerDiff {
+	count := len(previous)
+	if len(current) > count {
+		count = len(current)
+	}
+
+	diffs := make([]TaxReducerDiff, 0, count)
+	for i := 0; i < count; i++ {
+		item := TaxReducerDiff{Order: i + 1}
+		if i < len(previous) {
+			item.Previous = &previous[i]
+		}
+		if i < len(current) {
+			item.Current = &current[i]
+		}
+
+		switch {
+		case item.Previous == nil:
+			item.Status = BracketAdded
+		case item.Current == nil:
+			item.Status = BracketRemoved
+		case sameReducer(*item.Previous, *item.Current):
+			item.Status = BracketUnchanged
+		default:
+			item.Status = BracketChanged
+		}
+		diffs = append(diffs, item)
+	}
+	return diffs
+}
+
+func sameBracket(a, b TaxBracket) bool {
+	return sameTaxValue(a.MinValue, b.MinValue) && sameTaxValue(a.MaxValue, b.MaxValue) &&
+		sameTaxValue(a.Rate, b.Rate) && sameTaxValue(a.Deduction, b.Deduction)
+}
+
+func sameReducer(a, b TaxReducer) bool {
+	return sameTaxValue(a.MinValue, b.MinValue) && sameTaxValue(a.MaxValue, b.MaxValue) &&
+		sameTaxValue(a.FixedAmount, b.FixedAmount) && sameTaxValue(a.Rate, b.Rate)
+}
+
+func sameTaxValue(a, b float64) bool {
+	return math.Abs(a-b) < 0.000001
+}
+
+// ValidateTaxTable valida a tabela de um ano: faixas contíguas começando em zero (cada faixa começa
+// R$ 0,01 após o fim da anterior), alíquotas crescentes, teto na última faixa do INSS e parcela a deduzir
+// do IRPF coerente com as faixas
+func ValidateTaxTable(table *TaxTable) error {
+	validator := utils.NewValidator()
+
+	validator.Add(utils.ValidateRange(table.Year, 2000, 2100, "year"))
+	if table.INSSDeductionPerDependent < 0 {
+		validator.AddError(utils.ValidationError{Field: "inss_deduction_per_dependent", Message: "não pode ser negativo"})
+	}
+	if table.FGTSRate <= 0 || table.FGTSRate >= 1 {
+		validator.AddError(utils.ValidationError{Field: "fgts_rate", Message: "deve estar entre 0 e 1 (ex: 0.08)"})
+	}
+	if table.IRPFSimplifiedDiscount < 0 {
+		validator.AddError(utils.ValidationError{Field: "irpf_simplified_discount", Message: "não pode ser negativo"})
+	}
+	if table.IRPFAnnualSimplifiedCap < 0 {
+		validator.AddError(utils.ValidationError{Field: "irpf_annual_simplified_cap", Message: "não pode ser negativo"})
+	}
+	if table.IRPFEducationCap < 0 {
+		validator.AddError(utils.ValidationError{Field: "irpf_education_cap", Message: "não pode ser negativo"})
+	}
+
+	validateBrackets(validator, "inss_brackets", table.INSSBrackets, false)
+	validateBrackets(validator, "irpf_brackets", table.IRPFBrackets, true)
+	if len(table.IRPFAnnualBrackets) > 0 {
+		validateBrackets(validator, "irpf_annual_brackets", table.IRPFAnnualBrackets, true)
+	}
+	validateReducers(validator, "irpf_reducers", table.IRPFReducers)
+	validateReducers(validator, "irpf_annual_reducers", table.IRPFAnnualReducers)
+
+	if validator.HasErrors() {
+		return validator.GetErrors()
+	}
+	return nil
+}
+
+// validateBrackets valida a sequência de faixas; no IRPF a última faixa pode ser sem limite e a parcela a
+// deduzir de cada faixa deve ser a anterior + (alíquota - alíquota anterior) × limite da faixa anterior
+func validateBrackets(validator *utils.Validator, field string, brackets []TaxBracket, isIRPF bool) {
+	if len(brackets) == 0 {
+		validator.AddError(utils.ValidationError{Field: field, Message: "informe ao menos uma faixa"})
+		return
+	}
+
+	for i, bracket := range brackets {
+		prefix := fmt.Sprintf("%s[%d]", field, i)
+		last := i == len(brackets)-1
+		unlimited := bracket.MaxValue == 0 || bracket.MaxValue >= unlimitedBracketValue
+
+		if bracket.Rate < 0 || bracket.Rate >= 1 {
+			validator.AddError(utils.ValidationError{Field: prefix + ".rate", Message: "deve estar entre 0 e 1 (ex: 0.075)"})
+		}
+
+		switch {
+		case unlimited && !isIRPF:
+			validator.AddError(utils.ValidationError{Field: prefix + ".max_value", Message: "as faixas do INSS devem ter limite (a última é o teto de contribuição)"})
+		case unlimited && !last:
+			validator.AddError(utils.ValidationError{Field: prefix + ".max_value", Message: "somente a última faixa pode ser sem limite"})
+		case !unlimited && bracket.MaxValue <= bracket.MinValue:
+			validator.AddError(utils.ValidationError{Field: prefix + ".max_value", Message: "deve ser maior que min_value"})
+		}
+
+		if i == 0 {
+			if utils.FloatToCents(bracket.MinValue) != 0 {
+				validator.AddError(utils.ValidationError{Field: prefix + ".min_value", Message: "a primeira faixa deve começar em 0"})
+			}
+			if isIRPF && math.Abs(bracket.Deduction-bracket.Rate*bracket.MinValue) > 0.05 {
+				validator.AddError(utils.ValidationError{Field: prefix + ".deduction", Message: "a primeira faixa não tem parcela a deduzir"})
+			}
+			continue
+		}
+
+		previous := brackets[i-1]
+		if utils.FloatToCents(bracket.MinValue) != utils.FloatToCents(previous.MaxValue)+1 {
+			validator.AddError(utils.ValidationError{
+				Field:   prefix + ".min_value",
+				Message: fmt.Sprintf("deve começar R$ 0,01 após o fim da faixa anterior (%.2f)", previous.MaxValue),
+			})
+		}
+		if bracket.Rate <= previous.Rate {
+			validator.AddError(utils.ValidationError{Field: prefix + ".rate", Message: "as alíquotas devem ser crescentes"})
+		}
+		if isIRPF {
+			expected := previous.Deduction + (bracket.Rate-previous.Rate)*previous.MaxValue
+			if math.Abs(bracket.Deduction-expected) > 0.05 {
+				validator.AddError(utils.ValidationError{
+					Field:   prefix + ".deduction",
+					Message: fmt.Sprintf("parcela a deduzir inconsistente com as faixas (esperado %.2f)", expected),
+				})
+			}
+		}
+	}
+}
+
+// validateReducers valida as faixas de redução do IRPF: crescentes e sem sobreposição, só a última sem limite
+func validateReducers(validator *utils.Validator, field string, reducers []TaxReducer) {
+	for i, reducer := range reducers {
+		prefix := fmt.Sprintf("%s[%d]", field, i)
+		unlimited := reducer.MaxValue == 0 || reducer.MaxValue >= unlimitedBracketValue
+
+		if reducer.FixedAmount < 0 {
+			validator.AddError(utils.ValidationError{Field: prefix + ".fixed_amount", Message: "não pode ser negativo"})
+		}
+		if reducer.Rate < 0 || reducer.Rate >= 1 {
+			validator.AddError(utils.ValidationError{Field: prefix + ".rate", Message: "deve estar entre 0 e 1 (ex: 0.133145)"})
+		}
+
+		switch {
+		case unlimited && i < len(reducers)-1:
+			validator.AddError(utils.ValidationError{Field: prefix + ".max_value", Message: "somente a última faixa pode ser sem limite"})
+		case !unlimited && reducer.MaxValue <= reducer.MinValue:
+			validator.AddError(utils.ValidationError{Field: prefix + ".max_value", Message: "deve ser maior que min_value"})
+		}
+
+		if reducer.MinValue < 0 {
+			validator.AddError(utils.ValidationError{Field: prefix + ".min_value", Message: "não pode ser negativo"})
+		}
+		if i > 0 && reducer.MinValue <= reducers[i-1].MaxValue {
+			validator.AddError(utils.ValidationError{
+				Field:   prefix + ".min_value",
+				Message: fmt.Sprintf("deve começar após o fim da faixa anterior (%.2f)", reducers[i-1].MaxValue),
+			})
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"finance-backend/config"
+	"finance-backend/repositories"
+	"finance-backend/services"
+	"finance-backend/utils"
+)
+
+const taxUsage = `Uso: main tax <comando>
+
+Comandos:
+  list                                     lista os anos cadastrados
+  show <ano>                               imprime a tabela do ano em JSON (formato de importação)
+  diff <ano>                               compara a tabela do ano com a do ano anterior
+  import [-activate] [-dry-run] <arquivo>  valida, compara e grava a tabela de um ano a partir de JSON
+  activate <ano>                           ativa a tabela do ano para os cálculos
+`
+
+// runTaxCommand executa o subcomando "tax" e retorna o código de saída
+func runTaxCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, taxUsage)
+		return 2
+	}
+
+	config.ConnectDB()
+	service := services.NewTaxTableService(repositories.NewTaxRepository(config.DB))
+
+	switch args[0] {
+	case "list":
+		years, err := service.ListYears()
+		if err != nil {
+			return printTaxError(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ANO\tESTADO")
+		for _, year := range years {
+			state := "inativo"
+			if year.IsActive {
+				state = "ativo"
+			}
+			fmt.Fprintf(w, "%d\t%s\n", year.Year, state)
+		}
+		w.Flush()
+
+	case "show":
+		year, ok := parseTaxYearArg(args[1:])
+		if !ok {
+			return 2
+		}
+
+		table, err := service.GetTable(year)
+		if err != nil {
+			return printTaxError(err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(table); err != nil {
+			return printTaxError(err)
+		}
+
+	case "diff":
+		year, ok := parseTaxYearArg(args[1:])
+		if !ok {
+			return 2
+		}
+
+		diff, err := service.DiffYear(year)
+		if err != nil {
+			return printTaxError(err)
+		}
+		printTaxDiff(diff)
+
+	case "import":
+		flags := flag.NewFlagSet("import", flag.ContinueOnError)
+		activate := flags.Bool("activate", false, "ativa o ano após gravar")
+		dryRun := flags.Bool("dry-run", false, "apenas valida e compara, sem gravar")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if flags.NArg() != 1 {
+			fmt.Fprint(os.Stderr, taxUsage)
+			return 2
+		}
+
+		content, err := os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return printTaxError(err)
+		}
+		var table services.TaxTable
+		if err := json.Unmarshal(content, &table); err != nil {
+			fmt.Fprintf(os.Stderr, "JSON inválido: %v\n", err)
+			return 1
+		}
+
+		result, err := service.ImportTable(&table, *activate, *dryRun)
+		if err != nil {
+			return printTaxError(err)
+		}
+		printTaxDiff(result.Diff)
+
+		switch {
+		case !result.Saved:
+			fmt.Printf("tabela %d válida (dry run: nada foi gravado)\n", table.Year)
+		case result.Activated:
+			fmt.Printf("tabela %d importada e ativada\n", table.Year)
+		case table.IsActive:
+			fmt.Printf("tabela %d importada (continua ativa)\n", table.Year)
+		default:
+			fmt.Printf("tabela %d importada (inativa: use 'main tax activate %d')\n", table.Year, table.Year)
+		}
+
+	case "activate":
+		year, ok := parseTaxYearArg(args[1:])
+		if !ok {
+			return 2
+		}
+
+		if _, err := service.ActivateYear(year); err != nil {
+			return printTaxError(err)
+		}
+		fmt.Printf("tabela %d ativada\n", year)
+
+	default:
+		fmt.Fprint(os.Stderr, taxUsage)
+		return 2
+	}
+
+	return 0
+}
+
+func parseTaxYearArg(args []string) (int, bool) {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, taxUsage)
+		return 0, false
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ano inválido: %s\n", args[0])
+		return 0, false
+	}
+	return year, true
+}
+
+// printTaxError imprime o erro (um por linha nos erros de validação) e retorna o código de saída
+func printTaxError(err error) int {
+	if validationErrs, ok := err.(utils.ValidationErrors); ok {
+		fmt.Fprintln(os.Stderr, "tabela inválida:")
+		for _, validationErr := range validationErrs {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", validationErr.Field, validationErr.Message)
+		}
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
+
+func printTaxDiff(diff *services.TaxTableDiff) {
+	if diff.PreviousYear == 0 {
+		fmt.Printf("%d: não há ano anterior para comparar\n", diff.Year)
+		return
+	}
+
+	fmt.Printf("%d comparado com %d\n", diff.Year, diff.PreviousYear)
+	for _, change := range diff.Configuration {
+		fmt.Printf("  %s: %v -> %v\n", change.Field, change.Previous, change.Current)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, section := range []struct {
+		name  string
+		items []services.TaxBracketDiff
+	}{{"INSS", diff.INSSBrackets}, {"IRPF", diff.IRPFBrackets}, {"IRPF ANUAL", diff.IRPFAnnualBrackets}} {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s\tFAIXA\tESTADO\tANTERIOR\tNOVA\n", section.name)
+		for _, item := range section.items {
+			fmt.Fprintf(w, "\t%d\t%s\t%s\t%s\n", item.Order, item.Status, formatTaxBracket(item.Previous), formatTaxBracket(item.Current))
+		}
+	}
+	for _, section := range []struct {
+		name  string
+		items []services.TaxReducerDiff
+	}{{"REDUTOR IRPF", diff.IRPFReducers}, {"REDUTOR ANUAL", diff.IRPFAnnualReducers}} {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s\tFAIXA\tESTADO\tANTERIOR\tNOVA\n", section.name)
+		for _, item := range section.items {
+			fmt.Fprintf(w, "\t%d\t%s\t%s\t%s\n", item.Order, item.Status, formatTaxReducer(item.Previous), formatTaxReducer(item.Current))
+		}
+	}
+	w.Flush()
+}
+
+func formatTaxBracket(bracket *services.TaxBracket) string {
+	if bracket == nil {
+		return "-"
+	}
+	text := fmt.Sprintf("%.2f-%.2f %.2f%%", bracket.MinValue, bracket.MaxValue, bracket.Rate*100)
+	if bracket.Deduction > 0 {
+		text += fmt.Sprintf(" (-%.2f)", bracket.Deduction)
+	}
+	return text
+}
+
+func formatTaxReducer(reducer *services.TaxReducer) string {
+	if reducer == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f-%.2f %.2f - %g × renda", reducer.MinValue, reducer.MaxValue, reducer.FixedAmount, reducer.Rate)
+}
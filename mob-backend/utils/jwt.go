@@ -1,7 +1,12 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,6 +14,12 @@ import (
 
 var JwtSecret []byte
 
+// Padrões de expiração (sobrescritos por JWT_ACCESS_TTL_MINUTES e JWT_REFRESH_TTL_HOURS)
+const (
+	defaultAccessTokenTTLMinutes = 15
+	defaultRefreshTokenTTLHours  = 720 // 30 dias
+)
+
 func init() {
 	// Lê JWT_SECRET do ambiente
 	secret := os.Getenv("JWT_SECRET")
@@ -19,29 +30,63 @@ func init() {
 	JwtSecret = []byte(secret)
 }
 
-// GenerateToken gera um token JWT para o usuário
-func GenerateToken(userID uint) (string, error) {
-	// Lê tempo de expiração do ambiente (padrão: 72 horas)
-	expirationHours := 72
-	if envHours := os.Getenv("JWT_EXPIRATION_HOURS"); envHours != "" {
-		// Aqui você pode adicionar parsing se precisar
+// AccessTokenTTL retorna a validade do access token
+func AccessTokenTTL() time.Duration {
+	return time.Duration(envPositiveInt("JWT_ACCESS_TTL_MINUTES", defaultAccessTokenTTLMinutes)) * time.Minute
+}
+
+// RefreshTokenTTL retorna a validade do refresh token (renovada a cada rotação)
+func RefreshTokenTTL() time.Duration {
+	return time.Duration(envPositiveInt("JWT_REFRESH_TTL_HOURS", defaultRefreshTokenTTLHours)) * time.Hour
+}
+
+func envPositiveInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
 	}
+	return value
+}
+
+// GenerateAccessToken gera um access token JWT de curta duração vinculado a uma sessão
+func GenerateAccessToken(userID, sessionID uint) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL())
 
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * time.Duration(expirationHours)).Unix(),
-		"iat":     time.Now().Unix(),
+		"sid":     sessionID,
+		"exp":     expiresAt.Unix(),
+		"iat":     now.Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(JwtSecret)
+	signed, err := token.SignedString(JwtSecret)
+	return signed, expiresAt, err
+}
+
+// GenerateSecureToken gera um token opaco (refresh token, convite) e retorna também seu hash
+func GenerateSecureToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// HashToken retorna o SHA-256 (hex) de um token; apenas o hash é persistido
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // ValidateToken valida e retorna os claims do token
 func ValidateToken(tokenString string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		return JwtSecret, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
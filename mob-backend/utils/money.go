@@ -79,10 +79,12 @@ func FormatMoneyCompact(cents int64) string {
 
 // ParseMoneyString converte string brasileira para centavos
 // Ex: "R$ 1.500,00" ou "1.500,00" -> 150000
+// Aceita sinal negativo ("-45,90", "45,90-" ou "(45,90)"), comum em extratos
 func ParseMoneyString(str string) (int64, error) {
 	// Remove caracteres não numéricos exceto vírgula
 	cleaned := ""
 	hasComma := false
+	negative := false
 	
 	for _, char := range str {
 		if char >= '0' && char <= '9' {
@@ -90,6 +92,8 @@ func ParseMoneyString(str string) (int64, error) {
 		} else if char == ',' && !hasComma {
 			cleaned += "."
 			hasComma = true
+		} else if char == '-' || char == '−' || char == '(' {
+			negative = true
 		}
 	}
 	
@@ -103,6 +107,10 @@ func ParseMoneyString(str string) (int64, error) {
 		return 0, err
 	}
 	
+	if negative {
+		value = -value
+	}
+	
 	return FloatToCents(value), nil
 }
 
@@ -77,6 +77,18 @@ func ForbiddenResponse(c *gin.Context, message string) {
 	})
 }
 
+// PermissionDeniedResponse retorna 403 com o código da permissão exigida
+func PermissionDeniedResponse(c *gin.Context, permission string) {
+	c.JSON(http.StatusForbidden, APIResponse{
+		Success: false,
+		Message: "Seu papel na família não permite esta ação",
+		Error: gin.H{
+			"code":       "permission_denied",
+			"permission": permission,
+		},
+	})
+}
+
 // InternalErrorResponse retorna resposta de erro interno
 func InternalErrorResponse(c *gin.Context, message string) {
 	if message == "" {
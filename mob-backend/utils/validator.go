@@ -5,6 +5,7 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 // ValidationError representa um erro de validação
@@ -228,20 +229,144 @@ func ValidateAnnualReturnRate(rate float64) error {
 // ValidateIncomeType valida tipo de renda
 func ValidateIncomeType(incomeType string) error {
 	validTypes := map[string]bool{
-		"CLT": true,
-		"PJ":  true,
+		"CLT":             true,
+		"PJ":              true,
+		"MEI":             true,
+		"lucro_presumido": true,
+		"aluguel":         true,
+		"aposentadoria":   true,
+		"pensao":          true,
+		"freelance":       true,
 	}
 	
 	if !validTypes[incomeType] {
 		return ValidationError{
 			Field:   "type",
-			Message: "deve ser CLT ou PJ",
+			Message: "deve ser CLT, PJ, MEI, lucro_presumido, aluguel, aposentadoria, pensao ou freelance",
 		}
 	}
 	
 	return nil
 }
 
+// ValidateIRPFDeduction valida a dedução do IRPF de uma categoria (vazio = não dedutível)
+func ValidateIRPFDeduction(deduction string) error {
+	validDeductions := map[string]bool{
+		"":                    true,
+		"saude":               true,
+		"educacao":            true,
+		"previdencia_privada": true,
+		"pensao_alimenticia":  true,
+	}
+	
+	if !validDeductions[deduction] {
+		return ValidationError{
+			Field:   "irpf_deduction",
+			Message: "deve ser saude, educacao, previdencia_privada ou pensao_alimenticia",
+		}
+	}
+	
+	return nil
+}
+
+// ValidateExpenseIRPFDeduction valida a dedução do IRPF de uma despesa (vazio = a da categoria; nenhuma = não dedutível)
+func ValidateExpenseIRPFDeduction(deduction string) error {
+	if deduction == "nenhuma" {
+		return nil
+	}
+	
+	if err := ValidateIRPFDeduction(deduction); err != nil {
+		return ValidationError{
+			Field:   "irpf_deduction",
+			Message: "deve ser saude, educacao, previdencia_privada, pensao_alimenticia ou nenhuma",
+		}
+	}
+	
+	return nil
+}
+
+// NormalizeDocument remove a pontuação (. / - e espaços) de um CPF ou CNPJ e passa as letras do CNPJ
+// alfanumérico para maiúsculas (ex: "12.abc.345/01de-35" → "12ABC34501DE35"). Outros caracteres são mantidos
+// para que a validação recuse o documento
+func NormalizeDocument(value string) string {
+	var document strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		switch r {
+		case '.', '/', '-', ' ', '\t':
+			continue
+		}
+		document.WriteRune(r)
+	}
+	return document.String()
+}
+
+// ValidateCPFOrCNPJ valida um CPF (11 dígitos) ou CNPJ (14 caracteres) sem pontuação pelos dígitos verificadores.
+// O CNPJ pode ser alfanumérico (IN RFB 2.229/2024): 12 caracteres de 0-9 ou A-Z e 2 dígitos verificadores
+func ValidateCPFOrCNPJ(document, fieldName string) error {
+	valid := false
+	switch len(document) {
+	case 11:
+		valid = documentCharacters(document, 0) &&
+			validDocument(document, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+	case 14:
+		valid = documentCharacters(document, 12) &&
+			validDocument(document, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	}
+	
+	if !valid {
+		return ValidationError{
+			Field:   fieldName,
+			Message: "CPF ou CNPJ inválido",
+		}
+	}
+	
+	return nil
+}
+
+// documentCharacters confere os caracteres do documento: antes da posição alphanumeric aceita 0-9 e A-Z,
+// a partir dela só dígitos
+func documentCharacters(document string, alphanumeric int) bool {
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+		if (c < '0' || c > '9') && (i >= alphanumeric || c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// validDocument confere os dois dígitos verificadores (módulo 11) de um CPF ou CNPJ. Cada caractere vale o
+// código ASCII - 48 (dígitos 0-9, letras A = 17 até Z = 42)
+func validDocument(document string, firstWeights, secondWeights []int) bool {
+	if strings.Count(document, document[:1]) == len(document) {
+		return false // 000.000.000-00, 111.111.111-11...
+	}
+	
+	checkDigit := func(weights []int) byte {
+		sum := 0
+		for i, weight := range weights {
+			sum += int(document[i]-'0') * weight
+		}
+		if rest := sum % 11; rest >= 2 {
+			return byte('0' + 11 - rest)
+		}
+		return '0'
+	}
+	
+	return document[len(firstWeights)] == checkDigit(firstWeights) && document[len(secondWeights)] == checkDigit(secondWeights)
+}
+
+// FormatCPFOrCNPJ formata um CPF ou CNPJ sem pontuação (000.000.000-00 ou 00.000.000/0000-00)
+func FormatCPFOrCNPJ(document string) string {
+	switch len(document) {
+	case 11:
+		return document[:3] + "." + document[3:6] + "." + document[6:9] + "-" + document[9:]
+	case 14:
+		return document[:2] + "." + document[2:5] + "." + document[5:8] + "/" + document[8:12] + "-" + document[12:]
+	}
+	return document
+}
+
 // ValidateInvestmentType valida tipo de investimento
 func ValidateInvestmentType(investmentType string) error {
 	validTypes := map[string]bool{
@@ -262,6 +387,31 @@ func ValidateInvestmentType(investmentType string) error {
 	return nil
 }
 
+// ValidateRecurrence valida a regra de recorrência de uma despesa
+func ValidateRecurrence(rule string, interval int) error {
+	switch rule {
+	case "", "none", "monthly", "yearly":
+		return nil
+	case "every_n_months":
+		return ValidateRange(interval, 1, 60, "recurrence_interval")
+	}
+	return ValidationError{
+		Field:   "recurrence_rule",
+		Message: "deve ser none, monthly, every_n_months ou yearly",
+	}
+}
+
+// ValidateMemberRole valida o papel de um membro (owner só por transferência de propriedade)
+func ValidateMemberRole(role string) error {
+	if role != "member" && role != "dependent" {
+		return ValidationError{
+			Field:   "role",
+			Message: "deve ser member ou dependent",
+		}
+	}
+	return nil
+}
+
 // Validator é um helper para coletar múltiplos erros de validação
 type Validator struct {
 	Errors ValidationErrors
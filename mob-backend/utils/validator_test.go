@@ -0,0 +1,85 @@
+package utils
+
+import "testing"
+
+func TestNormalizeDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "CPF com pontuação", value: "529.982.247-25", want: "52998224725"},
+		{name: "CNPJ com pontuação", value: "11.222.333/0001-81", want: "11222333000181"},
+		{name: "CNPJ alfanumérico em minúsculas", value: "12.abc.345/01de-35", want: "12ABC34501DE35"},
+		{name: "espaços e tabulação", value: " 529 982\t247 25 ", want: "52998224725"},
+		{name: "outros caracteres são mantidos", value: "529.982.247-25x", want: "52998224725X"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeDocument(tt.value); got != tt.want {
+				t.Errorf("NormalizeDocument(%q) = %q, esperado %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCPFOrCNPJ(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		valid    bool
+	}{
+		{name: "CPF válido", document: "529.982.247-25", valid: true},
+		{name: "CNPJ numérico válido", document: "11.222.333/0001-81", valid: true},
+		{name: "CNPJ alfanumérico válido", document: "12.ABC.345/01DE-35", valid: true},
+		{name: "CNPJ alfanumérico em minúsculas", document: "12.abc.345/01de-35", valid: true},
+		{name: "CPF com dígito verificador errado", document: "529.982.247-24", valid: false},
+		{name: "CNPJ com dígito verificador errado", document: "11.222.333/0001-82", valid: false},
+		{name: "CNPJ alfanumérico com dígito verificador errado", document: "12ABC34501DE34", valid: false},
+		{name: "letra no dígito verificador do CNPJ", document: "12ABC34501DE3A", valid: false},
+		{name: "letra no CPF", document: "1A2.982.247-25", valid: false},
+		{name: "letra no dígito verificador do CPF", document: "529.982.247-2A", valid: false},
+		{name: "CPF com caractere a mais", document: "529.982.247-25x", valid: false},
+		{name: "CNPJ com caractere a mais", document: "11.222.333/0001-81x", valid: false},
+		{name: "CPF com dígitos repetidos", document: "111.111.111-11", valid: false},
+		{name: "CNPJ com dígitos repetidos", document: "00000000000000", valid: false},
+		{name: "tamanho inválido", document: "ABC", valid: false},
+		{name: "vazio", document: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCPFOrCNPJ(NormalizeDocument(tt.document), "document")
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateCPFOrCNPJ(%q) = %v, esperado válido", tt.document, err)
+			}
+			if !tt.valid {
+				validationErr, ok := err.(ValidationError)
+				if !ok {
+					t.Fatalf("ValidateCPFOrCNPJ(%q) = %v, esperado ValidationError", tt.document, err)
+				}
+				if validationErr.Field != "document" {
+					t.Errorf("campo = %s, esperado document", validationErr.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatCPFOrCNPJ(t *testing.T) {
+	tests := []struct {
+		document string
+		want     string
+	}{
+		{document: "52998224725", want: "529.982.247-25"},
+		{document: "12ABC34501DE35", want: "12.ABC.345/01DE-35"},
+		{document: "123", want: "123"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatCPFOrCNPJ(tt.document); got != tt.want {
+			t.Errorf("FormatCPFOrCNPJ(%q) = %q, esperado %q", tt.document, got, tt.want)
+		}
+	}
+}
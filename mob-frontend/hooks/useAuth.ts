@@ -47,6 +47,7 @@ export const useAuth = (): UseAuthReturn => {
       const response = await authApi.login(credentials);
 
       storage.setToken(response.token);
+      storage.setRefreshToken(response.refresh_token);
       storage.setUser(response.user);
       setUser(response.user);
 
@@ -69,6 +70,7 @@ export const useAuth = (): UseAuthReturn => {
       const response = await authApi.register(userData);
 
       storage.setToken(response.token);
+      storage.setRefreshToken(response.refresh_token);
       storage.setUser(response.user);
       setUser(response.user);
 
@@ -27,6 +27,7 @@ export const authApi = {
    */
   logout: () => {
     localStorage.removeItem('auth_token');
+    localStorage.removeItem('refresh_token');
     localStorage.removeItem('user_data');
   },
 };
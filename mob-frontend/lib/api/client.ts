@@ -3,7 +3,7 @@
  * Axios instance with interceptors for authentication and error handling
  */
 
-import axios, { AxiosError, AxiosResponse } from 'axios';
+import axios, { AxiosError, AxiosResponse, InternalAxiosRequestConfig } from 'axios';
 
 // Base URL do backend
 const API_BASE_URL = (import.meta.env?.VITE_API_URL as string) || 'http://localhost:8080/api';
@@ -32,6 +32,40 @@ apiClient.interceptors.request.use(
   }
 );
 
+// ===== REFRESH TOKEN =====
+// Access tokens duram poucos minutos: um 401 troca o refresh token por um novo par e repete a requisição.
+// Requisições que falham ao mesmo tempo compartilham a mesma renovação (o refresh token é de uso único).
+let refreshPromise: Promise<string | null> | null = null;
+
+const refreshAccessToken = (): Promise<string | null> => {
+  if (!refreshPromise) {
+    const refreshToken = localStorage.getItem('refresh_token');
+    refreshPromise = (refreshToken
+      ? axios
+          .post<{ access_token: string; refresh_token: string }>(`${API_BASE_URL}/auth/refresh`, {
+            refresh_token: refreshToken,
+          })
+          .then(({ data }) => {
+            localStorage.setItem('auth_token', data.access_token);
+            localStorage.setItem('refresh_token', data.refresh_token);
+            return data.access_token;
+          })
+          .catch(() => null)
+      : Promise.resolve(null)
+    ).finally(() => {
+      refreshPromise = null;
+    });
+  }
+  return refreshPromise;
+};
+
+const redirectToLogin = () => {
+  localStorage.removeItem('auth_token');
+  localStorage.removeItem('refresh_token');
+  localStorage.removeItem('user_data');
+  window.location.href = '/login';
+};
+
 // ===== RESPONSE INTERCEPTOR =====
 // Trata erros globalmente e extrai o campo 'data' das respostas
 apiClient.interceptors.response.use(
@@ -42,12 +76,20 @@ apiClient.interceptors.response.use(
     }
     return response;
   },
-  (error: AxiosError) => {
-    // Token expirado ou inválido
-    if (error.response?.status === 401) {
-      localStorage.removeItem('auth_token');
-      localStorage.removeItem('user_data');
-      window.location.href = '/login';
+  async (error: AxiosError) => {
+    // Token expirado ou inválido: tenta renovar uma vez antes de mandar para o login
+    const original = error.config as (InternalAxiosRequestConfig & { _retried?: boolean }) | undefined;
+    const isAuthRequest = original?.url?.startsWith('/auth/login') || original?.url?.startsWith('/auth/register');
+    if (error.response?.status === 401 && original && !isAuthRequest) {
+      if (!original._retried) {
+        original._retried = true;
+        const token = await refreshAccessToken();
+        if (token) {
+          original.headers.Authorization = `Bearer ${token}`;
+          return apiClient(original);
+        }
+      }
+      redirectToLogin();
     }
 
     // Forbidden
@@ -119,6 +161,14 @@ export const storage = {
     localStorage.removeItem('auth_token');
   },
   
+  setRefreshToken: (token: string) => {
+    localStorage.setItem('refresh_token', token);
+  },
+  
+  getRefreshToken: (): string | null => {
+    return localStorage.getItem('refresh_token');
+  },
+  
   setUser: (user: any) => {
     localStorage.setItem('user_data', JSON.stringify(user));
   },
@@ -134,6 +184,7 @@ export const storage = {
   
   clear: () => {
     localStorage.removeItem('auth_token');
+    localStorage.removeItem('refresh_token');
     localStorage.removeItem('user_data');
   },
 };
@@ -17,6 +17,7 @@ export interface RegisterRequest {
 
 export interface AuthResponse {
   token: string;
+  refresh_token: string;
   user: User;
 }
 
@@ -43,6 +44,7 @@ export interface FamilyMember {
   email?: string;
   role: 'owner' | 'member' | 'dependent';
   is_active: boolean;
+  irpf_declarant_member_id?: number | null;
   created_at: string;
 }
 